@@ -0,0 +1,89 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/errors"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	genericoptions "k8s.io/apiserver/pkg/server/options"
+	"k8s.io/klog/v2"
+
+	"github.com/gocrane-io/api/pkg/algorithm"
+	"github.com/gocrane-io/api/pkg/apiserver"
+	"github.com/gocrane-io/api/pkg/controller"
+	"github.com/gocrane-io/api/pkg/externalmetrics"
+	"github.com/gocrane-io/api/pkg/generated/clientset/versioned"
+	"github.com/gocrane-io/api/pkg/generated/informers/externalversions"
+	"github.com/gocrane-io/api/pkg/metricsource"
+	"github.com/gocrane-io/api/pkg/version"
+	queryv1alpha1 "github.com/gocrane-io/api/query/v1alpha1"
+)
+
+func main() {
+	var (
+		metricFile   string
+		resync       time.Duration
+		lookahead    time.Duration
+		printVersion bool
+	)
+	// the queries are not stored and need no admission.
+	options := genericoptions.NewRecommendedOptions("", apiserver.Codecs.LegacyCodec(queryv1alpha1.SchemeGroupVersion))
+	options.Etcd = nil
+	options.Admission = nil
+
+	// the klog flags are already registered in the go flags by the logs of the generic API server.
+	fs := pflag.CommandLine
+	fs.AddGoFlagSet(flag.CommandLine)
+	options.AddFlags(fs)
+	fs.StringVar(&metricFile, "metric-file", "", "The json or csv file holding the history of the metrics.")
+	fs.DurationVar(&resync, "resync-period", 10*time.Minute, "The resync period of the prediction informers.")
+	fs.DurationVar(&lookahead, "external-metrics-lookahead", externalmetrics.DefaultLookahead, "The window of the predictions whose maximum is served as external metric.")
+	fs.BoolVar(&printVersion, "version", false, "Print version information and quit.")
+	pflag.Parse()
+
+	if printVersion {
+		fmt.Println(version.GetVersionInfo())
+		os.Exit(0)
+	}
+
+	if metricFile == "" {
+		klog.Fatal("--metric-file is required")
+	}
+	source, err := metricsource.NewFileSource(metricFile)
+	if err != nil {
+		klog.Fatalf("Failed to load metric file: %v", err)
+	}
+
+	if err := options.SecureServing.MaybeDefaultWithSelfSignedCerts("localhost", nil, []net.IP{net.ParseIP("127.0.0.1")}); err != nil {
+		klog.Fatalf("Failed to create self-signed certificates: %v", err)
+	}
+	if err := errors.NewAggregate(options.Validate()); err != nil {
+		klog.Fatalf("Invalid options: %v", err)
+	}
+
+	// the recommended config provides the kube client config and informers, the pod and workload informers are
+	// started with the server.
+	config := apiserver.NewConfig(apiserver.ExtraConfig{})
+	if err := options.ApplyTo(config.GenericConfig); err != nil {
+		klog.Fatalf("Failed to apply options: %v", err)
+	}
+	predictionClient := versioned.NewForConfigOrDie(config.GenericConfig.ClientConfig)
+	config.ExtraConfig.PredictionInformers = externalversions.NewSharedInformerFactory(predictionClient, resync)
+	config.ExtraConfig.Forecaster = controller.NewForecaster(config.GenericConfig.SharedInformerFactory, source, algorithm.DefaultRegistry)
+	config.ExtraConfig.ExternalMetrics = externalmetrics.NewProvider(
+		config.ExtraConfig.PredictionInformers.Prediction().V1alpha1().PodGroupPredictions().Lister(), lookahead)
+
+	server, err := config.Complete().New()
+	if err != nil {
+		klog.Fatalf("Failed to create server: %v", err)
+	}
+	klog.Infof("Starting prediction API server, version %s", version.GetVersionInfo())
+	if err := server.GenericAPIServer.PrepareRun().Run(genericapiserver.SetupSignalHandler()); err != nil {
+		klog.Fatalf("Failed to run server: %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+
+	"github.com/gocrane-io/api/pkg/algorithm"
+	"github.com/gocrane-io/api/pkg/controller"
+	"github.com/gocrane-io/api/pkg/generated/clientset/versioned"
+	"github.com/gocrane-io/api/pkg/generated/informers/externalversions"
+	"github.com/gocrane-io/api/pkg/metricsource"
+	"github.com/gocrane-io/api/pkg/migration"
+	"github.com/gocrane-io/api/pkg/version"
+)
+
+func main() {
+	var (
+		kubeconfig   string
+		master       string
+		metricFile   string
+		workers      int
+		resync       time.Duration
+		printVersion bool
+	)
+	klog.InitFlags(nil)
+	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
+	flag.StringVar(&master, "master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig.")
+	flag.StringVar(&metricFile, "metric-file", "", "The json or csv file holding the history of the metrics.")
+	flag.IntVar(&workers, "workers", 2, "The number of workers reconciling each kind of prediction.")
+	flag.DurationVar(&resync, "resync-period", 10*time.Minute, "The resync period of the informers.")
+	flag.BoolVar(&printVersion, "version", false, "Print version information and quit.")
+	flag.Parse()
+
+	if printVersion {
+		fmt.Println(version.GetVersionInfo())
+		os.Exit(0)
+	}
+
+	if metricFile == "" {
+		klog.Fatal("--metric-file is required")
+	}
+	source, err := metricsource.NewFileSource(metricFile)
+	if err != nil {
+		klog.Fatalf("Failed to load metric file: %v", err)
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags(master, kubeconfig)
+	if err != nil {
+		klog.Fatalf("Failed to build kubeconfig: %v", err)
+	}
+	predictionClient := versioned.NewForConfigOrDie(config)
+	kubeClient := kubernetes.NewForConfigOrDie(config)
+	if err := migration.CheckNodePredictionScope(context.Background(), apiextensionsclient.NewForConfigOrDie(config)); err != nil {
+		klog.Fatalf("Refusing to start: %v", err)
+	}
+
+	predictionInformers := externalversions.NewSharedInformerFactory(predictionClient, resync)
+	kubeInformers := kubeinformers.NewSharedInformerFactory(kubeClient, resync)
+	c := controller.New(predictionClient, predictionInformers, kubeInformers, source, algorithm.DefaultRegistry)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	predictionInformers.Start(ctx.Done())
+	kubeInformers.Start(ctx.Done())
+
+	klog.Infof("Starting prediction controller, version %s", version.GetVersionInfo())
+	if err := c.Run(ctx, workers); err != nil {
+		klog.Fatalf("Failed to run controller: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+
+	"github.com/gocrane-io/api/pkg/exporter"
+	"github.com/gocrane-io/api/pkg/generated/clientset/versioned"
+	"github.com/gocrane-io/api/pkg/generated/informers/externalversions"
+	"github.com/gocrane-io/api/pkg/version"
+)
+
+func main() {
+	var (
+		kubeconfig   string
+		master       string
+		bindAddress  string
+		maxHorizon   time.Duration
+		resync       time.Duration
+		printVersion bool
+	)
+	klog.InitFlags(nil)
+	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
+	flag.StringVar(&master, "master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig.")
+	flag.StringVar(&bindAddress, "bind-address", ":9100", "The address the metrics are served on, at /metrics.")
+	flag.DurationVar(&maxHorizon, "max-horizon", time.Hour, "The largest horizon of the exported forecast points, 0 exports every point.")
+	flag.DurationVar(&resync, "resync-period", 10*time.Minute, "The resync period of the informers.")
+	flag.BoolVar(&printVersion, "version", false, "Print version information and quit.")
+	flag.Parse()
+
+	if printVersion {
+		fmt.Println(version.GetVersionInfo())
+		os.Exit(0)
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags(master, kubeconfig)
+	if err != nil {
+		klog.Fatalf("Failed to build kubeconfig: %v", err)
+	}
+	predictionClient := versioned.NewForConfigOrDie(config)
+	predictionInformers := externalversions.NewSharedInformerFactory(predictionClient, resync)
+	informers := predictionInformers.Prediction().V1alpha1()
+	collector := exporter.NewCollector(informers.PodGroupPredictions().Lister(), informers.NodePredictions().Lister(), maxHorizon)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector, collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	server := &http.Server{Addr: bindAddress, Handler: mux}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	predictionInformers.Start(ctx.Done())
+	predictionInformers.WaitForCacheSync(ctx.Done())
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	klog.Infof("Starting prediction exporter on %s, version %s", bindAddress, version.GetVersionInfo())
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		klog.Fatalf("Failed to serve metrics: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+
+	"github.com/gocrane-io/api/pkg/generated/clientset/versioned"
+	"github.com/gocrane-io/api/pkg/generated/informers/externalversions"
+	"github.com/gocrane-io/api/pkg/grafana"
+	"github.com/gocrane-io/api/pkg/version"
+)
+
+func main() {
+	var (
+		kubeconfig   string
+		master       string
+		bindAddress  string
+		resync       time.Duration
+		printVersion bool
+	)
+	klog.InitFlags(nil)
+	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
+	flag.StringVar(&master, "master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig.")
+	flag.StringVar(&bindAddress, "bind-address", ":8080", "The address the datasource is served on.")
+	flag.DurationVar(&resync, "resync-period", 10*time.Minute, "The resync period of the informers.")
+	flag.BoolVar(&printVersion, "version", false, "Print version information and quit.")
+	flag.Parse()
+
+	if printVersion {
+		fmt.Println(version.GetVersionInfo())
+		os.Exit(0)
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags(master, kubeconfig)
+	if err != nil {
+		klog.Fatalf("Failed to build kubeconfig: %v", err)
+	}
+	predictionClient := versioned.NewForConfigOrDie(config)
+	predictionInformers := externalversions.NewSharedInformerFactory(predictionClient, resync)
+	informers := predictionInformers.Prediction().V1alpha1()
+	handler := grafana.NewHandler(informers.PodGroupPredictions().Lister(), informers.NodePredictions().Lister())
+	server := &http.Server{Addr: bindAddress, Handler: handler}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	predictionInformers.Start(ctx.Done())
+	predictionInformers.WaitForCacheSync(ctx.Done())
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	klog.Infof("Starting prediction grafana datasource on %s, version %s", bindAddress, version.GetVersionInfo())
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		klog.Fatalf("Failed to serve datasource: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+
+	"github.com/gocrane-io/api/pkg/migration"
+	"github.com/gocrane-io/api/pkg/version"
+)
+
+func main() {
+	var (
+		kubeconfig   string
+		master       string
+		crdFile      string
+		backupFile   string
+		printVersion bool
+	)
+	klog.InitFlags(nil)
+	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
+	flag.StringVar(&master, "master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig.")
+	flag.StringVar(&crdFile, "crd-file", "artifacts/deploy/prediction.crane.io_nodepredictions.yaml", "The cluster scoped NodePrediction CustomResourceDefinition.")
+	flag.StringVar(&backupFile, "backup-file", "nodepredictions-backup.json", "The file the namespaced NodePredictions are saved to before they are deleted.")
+	flag.BoolVar(&printVersion, "version", false, "Print version information and quit.")
+	flag.Parse()
+
+	if printVersion {
+		fmt.Println(version.GetVersionInfo())
+		os.Exit(0)
+	}
+
+	if err := migrate(kubeconfig, master, crdFile, backupFile); err != nil {
+		klog.Fatalf("Failed to migrate the NodePredictions: %v", err)
+	}
+	klog.Infof("Migrated the NodePredictions, the former ones are saved in %s", backupFile)
+}
+
+// migrate returns its errors rather than exiting, so that the backup file is flushed and closed.
+func migrate(kubeconfig, master, crdFile, backupFile string) (err error) {
+	crd, err := readCRD(crdFile)
+	if err != nil {
+		return fmt.Errorf("failed to read the CustomResourceDefinition: %v", err)
+	}
+	config, err := clientcmd.BuildConfigFromFlags(master, kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to build kubeconfig: %v", err)
+	}
+	crdClient, err := apiextensionsclient.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	migrator := migration.NewNodePredictionMigrator(crdClient, dynamicClient)
+
+	backup, err := os.OpenFile(backupFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create the backup file: %v", err)
+	}
+	defer func() {
+		if closeErr := backup.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close the backup file: %v", closeErr)
+		}
+	}()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	return migrator.Migrate(ctx, crd, backup)
+}
+
+func readCRD(file string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	decoder := yaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		// the generated manifests start with an empty document
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := decoder.Decode(crd); err != nil {
+			return nil, err
+		}
+		if crd.Name != "" {
+			return crd, nil
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+
+	"github.com/gocrane-io/api/pkg/generated/clientset/versioned"
+	"github.com/gocrane-io/api/pkg/generated/informers/externalversions"
+	"github.com/gocrane-io/api/pkg/remotewrite"
+	"github.com/gocrane-io/api/pkg/version"
+)
+
+func main() {
+	var (
+		kubeconfig   string
+		master       string
+		url          string
+		timeout      time.Duration
+		resync       time.Duration
+		printVersion bool
+	)
+	queueConfig := remotewrite.DefaultQueueConfig()
+	klog.InitFlags(nil)
+	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
+	flag.StringVar(&master, "master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig.")
+	flag.StringVar(&url, "url", "", "The url of the remote write endpoint, for example http://prometheus:9090/api/v1/write.")
+	flag.DurationVar(&timeout, "timeout", 30*time.Second, "The timeout of a remote write request.")
+	flag.IntVar(&queueConfig.Capacity, "queue-capacity", queueConfig.Capacity, "The largest number of samples waiting to be sent.")
+	flag.IntVar(&queueConfig.MaxSamplesPerSend, "max-samples-per-send", queueConfig.MaxSamplesPerSend, "The number of samples a request is sent at.")
+	flag.DurationVar(&queueConfig.BatchSendDeadline, "batch-send-deadline", queueConfig.BatchSendDeadline, "The longest time a series waits to be sent.")
+	flag.DurationVar(&queueConfig.MaxBackoff, "max-backoff", queueConfig.MaxBackoff, "The longest delay between the retries of a request.")
+	flag.DurationVar(&resync, "resync-period", 10*time.Minute, "The resync period of the informers.")
+	flag.BoolVar(&printVersion, "version", false, "Print version information and quit.")
+	flag.Parse()
+
+	if printVersion {
+		fmt.Println(version.GetVersionInfo())
+		os.Exit(0)
+	}
+
+	if url == "" {
+		klog.Fatal("--url is required")
+	}
+	config, err := clientcmd.BuildConfigFromFlags(master, kubeconfig)
+	if err != nil {
+		klog.Fatalf("Failed to build kubeconfig: %v", err)
+	}
+	predictionClient := versioned.NewForConfigOrDie(config)
+	predictionInformers := externalversions.NewSharedInformerFactory(predictionClient, resync)
+	queue := remotewrite.NewQueue(remotewrite.NewClient(url, &http.Client{Timeout: timeout}), queueConfig)
+	remotewrite.NewSink(predictionInformers, queue)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	predictionInformers.Start(ctx.Done())
+
+	klog.Infof("Starting prediction remote write to %s, version %s", url, version.GetVersionInfo())
+	queue.Run(ctx)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"k8s.io/klog/v2"
+
+	"github.com/gocrane-io/api/pkg/version"
+	"github.com/gocrane-io/api/pkg/webhook"
+)
+
+func main() {
+	var (
+		bindAddress  string
+		certFile     string
+		keyFile      string
+		printVersion bool
+	)
+	klog.InitFlags(nil)
+	flag.StringVar(&bindAddress, "bind-address", ":9443", "The address the webhook server listens on.")
+	flag.StringVar(&certFile, "tls-cert-file", "/etc/webhook/certs/tls.crt", "File containing the x509 certificate for HTTPS.")
+	flag.StringVar(&keyFile, "tls-private-key-file", "/etc/webhook/certs/tls.key", "File containing the x509 private key matching --tls-cert-file.")
+	flag.BoolVar(&printVersion, "version", false, "Print version information and quit.")
+	flag.Parse()
+
+	if printVersion {
+		fmt.Println(version.GetVersionInfo())
+		os.Exit(0)
+	}
+
+	klog.Infof("Starting prediction webhook server on %s, version %s", bindAddress, version.GetVersionInfo())
+	server := &http.Server{
+		Addr:    bindAddress,
+		Handler: webhook.NewHandler(),
+	}
+	if err := server.ListenAndServeTLS(certFile, keyFile); err != nil {
+		klog.Fatalf("Failed to serve webhook: %v", err)
+	}
+}
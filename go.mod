@@ -3,9 +3,18 @@ module github.com/gocrane-io/api
 go 1.16
 
 require (
+	github.com/golang/snappy v1.0.0
 	github.com/onsi/ginkgo v1.16.5
+	github.com/prometheus/client_golang v1.11.0
+	github.com/prometheus/common v0.26.0
+	github.com/spf13/pflag v1.0.5
+	google.golang.org/protobuf v1.26.0
 	k8s.io/api v0.22.3
+	k8s.io/apiextensions-apiserver v0.22.3
 	k8s.io/apimachinery v0.22.3
+	k8s.io/apiserver v0.22.3
 	k8s.io/client-go v0.22.3
 	k8s.io/code-generator v0.22.3
+	k8s.io/klog/v2 v2.9.0
+	k8s.io/metrics v0.22.3
 )
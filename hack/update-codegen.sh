@@ -8,30 +8,52 @@ set -o pipefail
 REPO_ROOT=$(git rev-parse --show-toplevel)
 cd "${REPO_ROOT}"
 
+API_PACKAGES="github.com/gocrane-io/api/prediction/v1alpha1,github.com/gocrane-io/api/prediction/v1beta1,github.com/gocrane-io/api/query/v1alpha1"
+
 echo "Generating with deepcopy-gen"
 GO111MODULE=on go install k8s.io/code-generator/cmd/deepcopy-gen
 export GOPATH=$(go env GOPATH | awk -F ':' '{print $1}')
 export PATH=$PATH:$GOPATH/bin
-deepcopy-gen \
-  --go-header-file hack/boilerplate/boilerplate.go.txt \
-  --input-dirs=github.com/gocrane-io/api/prediction/v1alpha1 \
-  --output-package=github.com/gocrane-io/api/prediction/v1alpha1 \
-  --output-file-base=zz_generated.deepcopy
+for package in ${API_PACKAGES//,/ }; do
+  deepcopy-gen \
+    --go-header-file hack/boilerplate/boilerplate.go.txt \
+    --input-dirs=${package} \
+    --output-package=${package} \
+    --output-file-base=zz_generated.deepcopy
+done
 
 echo "Generating with register-gen"
 GO111MODULE=on go install k8s.io/code-generator/cmd/register-gen
-register-gen \
+for package in ${API_PACKAGES//,/ }; do
+  register-gen \
+    --go-header-file hack/boilerplate/boilerplate.go.txt \
+    --input-dirs=${package} \
+    --output-package=${package} \
+    --output-file-base=zz_generated.register
+done
+
+echo "Generating with defaulter-gen"
+GO111MODULE=on go install k8s.io/code-generator/cmd/defaulter-gen
+defaulter-gen \
   --go-header-file hack/boilerplate/boilerplate.go.txt \
   --input-dirs=github.com/gocrane-io/api/prediction/v1alpha1 \
   --output-package=github.com/gocrane-io/api/prediction/v1alpha1 \
-  --output-file-base=zz_generated.register
+  --output-file-base=zz_generated.defaults
+
+echo "Generating with conversion-gen"
+GO111MODULE=on go install k8s.io/code-generator/cmd/conversion-gen
+conversion-gen \
+  --go-header-file hack/boilerplate/boilerplate.go.txt \
+  --input-dirs=github.com/gocrane-io/api/prediction/v1beta1 \
+  --output-package=github.com/gocrane-io/api/prediction/v1beta1 \
+  --output-file-base=zz_generated.conversion
 
 echo "Generating with client-gen"
 GO111MODULE=on go install k8s.io/code-generator/cmd/client-gen
 client-gen \
   --go-header-file hack/boilerplate/boilerplate.go.txt \
   --input-base="" \
-  --input=github.com/gocrane-io/api/prediction/v1alpha1 \
+  --input=${API_PACKAGES} \
   --output-package=github.com/gocrane-io/api/pkg/generated/clientset \
   --clientset-name=versioned
 
@@ -39,7 +61,7 @@ echo "Generating with lister-gen"
 GO111MODULE=on go install k8s.io/code-generator/cmd/lister-gen
 lister-gen \
   --go-header-file hack/boilerplate/boilerplate.go.txt \
-  --input-dirs=github.com/gocrane-io/api/prediction/v1alpha1 \
+  --input-dirs=${API_PACKAGES} \
   --output-package=github.com/gocrane-io/api/pkg/generated/listers
 
 
@@ -47,7 +69,7 @@ echo "Generating with informer-gen"
 GO111MODULE=on go install k8s.io/code-generator/cmd/informer-gen
 informer-gen \
   --go-header-file hack/boilerplate/boilerplate.go.txt \
-  --input-dirs=github.com/gocrane-io/api/prediction/v1alpha1 \
+  --input-dirs=${API_PACKAGES} \
   --versioned-clientset-package=github.com/gocrane-io/api/pkg/generated/clientset/versioned \
   --listers-package=github.com/gocrane-io/api/pkg/generated/listers \
   --output-package=github.com/gocrane-io/api/pkg/generated/informers
@@ -0,0 +1,220 @@
+// Package algorithm selects the estimator of a metric from its AlgorithmProviderConfig. The built-in
+// algorithms are registered under the names of their config fields, in-house algorithms are registered
+// under their own name and selected by the Custom config.
+package algorithm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/gocrane-io/api/pkg/estimator"
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+)
+
+// The names of the built-in algorithms and of the ensemble, which is not an algorithm of the registry. They are
+// reserved, see v1alpha1.IsReservedAlgorithmName.
+const (
+	DSP        = v1alpha1.AlgorithmDSP
+	Percentile = v1alpha1.AlgorithmPercentile
+	Ensemble   = v1alpha1.AlgorithmEnsemble
+)
+
+// Algorithm builds the estimator of a metric from its config.
+type Algorithm interface {
+	// NewEstimator returns the estimator of the defaulted config.
+	NewEstimator(config *v1alpha1.AlgorithmProviderConfig) (estimator.Estimator, error)
+}
+
+// AlgorithmFunc adapts a function to an Algorithm.
+type AlgorithmFunc func(config *v1alpha1.AlgorithmProviderConfig) (estimator.Estimator, error)
+
+// NewEstimator implements Algorithm.
+func (f AlgorithmFunc) NewEstimator(config *v1alpha1.AlgorithmProviderConfig) (estimator.Estimator, error) {
+	return f(config)
+}
+
+// Registry holds algorithms by name, it is safe for concurrent use.
+type Registry struct {
+	lock       sync.RWMutex
+	algorithms map[string]Algorithm
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{algorithms: make(map[string]Algorithm)}
+}
+
+// NewDefaultRegistry returns a registry of the built-in algorithms.
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.algorithms[DSP] = AlgorithmFunc(newDSP)
+	r.algorithms[Percentile] = AlgorithmFunc(newPercentile)
+	return r
+}
+
+// DefaultRegistry is the registry of the prediction controller, in-house algorithms register themselves
+// into it, typically from an init function.
+var DefaultRegistry = NewDefaultRegistry()
+
+// Register registers an algorithm into the DefaultRegistry.
+func Register(name string, algorithm Algorithm) error {
+	return DefaultRegistry.Register(name, algorithm)
+}
+
+// Register registers an algorithm under a name, it fails if the name is already registered or is the name of
+// a built-in algorithm.
+func (r *Registry) Register(name string, algorithm Algorithm) error {
+	if name == "" {
+		return fmt.Errorf("algorithm name must not be empty")
+	}
+	if v1alpha1.IsReservedAlgorithmName(name) {
+		return fmt.Errorf("algorithm name %q is reserved", name)
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.algorithms[name]; ok {
+		return fmt.Errorf("algorithm %q is already registered", name)
+	}
+	r.algorithms[name] = algorithm
+	return nil
+}
+
+// MustRegister is like Register but panics on error.
+func (r *Registry) MustRegister(name string, algorithm Algorithm) {
+	if err := r.Register(name, algorithm); err != nil {
+		panic(err)
+	}
+}
+
+// Get returns the algorithm registered under the name.
+func (r *Registry) Get(name string) (Algorithm, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	algorithm, ok := r.algorithms[name]
+	return algorithm, ok
+}
+
+// Names returns the sorted names of the registered algorithms.
+func (r *Registry) Names() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	names := make([]string, 0, len(r.algorithms))
+	for name := range r.algorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewEstimator returns the estimator of the metric: the chain of its algorithm, or its ensemble, followed by
+// its fallbacks.
+func (r *Registry) NewEstimator(config *v1alpha1.AlgorithmProviderConfig) (estimator.Tracer, error) {
+	var primary estimator.Named
+	if config.Ensemble != nil {
+		ensemble := make(estimator.Ensemble, 0, len(config.Ensemble.Members))
+		for i := range config.Ensemble.Members {
+			member := &config.Ensemble.Members[i]
+			named, err := r.newEstimator(config.MetricName, &member.AlgorithmConfig)
+			if err != nil {
+				return nil, err
+			}
+			ensemble = append(ensemble, estimator.EnsembleMember{Named: named, Weight: float64(member.Weight)})
+		}
+		primary = estimator.Named{Name: Ensemble, Estimator: ensemble}
+	} else {
+		var err error
+		primary, err = r.newEstimator(config.MetricName, &v1alpha1.AlgorithmConfig{DSP: config.DSP, Percentile: config.Percentile, Custom: config.Custom})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	chain := estimator.Chain{primary}
+	for i := range config.Fallbacks {
+		named, err := r.newEstimator(config.MetricName, &config.Fallbacks[i])
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, named)
+	}
+	return chain, nil
+}
+
+// newEstimator returns the estimator of a single algorithm, named after the algorithm.
+func (r *Registry) newEstimator(metricName string, config *v1alpha1.AlgorithmConfig) (estimator.Named, error) {
+	providerConfig := &v1alpha1.AlgorithmProviderConfig{MetricName: metricName, DSP: config.DSP, Percentile: config.Percentile, Custom: config.Custom}
+	name, err := Name(providerConfig)
+	if err != nil {
+		return estimator.Named{}, err
+	}
+	algorithm, ok := r.Get(name)
+	if !ok {
+		return estimator.Named{}, fmt.Errorf("algorithm %q of metric %s is not registered", name, metricName)
+	}
+	e, err := algorithm.NewEstimator(providerConfig)
+	if err != nil {
+		return estimator.Named{}, err
+	}
+	return estimator.Named{Name: name, Estimator: e}, nil
+}
+
+// Predict estimates the metric with the estimator of the config and returns the name of the estimator that
+// produced the estimation, it makes the registry usable as the Predictor of the prediction controller.
+func (r *Registry) Predict(config *v1alpha1.AlgorithmProviderConfig, history timeseries.Series, timestamps []int64) (timeseries.Series, string, error) {
+	e, err := r.NewEstimator(config)
+	if err != nil {
+		return nil, "", err
+	}
+	return e.EstimateTraced(history, timestamps)
+}
+
+// Name returns the name of the algorithm selected by the config, Ensemble for an ensemble.
+func Name(config *v1alpha1.AlgorithmProviderConfig) (string, error) {
+	switch {
+	case config.Ensemble != nil:
+		return Ensemble, nil
+	case config.DSP != nil:
+		return DSP, nil
+	case config.Percentile != nil:
+		return Percentile, nil
+	case config.Custom != nil:
+		if v1alpha1.IsReservedAlgorithmName(config.Custom.Name) {
+			return "", fmt.Errorf("custom algorithm name %q of metric %s is reserved", config.Custom.Name, config.MetricName)
+		}
+		return config.Custom.Name, nil
+	}
+	return "", fmt.Errorf("no algorithm is configured for metric %s", config.MetricName)
+}
+
+// DecodeParameters decodes the parameters of a Custom config into out, typically a pointer to the typed
+// parameters of the algorithm. Unknown fields are rejected so that typos do not go unnoticed, missing
+// parameters leave out untouched.
+func DecodeParameters(config *v1alpha1.CustomAlgorithmConfig, out interface{}) error {
+	if config.Parameters == nil || len(config.Parameters.Raw) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(config.Parameters.Raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(out); err != nil {
+		return fmt.Errorf("invalid parameters of algorithm %q: %v", config.Name, err)
+	}
+	return nil
+}
+
+func newDSP(config *v1alpha1.AlgorithmProviderConfig) (estimator.Estimator, error) {
+	if config.DSP == nil {
+		return nil, fmt.Errorf("algorithm %q requires the dsp config", DSP)
+	}
+	return estimator.NewDSP(config.DSP)
+}
+
+func newPercentile(config *v1alpha1.AlgorithmProviderConfig) (estimator.Estimator, error) {
+	if config.Percentile == nil {
+		return nil, fmt.Errorf("algorithm %q requires the percentile config", Percentile)
+	}
+	return estimator.NewPercentileEstimator(config.Percentile, estimator.DefaultPercentile)
+}
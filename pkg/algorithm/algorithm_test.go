@@ -0,0 +1,264 @@
+package algorithm
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/gocrane-io/api/pkg/estimator"
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// constantParameters are the parameters of the constant algorithm.
+type constantParameters struct {
+	Value float64 `json:"value"`
+}
+
+// constantAlgorithm estimates the value of its parameters, or fails with ErrInsufficientHistory when the
+// value is negative.
+var constantAlgorithm = AlgorithmFunc(func(config *v1alpha1.AlgorithmProviderConfig) (estimator.Estimator, error) {
+	parameters := constantParameters{}
+	if err := DecodeParameters(config.Custom, &parameters); err != nil {
+		return nil, err
+	}
+	return constantEstimator(parameters.Value), nil
+})
+
+type constantEstimator float64
+
+func (c constantEstimator) Estimate(_ timeseries.Series, timestamps []int64) (timeseries.Series, error) {
+	if c < 0 {
+		return nil, estimator.ErrInsufficientHistory
+	}
+	var s timeseries.Series
+	for _, t := range timestamps {
+		s = append(s, helper.Sample{Timestamp: t, Value: float64(c)})
+	}
+	return s, nil
+}
+
+func custom(name, parameters string) *v1alpha1.CustomAlgorithmConfig {
+	config := &v1alpha1.CustomAlgorithmConfig{Name: name}
+	if parameters != "" {
+		config.Parameters = &runtime.RawExtension{Raw: []byte(parameters)}
+	}
+	return config
+}
+
+func TestRegister(t *testing.T) {
+	r := NewDefaultRegistry()
+	cases := []struct {
+		name string
+		err  string
+	}{
+		{name: "example.com/constant"},
+		{name: "example.com/constant", err: "already registered"},
+		{name: DSP, err: "reserved"},
+		{name: Percentile, err: "reserved"},
+		{name: Ensemble, err: "reserved"},
+		{name: "", err: "must not be empty"},
+	}
+	for _, c := range cases {
+		err := r.Register(c.name, constantAlgorithm)
+		switch {
+		case c.err == "" && err != nil:
+			t.Errorf("%q: unexpected error: %v", c.name, err)
+		case c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)):
+			t.Errorf("%q: expected an error containing %q, got %v", c.name, c.err, err)
+		}
+	}
+	if names := strings.Join(r.Names(), ","); names != "dsp,example.com/constant,percentile" {
+		t.Errorf("unexpected names %s", names)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected MustRegister to panic on a duplicate name")
+			}
+		}()
+		r.MustRegister("example.com/constant", constantAlgorithm)
+	}()
+}
+
+func TestName(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   v1alpha1.AlgorithmProviderConfig
+		expected string
+		err      bool
+	}{
+		{
+			name:     "ensemble first",
+			config:   v1alpha1.AlgorithmProviderConfig{Ensemble: &v1alpha1.EnsembleConfig{}, DSP: &v1alpha1.DspConfig{}, Custom: custom("example.com/constant", "")},
+			expected: Ensemble,
+		},
+		{
+			name:     "dsp before percentile",
+			config:   v1alpha1.AlgorithmProviderConfig{DSP: &v1alpha1.DspConfig{}, Percentile: &v1alpha1.PercentileConfig{}},
+			expected: DSP,
+		},
+		{
+			name:     "percentile before custom",
+			config:   v1alpha1.AlgorithmProviderConfig{Percentile: &v1alpha1.PercentileConfig{}, Custom: custom("example.com/constant", "")},
+			expected: Percentile,
+		},
+		{
+			name:     "custom",
+			config:   v1alpha1.AlgorithmProviderConfig{Custom: custom("example.com/constant", "")},
+			expected: "example.com/constant",
+		},
+		{
+			name:   "reserved custom name",
+			config: v1alpha1.AlgorithmProviderConfig{Custom: custom(DSP, "")},
+			err:    true,
+		},
+		{
+			name: "nothing",
+			err:  true,
+		},
+	}
+	for _, c := range cases {
+		name, err := Name(&c.config)
+		switch {
+		case c.err && err == nil:
+			t.Errorf("%s: expected an error, got %s", c.name, name)
+		case !c.err && err != nil:
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+		case name != c.expected:
+			t.Errorf("%s: expected %s, got %s", c.name, c.expected, name)
+		}
+	}
+}
+
+func TestDecodeParameters(t *testing.T) {
+	cases := []struct {
+		name       string
+		parameters string
+		expected   float64
+		err        bool
+	}{
+		{name: "parameters", parameters: `{"value": 2.5}`, expected: 2.5},
+		{name: "no parameters", expected: 1},
+		{name: "missing parameter", parameters: `{}`, expected: 1},
+		{name: "unknown field", parameters: `{"value": 2.5, "vlaue": 3}`, err: true},
+		{name: "wrong type", parameters: `{"value": "2.5"}`, err: true},
+	}
+	for _, c := range cases {
+		parameters := constantParameters{Value: 1}
+		err := DecodeParameters(custom("example.com/constant", c.parameters), &parameters)
+		switch {
+		case c.err && err == nil:
+			t.Errorf("%s: expected an error, got %v", c.name, parameters)
+		case !c.err && err != nil:
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+		case !c.err && parameters.Value != c.expected:
+			t.Errorf("%s: expected %g, got %g", c.name, c.expected, parameters.Value)
+		}
+	}
+}
+
+func TestNewEstimator(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister("constant", constantAlgorithm)
+	member := func(value string, weight int32) v1alpha1.EnsembleMember {
+		return v1alpha1.EnsembleMember{AlgorithmConfig: v1alpha1.AlgorithmConfig{Custom: custom("constant", `{"value": `+value+`}`)}, Weight: weight}
+	}
+	cases := []struct {
+		name     string
+		config   v1alpha1.AlgorithmProviderConfig
+		chain    int
+		value    float64
+		estimate string
+	}{
+		{
+			name:     "algorithm",
+			config:   v1alpha1.AlgorithmProviderConfig{Custom: custom("constant", `{"value": 2}`)},
+			chain:    1,
+			value:    2,
+			estimate: "constant",
+		},
+		{
+			name: "ensemble",
+			config: v1alpha1.AlgorithmProviderConfig{Ensemble: &v1alpha1.EnsembleConfig{Members: []v1alpha1.EnsembleMember{
+				member("1", 3), member("-1", 1), member("5", 1),
+			}}},
+			chain:    1,
+			value:    2,
+			estimate: "ensemble(constant:3,constant:1)",
+		},
+		{
+			name: "fallbacks",
+			config: v1alpha1.AlgorithmProviderConfig{
+				Custom: custom("constant", `{"value": -1}`),
+				Fallbacks: []v1alpha1.AlgorithmConfig{
+					{Custom: custom("constant", `{"value": -2}`)},
+					{Custom: custom("constant", `{"value": 4}`)},
+				},
+			},
+			chain:    3,
+			value:    4,
+			estimate: "constant",
+		},
+	}
+	for _, c := range cases {
+		c.config.MetricName = "cpu"
+		e, err := r.NewEstimator(&c.config)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+		chain, ok := e.(estimator.Chain)
+		if !ok || len(chain) != c.chain {
+			t.Errorf("%s: expected a chain of %d estimators, got %#v", c.name, c.chain, e)
+		}
+		estimation, name, err := r.Predict(&c.config, nil, []int64{60})
+		if err != nil || len(estimation) != 1 || estimation[0].Value != c.value || name != c.estimate {
+			t.Errorf("%s: expected %g by %s, got %v by %s, %v", c.name, c.value, c.estimate, estimation, name, err)
+		}
+	}
+}
+
+func TestNewEstimatorErrors(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister("constant", constantAlgorithm)
+	cases := []struct {
+		name   string
+		config v1alpha1.AlgorithmProviderConfig
+		err    string
+	}{
+		{
+			name:   "not registered",
+			config: v1alpha1.AlgorithmProviderConfig{Custom: custom("example.com/missing", "")},
+			err:    `"example.com/missing" of metric cpu is not registered`,
+		},
+		{
+			name:   "invalid parameters",
+			config: v1alpha1.AlgorithmProviderConfig{Custom: custom("constant", `{"values": 1}`)},
+			err:    "invalid parameters",
+		},
+		{
+			name: "invalid member",
+			config: v1alpha1.AlgorithmProviderConfig{Ensemble: &v1alpha1.EnsembleConfig{Members: []v1alpha1.EnsembleMember{
+				{AlgorithmConfig: v1alpha1.AlgorithmConfig{Custom: custom("constant", `{"values": 1}`)}},
+			}}},
+			err: "invalid parameters",
+		},
+		{
+			name: "invalid fallback",
+			config: v1alpha1.AlgorithmProviderConfig{
+				Custom:    custom("constant", ""),
+				Fallbacks: []v1alpha1.AlgorithmConfig{{}},
+			},
+			err: "no algorithm is configured",
+		},
+	}
+	for _, c := range cases {
+		c.config.MetricName = "cpu"
+		if _, err := r.NewEstimator(&c.config); err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s: expected an error containing %q, got %v", c.name, c.err, err)
+		}
+	}
+}
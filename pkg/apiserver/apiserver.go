@@ -0,0 +1,98 @@
+// Package apiserver is an aggregated API server answering the prediction queries of the
+// query.prediction.crane.io group with forecasts computed on demand from the prediction specs.
+package apiserver
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	restclient "k8s.io/client-go/rest"
+
+	"github.com/gocrane-io/api/pkg/externalmetrics"
+	"github.com/gocrane-io/api/pkg/generated/informers/externalversions"
+	queryv1alpha1 "github.com/gocrane-io/api/query/v1alpha1"
+)
+
+// ExtraConfig is the configuration of the server beyond the generic API server.
+type ExtraConfig struct {
+	// PredictionInformers provide the specs of the predictions, the server starts them.
+	PredictionInformers externalversions.SharedInformerFactory
+	// Forecaster forecasts the queried metrics.
+	Forecaster Forecaster
+	// ExternalMetrics, if set, serves the predictions as the external.metrics.k8s.io API group.
+	ExternalMetrics *externalmetrics.Provider
+}
+
+// Config is the configuration of the server.
+type Config struct {
+	GenericConfig *genericapiserver.RecommendedConfig
+	ExtraConfig   ExtraConfig
+}
+
+// CompletedConfig is a completed Config, see Config.Complete.
+type CompletedConfig struct {
+	genericConfig genericapiserver.CompletedConfig
+	extraConfig   *ExtraConfig
+}
+
+// NewConfig returns a Config with the default generic configuration of the scheme.
+func NewConfig(extraConfig ExtraConfig) *Config {
+	return &Config{
+		GenericConfig: genericapiserver.NewRecommendedConfig(Codecs),
+		ExtraConfig:   extraConfig,
+	}
+}
+
+// Complete fills in the fields of the configuration that are not set. A server without secure serving runs in
+// memory, serving its handler only: it is given an empty loopback client, which it does not need, and a
+// placeholder external address.
+func (c *Config) Complete() CompletedConfig {
+	if c.GenericConfig.LoopbackClientConfig == nil {
+		c.GenericConfig.LoopbackClientConfig = &restclient.Config{}
+	}
+	if c.GenericConfig.SecureServing == nil && c.GenericConfig.ExternalAddress == "" {
+		c.GenericConfig.ExternalAddress = "localhost:443"
+	}
+	return CompletedConfig{
+		genericConfig: c.GenericConfig.Complete(),
+		extraConfig:   &c.ExtraConfig,
+	}
+}
+
+// Server is the aggregated API server of the prediction queries.
+type Server struct {
+	GenericAPIServer *genericapiserver.GenericAPIServer
+}
+
+// New returns a server serving the predictionqueries resource, and the external metrics if configured. The prediction informers are started, and waited
+// for, when the server starts.
+func (c CompletedConfig) New() (*Server, error) {
+	genericServer, err := c.genericConfig.New("prediction-apiserver", genericapiserver.NewEmptyDelegate())
+	if err != nil {
+		return nil, err
+	}
+
+	informers := c.extraConfig.PredictionInformers.Prediction().V1alpha1()
+	storage := NewREST(informers.PodGroupPredictions().Lister(), informers.NodePredictions().Lister(), c.extraConfig.Forecaster)
+	apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(queryv1alpha1.GroupName, Scheme, runtime.NewParameterCodec(Scheme), Codecs)
+	apiGroupInfo.VersionedResourcesStorageMap[queryv1alpha1.SchemeGroupVersion.Version] = map[string]rest.Storage{
+		"predictionqueries": storage,
+	}
+	if err := genericServer.InstallAPIGroup(&apiGroupInfo); err != nil {
+		return nil, err
+	}
+
+	if c.extraConfig.ExternalMetrics != nil {
+		handler := externalmetrics.NewHandler(c.extraConfig.ExternalMetrics)
+		genericServer.Handler.NonGoRestfulMux.Handle(externalmetrics.PathPrefix, handler)
+		genericServer.Handler.NonGoRestfulMux.HandlePrefix(externalmetrics.PathPrefix+"/", handler)
+		genericServer.DiscoveryGroupManager.AddGroup(externalmetrics.APIGroup())
+	}
+
+	genericServer.AddPostStartHookOrDie("start-prediction-informers", func(ctx genericapiserver.PostStartHookContext) error {
+		c.extraConfig.PredictionInformers.Start(ctx.StopCh)
+		c.extraConfig.PredictionInformers.WaitForCacheSync(ctx.StopCh)
+		return nil
+	})
+	return &Server{GenericAPIServer: genericServer}, nil
+}
@@ -0,0 +1,241 @@
+package apiserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/gocrane-io/api/pkg/controller"
+	"github.com/gocrane-io/api/pkg/generated/clientset/versioned/fake"
+	"github.com/gocrane-io/api/pkg/generated/informers/externalversions"
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/predictiontest"
+	queryv1alpha1 "github.com/gocrane-io/api/query/v1alpha1"
+)
+
+// stubForecaster forecasts the value 2500 at the start of the window, or returns its error.
+type stubForecaster struct {
+	err error
+	// forecasted is the name of the last forecasted prediction and window is its window.
+	forecasted string
+	window     controller.Window
+}
+
+func (f *stubForecaster) forecast(name string, window controller.Window) (*controller.Forecast, error) {
+	f.forecasted, f.window = name, window
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &controller.Forecast{
+		Series:     timeseries.Series{{Timestamp: window.Start.Unix(), Value: 2500}},
+		Estimators: []string{"dsp(fft)"},
+	}, nil
+}
+
+func (f *stubForecaster) ForecastPodGroup(_ context.Context, pgp *v1alpha1.PodGroupPrediction, _ string, _ time.Time, window controller.Window) (*controller.Forecast, error) {
+	return f.forecast(pgp.Namespace+"/"+pgp.Name, window)
+}
+
+func (f *stubForecaster) ForecastNode(_ context.Context, np *v1alpha1.NodePrediction, _ string, _ time.Time, window controller.Window) (*controller.Forecast, error) {
+	return f.forecast(np.Name, window)
+}
+
+// newServer returns the handler of a server without secure serving whose listers are synced with the objects.
+func newServer(t *testing.T, forecaster Forecaster, predictions ...runtime.Object) *httptest.Server {
+	t.Helper()
+	informers := externalversions.NewSharedInformerFactory(fake.NewSimpleClientset(predictions...), 0)
+	config := NewConfig(ExtraConfig{PredictionInformers: informers, Forecaster: forecaster})
+	server, err := config.Complete().New()
+	if err != nil {
+		t.Fatalf("failed to create the server: %v", err)
+	}
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	informers.Start(stopCh)
+	informers.WaitForCacheSync(stopCh)
+
+	s := httptest.NewServer(server.GenericAPIServer.Handler)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// newPodGroupPrediction returns the fixture predicting the workload for 12 hours, or its pod without workload.
+func newPodGroupPrediction(name string, workload *autoscalingv2.CrossVersionObjectReference) *v1alpha1.PodGroupPrediction {
+	pgp := predictiontest.NewPodGroupPrediction("default", name)
+	pgp.Spec.Mode, pgp.Spec.PredictionLength = v1alpha1.PredictionModeRange, metav1.Duration{Duration: 12 * time.Hour}
+	if workload != nil {
+		pgp.Spec.Pods, pgp.Spec.WorkloadRef = nil, workload
+	}
+	return pgp
+}
+
+// create posts the query in the namespace and decodes the returned query or status.
+func create(t *testing.T, server *httptest.Server, namespace string, query *queryv1alpha1.PredictionQuery) (int, *queryv1alpha1.PredictionQuery, *metav1.Status) {
+	t.Helper()
+	query.APIVersion, query.Kind = queryv1alpha1.SchemeGroupVersion.String(), "PredictionQuery"
+	data, err := json.Marshal(query)
+	if err != nil {
+		t.Fatalf("failed to encode query: %v", err)
+	}
+	url := server.URL + "/apis/" + queryv1alpha1.SchemeGroupVersion.String() + "/namespaces/" + namespace + "/predictionqueries"
+	resp, err := http.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to post query: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		status := &metav1.Status{}
+		if err := json.NewDecoder(resp.Body).Decode(status); err != nil {
+			t.Fatalf("failed to decode status of code %d: %v", resp.StatusCode, err)
+		}
+		return resp.StatusCode, nil, status
+	}
+	created := &queryv1alpha1.PredictionQuery{}
+	if err := json.NewDecoder(resp.Body).Decode(created); err != nil {
+		t.Fatalf("failed to decode query: %v", err)
+	}
+	return resp.StatusCode, created, nil
+}
+
+func TestCreatePredictionQuery(t *testing.T) {
+	workload := &autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"}
+	forecaster := &stubForecaster{}
+	server := newServer(t, forecaster, newPodGroupPrediction("web", workload), newPodGroupPrediction("other", nil), predictiontest.NewNodePrediction("node-1"))
+
+	start := metav1.NewTime(predictiontest.Now)
+	end := metav1.NewTime(start.Add(time.Hour))
+	cases := []struct {
+		name       string
+		spec       queryv1alpha1.PredictionQuerySpec
+		forecasted string
+	}{
+		{
+			name:       "pod group prediction by name",
+			spec:       queryv1alpha1.PredictionQuerySpec{PodGroupPrediction: "other", MetricName: "cpu", Start: &start, End: &end},
+			forecasted: "default/other",
+		},
+		{
+			name:       "pod group prediction by workload",
+			spec:       queryv1alpha1.PredictionQuerySpec{WorkloadRef: &autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1beta1", Kind: "Deployment", Name: "web"}, MetricName: "cpu", Start: &start, End: &end},
+			forecasted: "default/web",
+		},
+		{
+			name:       "node prediction",
+			spec:       queryv1alpha1.PredictionQuerySpec{NodePrediction: "node-1", MetricName: "cpu", Start: &start, End: &end},
+			forecasted: "node-1",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			code, created, status := create(t, server, "default", &queryv1alpha1.PredictionQuery{Spec: c.spec})
+			if code != http.StatusCreated {
+				t.Fatalf("expected status %d, got %d: %+v", http.StatusCreated, code, status)
+			}
+			if forecaster.forecasted != c.forecasted {
+				t.Errorf("expected a forecast of %s, got %s", c.forecasted, forecaster.forecasted)
+			}
+			if !forecaster.window.Start.Equal(start.Time) || !forecaster.window.End.Equal(end.Time) {
+				t.Errorf("unexpected window %+v", forecaster.window)
+			}
+			if created.Namespace != "default" {
+				t.Errorf("expected namespace default, got %q", created.Namespace)
+			}
+			if len(created.Status.TimeSeries) != 1 || created.Status.TimeSeries[0].Value.MilliValue() != 2500 {
+				t.Errorf("expected a forecast of 2500 milli cores, got %+v", created.Status.TimeSeries)
+			}
+			if len(created.Status.Estimators) != 1 || created.Status.Estimators[0] != "dsp(fft)" {
+				t.Errorf("unexpected estimators %v", created.Status.Estimators)
+			}
+		})
+	}
+}
+
+func TestCreatePredictionQueryDefaultsWindow(t *testing.T) {
+	forecaster := &stubForecaster{}
+	server := newServer(t, forecaster, newPodGroupPrediction("web", nil))
+
+	code, created, status := create(t, server, "default", &queryv1alpha1.PredictionQuery{
+		Spec: queryv1alpha1.PredictionQuerySpec{PodGroupPrediction: "web", MetricName: "cpu"},
+	})
+	if code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %+v", http.StatusCreated, code, status)
+	}
+	if created.Spec.Start == nil || created.Spec.End == nil {
+		t.Fatalf("expected the window to be recorded in the spec, got %+v", created.Spec)
+	}
+	// the window spans the prediction length of the pod group prediction.
+	if length := created.Spec.End.Sub(created.Spec.Start.Time); length != 12*time.Hour {
+		t.Errorf("expected a window of 12h, got %s", length)
+	}
+}
+
+func TestCreatePredictionQueryErrors(t *testing.T) {
+	start := metav1.NewTime(predictiontest.Now)
+	cases := []struct {
+		name   string
+		err    error
+		spec   queryv1alpha1.PredictionQuerySpec
+		code   int
+		reason metav1.StatusReason
+	}{
+		{
+			name:   "invalid query",
+			spec:   queryv1alpha1.PredictionQuerySpec{PodGroupPrediction: "web"},
+			code:   http.StatusUnprocessableEntity,
+			reason: metav1.StatusReasonInvalid,
+		},
+		{
+			name:   "unknown prediction",
+			spec:   queryv1alpha1.PredictionQuerySpec{PodGroupPrediction: "missing", MetricName: "cpu"},
+			code:   http.StatusNotFound,
+			reason: metav1.StatusReasonNotFound,
+		},
+		{
+			name:   "unknown workload",
+			spec:   queryv1alpha1.PredictionQuerySpec{WorkloadRef: &autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "web"}, MetricName: "cpu"},
+			code:   http.StatusNotFound,
+			reason: metav1.StatusReasonNotFound,
+		},
+		{
+			name:   "metric not predicted",
+			err:    controller.ErrMetricNotPredicted,
+			spec:   queryv1alpha1.PredictionQuerySpec{PodGroupPrediction: "web", MetricName: "memory"},
+			code:   http.StatusUnprocessableEntity,
+			reason: metav1.StatusReasonInvalid,
+		},
+		{
+			name:   "invalid window",
+			err:    controller.ErrInvalidWindow,
+			spec:   queryv1alpha1.PredictionQuerySpec{PodGroupPrediction: "web", MetricName: "cpu", Start: &start},
+			code:   http.StatusBadRequest,
+			reason: metav1.StatusReasonBadRequest,
+		},
+		{
+			name:   "insufficient history",
+			err:    controller.ErrInsufficientHistory,
+			spec:   queryv1alpha1.PredictionQuerySpec{PodGroupPrediction: "web", MetricName: "cpu"},
+			code:   http.StatusServiceUnavailable,
+			reason: metav1.StatusReasonServiceUnavailable,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := newServer(t, &stubForecaster{err: c.err}, newPodGroupPrediction("web", nil))
+			code, _, status := create(t, server, "default", &queryv1alpha1.PredictionQuery{Spec: c.spec})
+			if code != c.code || status.Reason != c.reason {
+				t.Errorf("expected status %d %s, got %d %+v", c.code, c.reason, code, status)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/gocrane-io/api/pkg/controller"
+	predictionlisters "github.com/gocrane-io/api/pkg/generated/listers/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+	predictionv1beta1 "github.com/gocrane-io/api/prediction/v1beta1"
+	queryv1alpha1 "github.com/gocrane-io/api/query/v1alpha1"
+	"github.com/gocrane-io/api/query/v1alpha1/validation"
+)
+
+// Forecaster forecasts the metrics of the predictions, *controller.Forecaster implements it.
+type Forecaster interface {
+	ForecastPodGroup(ctx context.Context, pgp *v1alpha1.PodGroupPrediction, metricName string, now time.Time, window controller.Window) (*controller.Forecast, error)
+	ForecastNode(ctx context.Context, np *v1alpha1.NodePrediction, metricName string, now time.Time, window controller.Window) (*controller.Forecast, error)
+}
+
+var _ Forecaster = &controller.Forecaster{}
+
+// predictionQueryKind is the group kind of the errors about a query.
+var predictionQueryKind = schema.GroupKind{Group: queryv1alpha1.GroupName, Kind: "PredictionQuery"}
+
+// REST answers the PredictionQuery created by the clients with the forecast of its metric. It only supports
+// create, nothing is stored.
+type REST struct {
+	podGroupPredictionLister predictionlisters.PodGroupPredictionLister
+	nodePredictionLister     predictionlisters.NodePredictionLister
+	forecaster               Forecaster
+	now                      func() time.Time
+}
+
+var _ rest.Creater = &REST{}
+var _ rest.Scoper = &REST{}
+
+// NewREST returns the storage of the predictionqueries resource.
+func NewREST(podGroupPredictionLister predictionlisters.PodGroupPredictionLister, nodePredictionLister predictionlisters.NodePredictionLister, forecaster Forecaster) *REST {
+	return &REST{
+		podGroupPredictionLister: podGroupPredictionLister,
+		nodePredictionLister:     nodePredictionLister,
+		forecaster:               forecaster,
+		now:                      time.Now,
+	}
+}
+
+// New implements rest.Storage.
+func (r *REST) New() runtime.Object {
+	return &queryv1alpha1.PredictionQuery{}
+}
+
+// NamespaceScoped implements rest.Scoper, a query of a NodePrediction may be created in any namespace.
+func (r *REST) NamespaceScoped() bool {
+	return true
+}
+
+// Create implements rest.Creater. It returns the query with its window defaulted and its forecast in the status.
+func (r *REST) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, _ *metav1.CreateOptions) (runtime.Object, error) {
+	query, ok := obj.(*queryv1alpha1.PredictionQuery)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("not a PredictionQuery: %T", obj))
+	}
+	if errs := validation.ValidatePredictionQuery(query); len(errs) > 0 {
+		return nil, apierrors.NewInvalid(predictionQueryKind, query.Name, errs)
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj.DeepCopyObject()); err != nil {
+			return nil, err
+		}
+	}
+	if namespace, ok := genericapirequest.NamespaceFrom(ctx); ok {
+		query.Namespace = namespace
+	}
+
+	now := r.now()
+	var forecast *controller.Forecast
+	var err error
+	specPath := field.NewPath("spec")
+	if query.Spec.NodePrediction != "" {
+		np, getErr := r.nodePredictionLister.Get(query.Spec.NodePrediction)
+		if getErr != nil {
+			return nil, getErr
+		}
+		window := r.window(query, now, v1alpha1.DefaultPredictionLength)
+		forecast, err = r.forecaster.ForecastNode(ctx, np, query.Spec.MetricName, now, window)
+	} else {
+		pgp, getErr := r.podGroupPrediction(query)
+		if getErr != nil {
+			return nil, getErr
+		}
+		length := pgp.Spec.PredictionLength.Duration
+		if length <= 0 {
+			length = v1alpha1.DefaultPredictionLength
+		}
+		window := r.window(query, now, length)
+		forecast, err = r.forecaster.ForecastPodGroup(ctx, pgp, query.Spec.MetricName, now, window)
+	}
+
+	switch {
+	case errors.Is(err, controller.ErrMetricNotPredicted):
+		return nil, apierrors.NewInvalid(predictionQueryKind, query.Name, field.ErrorList{
+			field.NotFound(specPath.Child("metricName"), query.Spec.MetricName),
+		})
+	case errors.Is(err, controller.ErrInvalidWindow):
+		return nil, apierrors.NewBadRequest(err.Error())
+	case errors.Is(err, controller.ErrInsufficientHistory):
+		return nil, apierrors.NewServiceUnavailable(err.Error())
+	case err != nil:
+		return nil, apierrors.NewInternalError(err)
+	}
+
+	resourceName := v1alpha1.ResourceName(query.Spec.MetricName)
+	query.Status.Estimators = forecast.Estimators
+	query.Status.TimeSeries = make(predictionv1beta1.TimeSeries, 0, len(forecast.Series))
+	for _, s := range forecast.Series {
+		q := helper.ToQuantitySample(resourceName, s)
+		query.Status.TimeSeries = append(query.Status.TimeSeries, predictionv1beta1.Vector{
+			Value:      q.Value,
+			Timestamp:  q.Timestamp,
+			LowerBound: q.LowerBound,
+			UpperBound: q.UpperBound,
+			Quantiles:  q.Quantiles,
+		})
+	}
+	return query, nil
+}
+
+// window defaults the window of the query, which starts now and spans length by default, and records it in
+// the spec of the query.
+func (r *REST) window(query *queryv1alpha1.PredictionQuery, now time.Time, length time.Duration) controller.Window {
+	if query.Spec.Start == nil {
+		start := metav1.NewTime(now)
+		query.Spec.Start = &start
+	}
+	if query.Spec.End == nil {
+		end := metav1.NewTime(query.Spec.Start.Add(length))
+		query.Spec.End = &end
+	}
+	return controller.Window{Start: query.Spec.Start.Time, End: query.Spec.End.Time, Step: query.Spec.Step.Duration}
+}
+
+// podGroupPrediction returns the PodGroupPrediction of the query, selected by name or by workload. When several
+// predictions refer to the workload, the first by name is used.
+func (r *REST) podGroupPrediction(query *queryv1alpha1.PredictionQuery) (*v1alpha1.PodGroupPrediction, error) {
+	lister := r.podGroupPredictionLister.PodGroupPredictions(query.Namespace)
+	if query.Spec.WorkloadRef == nil {
+		return lister.Get(query.Spec.PodGroupPrediction)
+	}
+
+	ref := query.Spec.WorkloadRef
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return nil, apierrors.NewInvalid(predictionQueryKind, query.Name, field.ErrorList{
+			field.Invalid(field.NewPath("spec", "workloadRef", "apiVersion"), ref.APIVersion, err.Error()),
+		})
+	}
+	pgps, err := lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pgps, func(i, j int) bool {
+		return pgps[i].Name < pgps[j].Name
+	})
+	for _, pgp := range pgps {
+		other := pgp.Spec.WorkloadRef
+		if other == nil || other.Kind != ref.Kind || other.Name != ref.Name {
+			continue
+		}
+		if otherGV, err := schema.ParseGroupVersion(other.APIVersion); err == nil && otherGV.Group == gv.Group {
+			return pgp, nil
+		}
+	}
+	return nil, apierrors.NewNotFound(v1alpha1.Resource("podgrouppredictions"),
+		fmt.Sprintf("for %s %s %s", ref.APIVersion, ref.Kind, ref.Name))
+}
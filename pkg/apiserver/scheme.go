@@ -0,0 +1,26 @@
+package apiserver
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+
+	"github.com/gocrane-io/api/pkg/generated/clientset/versioned/scheme"
+	queryv1alpha1 "github.com/gocrane-io/api/query/v1alpha1"
+)
+
+var (
+	// Scheme is the scheme of the generated clientset, the server shares it with its clients.
+	Scheme = scheme.Scheme
+	// Codecs are the codecs of Scheme.
+	Codecs = scheme.Codecs
+)
+
+func init() {
+	// the generic API server converts the requests to the internal version of their group, which is the
+	// served version itself since the queries are never stored.
+	Scheme.AddKnownTypes(schema.GroupVersion{Group: queryv1alpha1.GroupName, Version: runtime.APIVersionInternal},
+		&queryv1alpha1.PredictionQuery{},
+	)
+	utilruntime.Must(Scheme.SetVersionPriority(queryv1alpha1.SchemeGroupVersion))
+}
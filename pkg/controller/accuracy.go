@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// AccuracyWindow is the rolling window over which the accuracy of the predictions is accumulated.
+const AccuracyWindow = 24 * time.Hour
+
+// The kinds of the objects prefixing their keys in forecasts.
+const (
+	nodePredictionKind     = "NodePrediction"
+	podGroupPredictionKind = "PodGroupPrediction"
+)
+
+// forecasts are the past predictions of the objects by kind/key and metric, retained until the values they
+// predicted have been observed. The status only holds the last prediction, whose PredictionModeInstant peak
+// is observed an hour later.
+type forecasts struct {
+	lock   sync.Mutex
+	series map[string]map[string]timeseries.Series
+}
+
+func newForecasts() *forecasts {
+	return &forecasts{series: make(map[string]map[string]timeseries.Series)}
+}
+
+// take removes and returns the forecasts of the object, nil if there are none.
+func (f *forecasts) take(key string) map[string]timeseries.Series {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	series := f.series[key]
+	delete(f.series, key)
+	return series
+}
+
+// put stores the forecasts of the object.
+func (f *forecasts) put(key string, series map[string]timeseries.Series) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if len(series) == 0 {
+		delete(f.series, key)
+		return
+	}
+	f.series[key] = series
+}
+
+// pendingForecasts returns the forecasts of the object to evaluate: the retained ones, or the previous
+// prediction of its status after a restart of the controller.
+func (c *Controller) pendingForecasts(key string, previous v1alpha1.Prediction) map[string]timeseries.Series {
+	if pending := c.forecasts.take(key); pending != nil {
+		return pending
+	}
+	pending := make(map[string]timeseries.Series, len(previous))
+	for metric, ts := range previous {
+		pending[metric], _ = timeseries.FromTimeSeries(v1alpha1.ResourceName(metric), ts)
+	}
+	return pending
+}
+
+// retainForecasts stores the forecasts that are not evaluated yet along with the new predictions of a
+// PredictionModeInstant object. The series of PredictionModeRange are in the status until the next
+// prediction, which starts after the observed values, so they are never retained.
+func (c *Controller) retainForecasts(key string, mode v1alpha1.PredictionMode, remaining, predictions map[string]timeseries.Series) {
+	if mode == v1alpha1.PredictionModeRange {
+		c.forecasts.put(key, nil)
+		return
+	}
+	retained := make(map[string]timeseries.Series, len(remaining)+len(predictions))
+	for metric, series := range remaining {
+		retained[metric] = series
+	}
+	for metric, series := range predictions {
+		retained[metric] = timeseries.New(append(retained[metric], series...))
+	}
+	c.forecasts.put(key, retained)
+}
+
+// horizon returns the time range after its timestamp that a forecast of the mode predicts the peak of.
+func horizon(mode v1alpha1.PredictionMode) time.Duration {
+	if mode == v1alpha1.PredictionModeRange {
+		return 0
+	}
+	return InstantPredictionLength
+}
+
+// evaluate compares the pending forecasts of the metrics with the values observed until now and adds their
+// accuracy to the last accuracy of the metric, trimmed to AccuracyWindow. Only the forecasts after the last
+// evaluated window are compared, so that a value is never counted twice. A metric without any new compared
+// value keeps its last accuracy. It returns the accuracies sorted by metric and the forecasts whose horizon
+// has not been observed yet.
+func evaluate(configs []v1alpha1.AlgorithmProviderConfig, pending, observed map[string]timeseries.Series, horizon time.Duration,
+	now time.Time, accuracies []v1alpha1.MetricAccuracy) ([]v1alpha1.MetricAccuracy, map[string]timeseries.Series) {
+	var evaluated []v1alpha1.MetricAccuracy
+	remaining := make(map[string]timeseries.Series)
+	for i := range configs {
+		metric := configs[i].MetricName
+		var last helper.Accuracy
+		if a := helper.GetAccuracy(accuracies, metric); a != nil {
+			// an accuracy that cannot be parsed is started over.
+			last, _ = helper.ParseAccuracy(v1alpha1.ResourceName(metric), a)
+		}
+		forecast := pending[metric]
+		if last.Samples > 0 {
+			forecast = forecast.Window(last.End+1, now.Unix()+int64(horizon/time.Second)+1)
+		}
+
+		// the observed history is sampled every sample interval, a longer gap is missing data.
+		maxGap := int64(2 * sampleInterval(&configs[i]) / time.Second)
+		compared, actual, later := compare(forecast, observed[metric], int64(horizon/time.Second), now.Unix())
+		if len(later) > 0 {
+			remaining[metric] = later
+		}
+		if horizon > 0 {
+			// the actual peaks are at the timestamps of the forecasts.
+			maxGap = 0
+		}
+		accuracy, err := timeseries.Evaluate(compared, actual, maxGap)
+		if err != nil {
+			if last.Samples > 0 {
+				evaluated = append(evaluated, *helper.GetAccuracy(accuracies, metric))
+			}
+			continue
+		}
+		last = last.Trim(accuracy.End - int64(AccuracyWindow/time.Second))
+		evaluated = append(evaluated, helper.FormatAccuracy(metric, helper.MergeAccuracy(last, accuracy)))
+	}
+	sort.Slice(evaluated, func(i, j int) bool {
+		return evaluated[i].MetricName < evaluated[j].MetricName
+	})
+	return evaluated, remaining
+}
+
+// compare returns the forecasts that can be compared with the observed series, the actual values they are
+// compared with and the forecasts to compare later. A forecast without horizon is compared with the observed
+// value at its timestamp. A forecast with a horizon is compared with the observed peak of the horizon after
+// its timestamp once the observed series covers it; it is dropped when the horizon ended more than a horizon
+// ago without being observed.
+func compare(forecast, observed timeseries.Series, horizon, now int64) (compared, actual, later timeseries.Series) {
+	if horizon == 0 {
+		return forecast, observed, nil
+	}
+	observedEnd, err := observed.End()
+	for _, f := range forecast {
+		end := f.Timestamp + horizon
+		if err != nil || end > observedEnd {
+			if end > now-horizon {
+				later = append(later, f)
+			}
+			continue
+		}
+		peak, err := observed.PeakIn(f.Timestamp, end)
+		if err != nil {
+			continue
+		}
+		compared = append(compared, f)
+		actual = append(actual, helper.Sample{Timestamp: f.Timestamp, Value: peak.Value})
+	}
+	return compared, actual, later
+}
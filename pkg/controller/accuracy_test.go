@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// constant returns a sample of the value every minute in [start, end).
+func constant(value float64, start, end time.Time) timeseries.Series {
+	var series timeseries.Series
+	for t := start; t.Before(end); t = t.Add(time.Minute) {
+		series = append(series, helper.Sample{Timestamp: t.Unix(), Value: value})
+	}
+	return series
+}
+
+func TestEvaluateAccumulatesInstantForecasts(t *testing.T) {
+	configs := []v1alpha1.AlgorithmProviderConfig{{MetricName: "cpu", Percentile: &v1alpha1.PercentileConfig{}}}
+	observed := map[string]timeseries.Series{"cpu": constant(100, now.Add(-3*time.Hour), now)}
+	// a peak of 110 forecast every minute of the last 2 hours.
+	pending := map[string]timeseries.Series{"cpu": constant(110, now.Add(-2*time.Hour), now)}
+
+	accuracies, remaining := evaluate(configs, pending, observed, InstantPredictionLength, now, nil)
+	if len(accuracies) != 1 {
+		t.Fatalf("expected the accuracy of cpu, got %+v", accuracies)
+	}
+	// the last observed value is a minute before now, the hour of the forecasts of the last 61 minutes is not
+	// observed yet.
+	if got := accuracies[0]; got.Samples != 60 || got.RMSE != "10" {
+		t.Errorf("unexpected accuracy %+v", got)
+	}
+	if got := len(remaining["cpu"]); got != 60 {
+		t.Errorf("expected 60 forecasts to compare later, got %d", got)
+	}
+
+	// ten minutes later, the retained forecasts whose hour has been observed are added to the accuracy.
+	later := now.Add(10 * time.Minute)
+	observed["cpu"] = constant(100, now.Add(-3*time.Hour), later)
+	accuracies, remaining = evaluate(configs, remaining, observed, InstantPredictionLength, later, accuracies)
+	if got := accuracies[0]; got.Samples != 70 || got.RMSE != "10" {
+		t.Errorf("unexpected accumulated accuracy %+v", got)
+	}
+	if got := len(remaining["cpu"]); got != 50 {
+		t.Errorf("expected 50 forecasts to compare later, got %d", got)
+	}
+	if err := helper.CheckAccuracy(accuracies, "cpu", helper.AccuracyThreshold{MaxMAPE: 0.2, MinSamples: 10}, later); err != nil {
+		t.Errorf("expected the accumulated accuracy to pass the threshold: %v", err)
+	}
+
+	// the forecasts already evaluated are not counted twice.
+	again, _ := evaluate(configs, pending, observed, InstantPredictionLength, later, accuracies)
+	if again[0].Samples != accuracies[0].Samples {
+		t.Errorf("expected %d samples after evaluating the same forecasts again, got %d", accuracies[0].Samples, again[0].Samples)
+	}
+}
+
+func TestEvaluateRangeForecastsOverRollingWindow(t *testing.T) {
+	configs := []v1alpha1.AlgorithmProviderConfig{{MetricName: "cpu", Percentile: &v1alpha1.PercentileConfig{}}}
+	// the last accuracy spans a day of 1440 compared values, ending 2 hours ago.
+	last := helper.FormatAccuracy("cpu", helper.Accuracy{
+		RMSE:    0,
+		Samples: 1440,
+		Start:   now.Add(-26 * time.Hour).Unix(),
+		End:     now.Add(-2 * time.Hour).Unix(),
+	})
+	observed := map[string]timeseries.Series{"cpu": constant(100, now.Add(-3*time.Hour), now)}
+	// the previous prediction of the status, compared with the observed values at its timestamps.
+	pending := map[string]timeseries.Series{"cpu": constant(100, now.Add(-2*time.Hour).Add(time.Minute), now.Add(time.Hour))}
+
+	accuracies, remaining := evaluate(configs, pending, observed, 0, now, []v1alpha1.MetricAccuracy{last})
+	if len(remaining) != 0 {
+		t.Errorf("expected no forecast to compare later, got %+v", remaining)
+	}
+	got := accuracies[0]
+	// the 119 values observed since the last window, and the last 22 hours and a minute of the last day.
+	if got.Samples != 119+1321 {
+		t.Errorf("expected %d samples, got %d", 119+1321, got.Samples)
+	}
+	if start := got.Window.Start.Unix(); start != now.Add(-AccuracyWindow).Add(-time.Minute).Unix() {
+		t.Errorf("unexpected window start %s", got.Window.Start.UTC())
+	}
+}
+
+func TestEvaluateKeepsLastAccuracy(t *testing.T) {
+	configs := []v1alpha1.AlgorithmProviderConfig{{MetricName: "cpu", Percentile: &v1alpha1.PercentileConfig{}}}
+	last := helper.FormatAccuracy("cpu", helper.Accuracy{RMSE: 5, Samples: 20, Start: now.Add(-time.Hour).Unix(), End: now.Unix()})
+
+	accuracies, _ := evaluate(configs, nil, nil, 0, now, []v1alpha1.MetricAccuracy{last})
+	if len(accuracies) != 1 || accuracies[0] != last {
+		t.Errorf("expected the last accuracy %+v, got %+v", last, accuracies)
+	}
+}
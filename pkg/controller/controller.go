@@ -0,0 +1,156 @@
+// Package controller implements the reference controller of the prediction resources. It drives the
+// lifecycle of NodePrediction and PodGroupPrediction, queries the history of their metrics from a
+// metric source and writes the predicted series into their status.
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"github.com/gocrane-io/api/pkg/generated/clientset/versioned"
+	"github.com/gocrane-io/api/pkg/generated/informers/externalversions"
+	predictionlisters "github.com/gocrane-io/api/pkg/generated/listers/prediction/v1alpha1"
+	"github.com/gocrane-io/api/pkg/metricsource"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+)
+
+// Controller reconciles the NodePrediction and PodGroupPrediction resources.
+type Controller struct {
+	*Forecaster
+	predictionClient versioned.Interface
+
+	nodePredictionLister     predictionlisters.NodePredictionLister
+	podGroupPredictionLister predictionlisters.PodGroupPredictionLister
+	synced                   []cache.InformerSynced
+
+	nodePredictionQueue     workqueue.RateLimitingInterface
+	podGroupPredictionQueue workqueue.RateLimitingInterface
+
+	forecasts *forecasts
+	now       func() time.Time
+}
+
+// New returns a controller watching the predictions, pods and workloads through the given informer factories,
+// which must be started by the caller after New returns.
+func New(
+	predictionClient versioned.Interface,
+	predictionInformers externalversions.SharedInformerFactory,
+	kubeInformers kubeinformers.SharedInformerFactory,
+	source metricsource.MetricSource,
+	predictor Predictor,
+) *Controller {
+	nodePredictionInformer := predictionInformers.Prediction().V1alpha1().NodePredictions()
+	podGroupPredictionInformer := predictionInformers.Prediction().V1alpha1().PodGroupPredictions()
+	forecaster := NewForecaster(kubeInformers, source, predictor)
+
+	c := &Controller{
+		Forecaster:               forecaster,
+		predictionClient:         predictionClient,
+		nodePredictionLister:     nodePredictionInformer.Lister(),
+		podGroupPredictionLister: podGroupPredictionInformer.Lister(),
+		synced: append([]cache.InformerSynced{
+			nodePredictionInformer.Informer().HasSynced,
+			podGroupPredictionInformer.Informer().HasSynced,
+		}, forecaster.synced...),
+		nodePredictionQueue:     workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "nodeprediction"),
+		podGroupPredictionQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "podgroupprediction"),
+		forecasts:               newForecasts(),
+		now:                     time.Now,
+	}
+
+	nodePredictionInformer.Informer().AddEventHandler(eventHandler(c.nodePredictionQueue))
+	podGroupPredictionInformer.Informer().AddEventHandler(eventHandler(c.podGroupPredictionQueue))
+	return c
+}
+
+// eventHandler enqueues the created and deleted objects, and the updated ones only when their spec changed:
+// the status updates of the controller itself must not trigger a new prediction, the predictions are
+// refreshed periodically instead.
+func eventHandler(queue workqueue.RateLimitingInterface) cache.ResourceEventHandler {
+	enqueue := func(obj interface{}) {
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+		if err != nil {
+			utilruntime.HandleError(err)
+			return
+		}
+		queue.Add(key)
+	}
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: enqueue,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldGeneration, newGeneration := generation(oldObj), generation(newObj)
+			if oldGeneration != newGeneration || oldGeneration == 0 {
+				enqueue(newObj)
+			}
+		},
+		DeleteFunc: enqueue,
+	}
+}
+
+func generation(obj interface{}) int64 {
+	switch o := obj.(type) {
+	case *v1alpha1.NodePrediction:
+		return o.Generation
+	case *v1alpha1.PodGroupPrediction:
+		return o.Generation
+	}
+	return 0
+}
+
+// Run waits for the caches to sync and runs the given number of workers per resource until ctx is done.
+func (c *Controller) Run(ctx context.Context, workers int) error {
+	defer utilruntime.HandleCrash()
+	defer c.nodePredictionQueue.ShutDown()
+	defer c.podGroupPredictionQueue.ShutDown()
+
+	klog.Info("Starting prediction controller")
+	if !cache.WaitForNamedCacheSync("prediction", ctx.Done(), c.synced...) {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, func(ctx context.Context) {
+			for c.processNextItem(ctx, c.nodePredictionQueue, c.syncNodePrediction) {
+			}
+		}, time.Second)
+		go wait.UntilWithContext(ctx, func(ctx context.Context) {
+			for c.processNextItem(ctx, c.podGroupPredictionQueue, c.syncPodGroupPrediction) {
+			}
+		}, time.Second)
+	}
+
+	<-ctx.Done()
+	klog.Info("Shutting down prediction controller")
+	return nil
+}
+
+// syncFunc reconciles the object of the key and returns when it has to be reconciled again, zero for never.
+type syncFunc func(ctx context.Context, key string) (time.Duration, error)
+
+func (c *Controller) processNextItem(ctx context.Context, queue workqueue.RateLimitingInterface, sync syncFunc) bool {
+	key, quit := queue.Get()
+	if quit {
+		return false
+	}
+	defer queue.Done(key)
+
+	requeueAfter, err := sync(ctx, key.(string))
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to sync %q: %v", key, err))
+		queue.AddRateLimited(key)
+		return true
+	}
+	queue.Forget(key)
+	if requeueAfter > 0 {
+		queue.AddAfter(key, requeueAfter)
+	}
+	return true
+}
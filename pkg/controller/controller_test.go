@@ -0,0 +1,339 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/gocrane-io/api/pkg/generated/clientset/versioned/fake"
+	"github.com/gocrane-io/api/pkg/generated/informers/externalversions"
+	"github.com/gocrane-io/api/pkg/metricsource"
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+	"github.com/gocrane-io/api/prediction/v1alpha1/predictiontest"
+)
+
+// now is a multiple of the default sample interval, the first predicted timestamp is a minute later.
+var now = time.Unix(1634860800, 0)
+
+// rampPredictor predicts the number of seconds after now at every timestamp, whatever the history.
+type rampPredictor struct{}
+
+func (rampPredictor) Predict(_ *v1alpha1.AlgorithmProviderConfig, history timeseries.Series, timestamps []int64) (timeseries.Series, string, error) {
+	if len(history) == 0 {
+		return nil, "ramp", ErrInsufficientHistory
+	}
+	predicted := make(timeseries.Series, 0, len(timestamps))
+	for _, t := range timestamps {
+		predicted = append(predicted, helper.Sample{Timestamp: t, Value: float64(t - now.Unix())})
+	}
+	return predicted, "ramp", nil
+}
+
+// history returns an hour of samples of the value until now.
+func history(value float64) []helper.Sample {
+	var samples []helper.Sample
+	for t := now.Add(-time.Hour); t.Before(now); t = t.Add(time.Minute) {
+		samples = append(samples, helper.Sample{Timestamp: t.Unix(), Value: value})
+	}
+	return samples
+}
+
+func newPod(name string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	for _, container := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: container})
+	}
+	return pod
+}
+
+// newPodGroupPrediction returns the fixture of the mode predicting the pods, or none.
+func newPodGroupPrediction(mode v1alpha1.PredictionMode, pods ...string) *v1alpha1.PodGroupPrediction {
+	pgp := predictiontest.NewPodGroupPrediction("default", "web")
+	pgp.Spec.Mode, pgp.Spec.Pods = mode, pods
+	return pgp
+}
+
+type fixture struct {
+	controller       *Controller
+	predictionClient *fake.Clientset
+}
+
+// newFixture returns a controller whose informers are synced with the objects, reading the series from a
+// file source in memory.
+func newFixture(t *testing.T, predictor Predictor, series []metricsource.FileSeries, predictions []runtime.Object, pods ...runtime.Object) *fixture {
+	t.Helper()
+	predictionClient := fake.NewSimpleClientset(predictions...)
+	kubeClient := kubefake.NewSimpleClientset(pods...)
+	predictionInformers := externalversions.NewSharedInformerFactory(predictionClient, 0)
+	kubeInformers := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
+	c := New(predictionClient, predictionInformers, kubeInformers, metricsource.NewFileSourceFromSeries(series), predictor)
+	c.now = func() time.Time { return now }
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	predictionInformers.Start(stopCh)
+	kubeInformers.Start(stopCh)
+	predictionInformers.WaitForCacheSync(stopCh)
+	kubeInformers.WaitForCacheSync(stopCh)
+	return &fixture{controller: c, predictionClient: predictionClient}
+}
+
+func (f *fixture) podGroupPrediction(t *testing.T) *v1alpha1.PodGroupPrediction {
+	t.Helper()
+	pgp, err := f.predictionClient.PredictionV1alpha1().PodGroupPredictions("default").Get(context.TODO(), "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get the pod group prediction: %v", err)
+	}
+	return pgp
+}
+
+func TestSyncPodGroupPredictionLifecycle(t *testing.T) {
+	series := []metricsource.FileSeries{{Metric: "cpu", Namespace: "default", Pod: "web-0", Container: "web", Samples: history(250)}}
+	cases := []struct {
+		name         string
+		start, end   time.Time
+		pods         []string
+		phase        v1alpha1.PredictionConditionType
+		status       v1alpha1.PredictionStatus
+		requeueAfter time.Duration
+	}{
+		{
+			name:         "before start",
+			start:        now.Add(time.Hour),
+			pods:         []string{"web-0"},
+			phase:        v1alpha1.PredictionConditionNotStarted,
+			status:       v1alpha1.PredictionStatusNotStarted,
+			requeueAfter: time.Hour,
+		},
+		{
+			name:         "without history",
+			pods:         []string{"web-1"},
+			phase:        v1alpha1.PredictionConditionCharging,
+			status:       v1alpha1.PredictionStatusCharging,
+			requeueAfter: time.Minute,
+		},
+		{
+			name:         "predicting",
+			start:        now.Add(-time.Hour),
+			end:          now.Add(30 * time.Second),
+			pods:         []string{"web-0"},
+			phase:        v1alpha1.PredictionConditionPredicting,
+			status:       v1alpha1.PredictionStatusPredicting,
+			requeueAfter: 30 * time.Second,
+		},
+		{
+			name:   "after end",
+			start:  now.Add(-time.Hour),
+			end:    now,
+			pods:   []string{"web-0"},
+			phase:  v1alpha1.PredictionConditionFinished,
+			status: v1alpha1.PredictionStatusFinished,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			pgp := newPodGroupPrediction(v1alpha1.PredictionModeInstant, c.pods...)
+			if !c.start.IsZero() {
+				pgp.Spec.Start = &metav1.Time{Time: c.start}
+			}
+			if !c.end.IsZero() {
+				pgp.Spec.End = &metav1.Time{Time: c.end}
+			}
+			f := newFixture(t, MaxValuePredictor{}, series, []runtime.Object{pgp}, newPod("web-0", "web"), newPod("web-1", "web"))
+
+			requeueAfter, err := f.controller.syncPodGroupPrediction(context.TODO(), "default/web")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if requeueAfter != c.requeueAfter {
+				t.Errorf("expected requeue after %s, got %s", c.requeueAfter, requeueAfter)
+			}
+			status := f.podGroupPrediction(t).Status
+			if !helper.IsConditionTrue(status.Conditions, c.phase) {
+				t.Errorf("expected condition %s to be true, got %+v", c.phase, status.Conditions)
+			}
+			if status.Status != c.status {
+				t.Errorf("expected status %s, got %s", c.status, status.Status)
+			}
+			if predicting := c.phase == v1alpha1.PredictionConditionPredicting; predicting != (len(status.Aggregation) > 0) {
+				t.Errorf("expected an aggregation only when predicting, got %+v", status.Aggregation)
+			}
+		})
+	}
+}
+
+func TestSyncPodGroupPredictionInstant(t *testing.T) {
+	series := []metricsource.FileSeries{
+		{Metric: "cpu", Namespace: "default", Pod: "web-0", Container: "web", Samples: history(250)},
+		{Metric: "cpu", Namespace: "default", Pod: "web-0", Container: "sidecar", Samples: history(50)},
+	}
+	pgp := newPodGroupPrediction(v1alpha1.PredictionModeInstant, "web-0")
+	f := newFixture(t, rampPredictor{}, series, []runtime.Object{pgp}, newPod("web-0", "web", "sidecar"))
+
+	if _, err := f.controller.syncPodGroupPrediction(context.TODO(), "default/web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	status := f.podGroupPrediction(t).Status
+	// the peak of the next hour, at the next sample, summed over both containers.
+	expected := v1alpha1.TimeSeries{{Value: "7200", Timestamp: now.Add(time.Minute).Unix()}}
+	if got := status.Aggregation["cpu"]; len(got) != 1 || got[0].Value != expected[0].Value || got[0].Timestamp != expected[0].Timestamp {
+		t.Errorf("expected aggregation %+v, got %+v", expected, got)
+	}
+	if len(status.Containers) != 2 {
+		t.Errorf("expected the predictions of 2 containers, got %d", len(status.Containers))
+	}
+	if len(status.Estimators) != 1 || status.Estimators[0].Estimator != "ramp" {
+		t.Errorf("unexpected estimators %+v", status.Estimators)
+	}
+}
+
+func TestSyncPodGroupPredictionWithoutSelection(t *testing.T) {
+	series := []metricsource.FileSeries{{Metric: "cpu", Namespace: "default", Pod: "web-0", Container: "web", Samples: history(250)}}
+	pgp := newPodGroupPrediction(v1alpha1.PredictionModeInstant)
+	f := newFixture(t, MaxValuePredictor{}, series, []runtime.Object{pgp}, newPod("web-0", "web"))
+
+	pods, err := f.controller.podsOf(pgp)
+	if err != nil || len(pods) != 0 {
+		t.Fatalf("expected no pods for a pod group without pods, workloadRef and labelSelector, got %v, %v", pods, err)
+	}
+	if _, err := f.controller.syncPodGroupPrediction(context.TODO(), "default/web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	status := f.podGroupPrediction(t).Status
+	if len(status.Aggregation) != 0 || len(status.Containers) != 0 {
+		t.Errorf("expected no prediction of the pods of the namespace, got %+v and %+v", status.Aggregation, status.Containers)
+	}
+}
+
+func TestPodsOfWorkloadRef(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	web, other := newPod("web-0", "web"), newPod("db-0", "db")
+	web.Labels, other.Labels = selector.MatchLabels, map[string]string{"app": "db"}
+	workloads := []runtime.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}, Spec: appsv1.DeploymentSpec{Selector: selector}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}, Spec: appsv1.StatefulSetSpec{Selector: selector}},
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}, Spec: appsv1.DaemonSetSpec{Selector: selector}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}, Spec: appsv1.ReplicaSetSpec{Selector: selector}},
+	}
+	f := newFixture(t, MaxValuePredictor{}, nil, nil, append(workloads, web, other)...)
+
+	cases := []struct {
+		name       string
+		apiVersion string
+		kind       string
+		workload   string
+		expected   []string
+		err        bool
+	}{
+		{name: "deployment", apiVersion: "apps/v1", kind: "Deployment", workload: "web", expected: []string{"web-0"}},
+		{name: "statefulset", apiVersion: "apps/v1", kind: "StatefulSet", workload: "web", expected: []string{"web-0"}},
+		{name: "daemonset", apiVersion: "apps/v1", kind: "DaemonSet", workload: "web", expected: []string{"web-0"}},
+		{name: "replicaset", apiVersion: "apps/v1", kind: "ReplicaSet", workload: "web", expected: []string{"web-0"}},
+		{name: "missing workload", apiVersion: "apps/v1", kind: "Deployment", workload: "db", err: true},
+		{name: "unsupported kind", apiVersion: "apps/v1", kind: "ControllerRevision", workload: "web", err: true},
+		{name: "unsupported group", apiVersion: "batch/v1", kind: "Job", workload: "web", err: true},
+	}
+	for _, c := range cases {
+		pgp := newPodGroupPrediction(v1alpha1.PredictionModeInstant)
+		pgp.Spec.WorkloadRef = &autoscalingv2.CrossVersionObjectReference{APIVersion: c.apiVersion, Kind: c.kind, Name: c.workload}
+		pods, err := f.controller.podsOf(pgp)
+		if c.err {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %v", c.name, pods)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+		var names []string
+		for _, pod := range pods {
+			names = append(names, pod.Name)
+		}
+		if !reflect.DeepEqual(names, c.expected) {
+			t.Errorf("%s: expected the pods %v, got %v", c.name, c.expected, names)
+		}
+	}
+}
+
+func TestSyncPodGroupPredictionRangeDownsamplesContainers(t *testing.T) {
+	series := []metricsource.FileSeries{{Metric: "cpu", Namespace: "default", Pod: "web-0", Container: "web", Samples: history(250)}}
+	pgp := newPodGroupPrediction(v1alpha1.PredictionModeRange, "web-0")
+	pgp.Spec.PredictionLength = metav1.Duration{Duration: 24 * time.Hour}
+	f := newFixture(t, rampPredictor{}, series, []runtime.Object{pgp}, newPod("web-0", "web"))
+
+	if _, err := f.controller.syncPodGroupPrediction(context.TODO(), "default/web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	status := f.podGroupPrediction(t).Status
+	if got := len(status.Aggregation["cpu"]); got != 24*60 {
+		t.Errorf("expected an aggregation of %d vectors, got %d", 24*60, got)
+	}
+	container := status.Containers["default/web-0/web"]["cpu"]
+	if len(container) != MaxContainerSeriesPoints {
+		t.Fatalf("expected a container series of %d vectors, got %d", MaxContainerSeriesPoints, len(container))
+	}
+	// every vector is the peak of an hour starting at its timestamp.
+	if first := container[0]; first.Timestamp != now.Add(time.Minute).Unix() || first.Value != "3600" {
+		t.Errorf("unexpected first container vector %+v", first)
+	}
+}
+
+func TestFormatContainersBoundsVectors(t *testing.T) {
+	containers := make(map[string]map[string]timeseries.Series)
+	ts := timestamps(v1alpha1.PredictionModeRange, now, time.Minute, 24*time.Hour)
+	predicted, _, _ := rampPredictor{}.Predict(nil, timeseries.Series{{}}, ts)
+	for i := 0; i < 500; i++ {
+		containers[fmt.Sprintf("default/web-%d/web", i)] = map[string]timeseries.Series{"cpu": predicted, "memory": predicted}
+	}
+
+	vectors := 0
+	for _, prediction := range formatContainers(containers) {
+		for _, series := range prediction {
+			vectors += len(series)
+		}
+	}
+	if vectors > MaxContainerVectors {
+		t.Errorf("expected at most %d vectors, got %d", MaxContainerVectors, vectors)
+	}
+}
+
+func TestSyncNodePrediction(t *testing.T) {
+	series := []metricsource.FileSeries{{Metric: "memory", Node: "node-1", Samples: history(1 << 30)}}
+	np := predictiontest.NewNodePrediction("node-1")
+	f := newFixture(t, MaxValuePredictor{}, series, []runtime.Object{np})
+
+	requeueAfter, err := f.controller.syncNodePrediction(context.TODO(), "node-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requeueAfter != np.Spec.Period.Duration {
+		t.Errorf("expected requeue after %s, got %s", np.Spec.Period.Duration, requeueAfter)
+	}
+	updated, err := f.predictionClient.PredictionV1alpha1().NodePredictions().Get(context.TODO(), "node-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status.Status != v1alpha1.PredictionStatusPredicting {
+		t.Errorf("expected status %s, got %s", v1alpha1.PredictionStatusPredicting, updated.Status.Status)
+	}
+	if got := updated.Status.Consumed["memory"]; len(got) != 1 || got[0].Value != "1073741824" {
+		t.Errorf("unexpected consumed memory %+v", got)
+	}
+}
@@ -0,0 +1,279 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	kubeinformers "k8s.io/client-go/informers"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/gocrane-io/api/pkg/metricsource"
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	queryv1alpha1 "github.com/gocrane-io/api/query/v1alpha1"
+)
+
+var (
+	// ErrMetricNotPredicted is returned when a forecast asks for a metric the prediction has no config of.
+	ErrMetricNotPredicted = errors.New("metric is not predicted")
+	// ErrInvalidWindow is returned when the window of a forecast is empty or has too many timestamps.
+	ErrInvalidWindow = errors.New("invalid forecast window")
+)
+
+// Forecaster predicts the metrics of the predictions from the history of a metric source. The controller
+// refreshes the status of the predictions with it, and it forecasts any window of their metrics on demand.
+type Forecaster struct {
+	podLister         corelisters.PodLister
+	deploymentLister  appslisters.DeploymentLister
+	statefulSetLister appslisters.StatefulSetLister
+	daemonSetLister   appslisters.DaemonSetLister
+	replicaSetLister  appslisters.ReplicaSetLister
+	// synced are the informers of the listers.
+	synced []cache.InformerSynced
+
+	source    metricsource.MetricSource
+	predictor Predictor
+}
+
+// NewForecaster returns a forecaster selecting the pods of the pod groups and the workloads of their WorkloadRef
+// with the listers of the informer factory, which must be started by the caller after NewForecaster returns.
+func NewForecaster(kubeInformers kubeinformers.SharedInformerFactory, source metricsource.MetricSource, predictor Predictor) *Forecaster {
+	podInformer := kubeInformers.Core().V1().Pods()
+	apps := kubeInformers.Apps().V1()
+	return &Forecaster{
+		podLister:         podInformer.Lister(),
+		deploymentLister:  apps.Deployments().Lister(),
+		statefulSetLister: apps.StatefulSets().Lister(),
+		daemonSetLister:   apps.DaemonSets().Lister(),
+		replicaSetLister:  apps.ReplicaSets().Lister(),
+		synced: []cache.InformerSynced{
+			podInformer.Informer().HasSynced,
+			apps.Deployments().Informer().HasSynced,
+			apps.StatefulSets().Informer().HasSynced,
+			apps.DaemonSets().Informer().HasSynced,
+			apps.ReplicaSets().Informer().HasSynced,
+		},
+		source:    source,
+		predictor: predictor,
+	}
+}
+
+// Window is the time range [Start, End] of a forecast, predicted at the multiples of Step within it, or of
+// the sample interval of the metric for a zero Step.
+type Window struct {
+	Start time.Time
+	End   time.Time
+	Step  time.Duration
+}
+
+// Forecast is the forecast of a metric over a window.
+type Forecast struct {
+	// Series is the predicted series, the sum of the containers of a pod group.
+	Series timeseries.Series
+	// Estimators are the sorted estimators that produced the series.
+	Estimators []string
+}
+
+// ForecastPodGroup forecasts a metric of the pod group over the window, from the history of its containers
+// until now, without updating its status. ErrInsufficientHistory is returned if no container can be predicted.
+func (f *Forecaster) ForecastPodGroup(ctx context.Context, pgp *v1alpha1.PodGroupPrediction, metricName string, now time.Time, window Window) (*Forecast, error) {
+	defaulted := pgp.DeepCopy()
+	v1alpha1.SetObjectDefaults_PodGroupPrediction(defaulted)
+	config, ts, err := forecastConfig(defaulted.Spec.MetricPredictionConfigs, metricName, window)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := f.podsOf(defaulted)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast := &Forecast{}
+	estimators := sets.NewString()
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			target := metricsource.Target{Namespace: pod.Namespace, Pod: pod.Name, Container: container.Name}
+			_, predicted, name, err := f.predictMetric(ctx, config, target, now, ts)
+			if errors.Is(err, metricsource.ErrNotFound) || errors.Is(err, ErrInsufficientHistory) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to forecast container %s: %v", target, err)
+			}
+			forecast.Series = sum(forecast.Series, predicted)
+			estimators.Insert(name)
+		}
+	}
+	if estimators.Len() == 0 {
+		return nil, fmt.Errorf("%w: no container of pod group %s/%s has enough history of metric %s", ErrInsufficientHistory, pgp.Namespace, pgp.Name, metricName)
+	}
+	forecast.Estimators = estimators.List()
+	return forecast, nil
+}
+
+// ForecastNode forecasts a metric of the node over the window, from its history until now, without updating
+// its status.
+func (f *Forecaster) ForecastNode(ctx context.Context, np *v1alpha1.NodePrediction, metricName string, now time.Time, window Window) (*Forecast, error) {
+	defaulted := np.DeepCopy()
+	v1alpha1.SetObjectDefaults_NodePrediction(defaulted)
+	config, ts, err := forecastConfig(defaulted.Spec.MetricPredictionConfigs, metricName, window)
+	if err != nil {
+		return nil, err
+	}
+	_, predicted, name, err := f.predictMetric(ctx, config, metricsource.Target{Node: defaulted.Spec.NodeName}, now, ts)
+	if errors.Is(err, metricsource.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %v", ErrInsufficientHistory, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Forecast{Series: predicted, Estimators: []string{name}}, nil
+}
+
+// forecastConfig returns the config of the metric and the timestamps of the window.
+func forecastConfig(configs []v1alpha1.AlgorithmProviderConfig, metricName string, window Window) (*v1alpha1.AlgorithmProviderConfig, []int64, error) {
+	for i := range configs {
+		if configs[i].MetricName != metricName {
+			continue
+		}
+		ts, err := window.timestamps(sampleInterval(&configs[i]))
+		if err != nil {
+			return nil, nil, err
+		}
+		return &configs[i], ts, nil
+	}
+	return nil, nil, fmt.Errorf("%w: %s", ErrMetricNotPredicted, metricName)
+}
+
+// timestamps returns the unix timestamps of the multiples of the step, or of the default step, in the window.
+func (w Window) timestamps(defaultStep time.Duration) ([]int64, error) {
+	step := w.Step
+	if step == 0 {
+		step = defaultStep
+	}
+	seconds := int64(step / time.Second)
+	if seconds < 1 {
+		return nil, fmt.Errorf("%w: step %s must be at least one second", ErrInvalidWindow, step)
+	}
+	first, last := w.Start.Unix(), w.End.Unix()
+	if r := first % seconds; r != 0 {
+		first += seconds - r
+	}
+	if last < first {
+		return nil, fmt.Errorf("%w: no multiple of %s between %s and %s", ErrInvalidWindow, step,
+			w.Start.UTC().Format(time.RFC3339), w.End.UTC().Format(time.RFC3339))
+	}
+	if count := (last-first)/seconds + 1; count > queryv1alpha1.MaxForecastPoints {
+		return nil, fmt.Errorf("%w: %d points of %s exceed the maximum of %d", ErrInvalidWindow, count, step, queryv1alpha1.MaxForecastPoints)
+	}
+	var ts []int64
+	for t := first; t <= last; t += seconds {
+		ts = append(ts, t)
+	}
+	return ts, nil
+}
+
+// podsOf returns the running pods of the pod group, selected by the first of Pods, WorkloadRef and LabelSelector
+// that is specified, sorted by name. A pod group that specifies none of them has no pods, rather than every pod of
+// its namespace.
+func (f *Forecaster) podsOf(pgp *v1alpha1.PodGroupPrediction) ([]*corev1.Pod, error) {
+	var pods []*corev1.Pod
+	switch {
+	case len(pgp.Spec.Pods) > 0:
+		for _, name := range pgp.Spec.Pods {
+			pod, err := f.podLister.Pods(pgp.Namespace).Get(name)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			pods = append(pods, pod)
+		}
+	case pgp.Spec.WorkloadRef != nil:
+		selector, err := f.workloadSelector(pgp.Namespace, pgp.Spec.WorkloadRef.APIVersion, pgp.Spec.WorkloadRef.Kind, pgp.Spec.WorkloadRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		if pods, err = f.podLister.Pods(pgp.Namespace).List(selector); err != nil {
+			return nil, err
+		}
+	case len(pgp.Spec.LabelSelector.MatchLabels) == 0 && len(pgp.Spec.LabelSelector.MatchExpressions) == 0:
+		return nil, nil
+	default:
+		selector, err := metav1.LabelSelectorAsSelector(&pgp.Spec.LabelSelector)
+		if err != nil {
+			return nil, err
+		}
+		if pods, err = f.podLister.Pods(pgp.Namespace).List(selector); err != nil {
+			return nil, err
+		}
+	}
+
+	running := make([]*corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			running = append(running, pod)
+		}
+	}
+	sort.Slice(running, func(i, j int) bool {
+		return running[i].Name < running[j].Name
+	})
+	return running, nil
+}
+
+// workloadSelector returns the pod selector of an apps/v1 workload.
+func (f *Forecaster) workloadSelector(namespace, apiVersion, kind, name string) (labels.Selector, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	if gv.Group != "apps" {
+		return nil, fmt.Errorf("workload %s %s is not supported, only apps workloads are", apiVersion, kind)
+	}
+
+	var selector *metav1.LabelSelector
+	switch kind {
+	case "Deployment":
+		workload, err := f.deploymentLister.Deployments(namespace).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		selector = workload.Spec.Selector
+	case "StatefulSet":
+		workload, err := f.statefulSetLister.StatefulSets(namespace).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		selector = workload.Spec.Selector
+	case "DaemonSet":
+		workload, err := f.daemonSetLister.DaemonSets(namespace).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		selector = workload.Spec.Selector
+	case "ReplicaSet":
+		workload, err := f.replicaSetLister.ReplicaSets(namespace).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		selector = workload.Spec.Selector
+	default:
+		return nil, fmt.Errorf("workload kind %s is not supported", kind)
+	}
+	if selector == nil {
+		return labels.Nothing(), nil
+	}
+	return metav1.LabelSelectorAsSelector(selector)
+}
@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gocrane-io/api/pkg/metricsource"
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// syncNodePrediction refreshes the predicted consumption of a node every period. A NodePrediction has no start
+// nor end, it is charging until the history of its metrics is long enough and predicting afterwards.
+func (c *Controller) syncNodePrediction(ctx context.Context, key string) (time.Duration, error) {
+	np, err := c.nodePredictionLister.Get(key)
+	if errors.IsNotFound(err) {
+		c.forecasts.put(nodePredictionKind+"/"+key, nil)
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	defaulted := np.DeepCopy()
+	v1alpha1.SetObjectDefaults_NodePrediction(defaulted)
+	spec := &defaulted.Spec
+	status := np.Status.DeepCopy()
+	status.ObservedGeneration = np.Generation
+	now := c.now()
+
+	target := metricsource.Target{Node: spec.NodeName}
+	predictions, estimators, observed, err := c.predict(ctx, spec.MetricPredictionConfigs, target, spec.Mode, now, spec.Period.Duration, v1alpha1.DefaultPredictionLength)
+	if err != nil {
+		markFailed(&status.Conditions, err)
+		status.Status = helper.StatusFromConditions(status.Conditions)
+		if updateErr := c.updateNodePredictionStatus(ctx, np, status); updateErr != nil {
+			return 0, updateErr
+		}
+		return 0, err
+	}
+	forecastKey := nodePredictionKind + "/" + key
+	var remaining map[string]timeseries.Series
+	status.Accuracy, remaining = evaluate(spec.MetricPredictionConfigs, c.pendingForecasts(forecastKey, np.Status.Consumed),
+		observed, horizon(spec.Mode), now, np.Status.Accuracy)
+	c.retainForecasts(forecastKey, spec.Mode, remaining, predictions)
+	if len(predictions) == 0 {
+		enterPhase(&status.Conditions, v1alpha1.PredictionConditionCharging, ReasonInsufficientHistory,
+			"waiting for enough history of the metrics of the node")
+	} else {
+		enterPhase(&status.Conditions, v1alpha1.PredictionConditionPredicting, ReasonPredicted, "")
+		status.Consumed = formatPrediction(predictions)
+		status.Estimators = formatEstimators(singleEstimators(estimators))
+		status.LastUpdateTime = &metav1.Time{Time: now}
+	}
+
+	status.Status = helper.StatusFromConditions(status.Conditions)
+	return spec.Period.Duration, c.updateNodePredictionStatus(ctx, np, status)
+}
+
+func (c *Controller) updateNodePredictionStatus(ctx context.Context, np *v1alpha1.NodePrediction, status *v1alpha1.NodePredictionResourceStatus) error {
+	if apiequality.Semantic.DeepEqual(&np.Status, status) {
+		return nil
+	}
+	updated := np.DeepCopy()
+	updated.Status = *status
+	_, err := c.predictionClient.PredictionV1alpha1().NodePredictions().UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/gocrane-io/api/pkg/metricsource"
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// syncPodGroupPrediction drives the NotStarted, Charging, Predicting and Finished lifecycle of a PodGroupPrediction
+// between its start and end, and refreshes the predictions of its containers every sample interval.
+func (c *Controller) syncPodGroupPrediction(ctx context.Context, key string) (time.Duration, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return 0, err
+	}
+	pgp, err := c.podGroupPredictionLister.PodGroupPredictions(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		c.forecasts.put(podGroupPredictionKind+"/"+key, nil)
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	defaulted := pgp.DeepCopy()
+	v1alpha1.SetObjectDefaults_PodGroupPrediction(defaulted)
+	spec := &defaulted.Spec
+	status := pgp.Status.DeepCopy()
+	now := c.now()
+
+	var requeueAfter time.Duration
+	switch {
+	case spec.Start != nil && now.Before(spec.Start.Time):
+		enterPhase(&status.Conditions, v1alpha1.PredictionConditionNotStarted, ReasonBeforeStart,
+			fmt.Sprintf("the prediction starts at %s", spec.Start.UTC().Format(time.RFC3339)))
+		requeueAfter = spec.Start.Sub(now)
+	case spec.End != nil && !now.Before(spec.End.Time):
+		enterPhase(&status.Conditions, v1alpha1.PredictionConditionFinished, ReasonAfterEnd,
+			fmt.Sprintf("the prediction ended at %s", spec.End.UTC().Format(time.RFC3339)))
+	default:
+		predicted, err := c.predictPodGroup(ctx, defaulted, now)
+		if err != nil {
+			markFailed(&status.Conditions, err)
+			status.Status = helper.StatusFromConditions(status.Conditions)
+			if updateErr := c.updatePodGroupPredictionStatus(ctx, pgp, status); updateErr != nil {
+				return 0, updateErr
+			}
+			return 0, err
+		}
+		forecastKey := podGroupPredictionKind + "/" + key
+		var remaining map[string]timeseries.Series
+		status.Accuracy, remaining = evaluate(spec.MetricPredictionConfigs, c.pendingForecasts(forecastKey, pgp.Status.Aggregation),
+			predicted.observed, horizon(spec.Mode), now, pgp.Status.Accuracy)
+		c.retainForecasts(forecastKey, spec.Mode, remaining, predicted.aggregation)
+		if len(predicted.aggregation) == 0 {
+			enterPhase(&status.Conditions, v1alpha1.PredictionConditionCharging, ReasonInsufficientHistory,
+				"waiting for enough history of the metrics of the pod group")
+		} else {
+			enterPhase(&status.Conditions, v1alpha1.PredictionConditionPredicting, ReasonPredicted, "")
+			status.Aggregation = formatPrediction(predicted.aggregation)
+			status.Containers = formatContainers(predicted.containers)
+			status.Estimators = formatEstimators(predicted.estimators)
+			status.LastUpdateTime = &metav1.Time{Time: now}
+		}
+		requeueAfter = refreshInterval(spec.MetricPredictionConfigs)
+		if spec.End != nil && spec.End.Sub(now) < requeueAfter {
+			requeueAfter = spec.End.Sub(now)
+		}
+	}
+
+	status.Status = helper.StatusFromConditions(status.Conditions)
+	return requeueAfter, c.updatePodGroupPredictionStatus(ctx, pgp, status)
+}
+
+// podGroupPredictions are the predictions of the metrics of the containers of a pod group.
+type podGroupPredictions struct {
+	// aggregation are the sums of the predicted series of the containers.
+	aggregation map[string]timeseries.Series
+	// containers are the predicted series of the containers by namespace/pod/container key and metric.
+	containers map[string]map[string]timeseries.Series
+	// estimators are the estimators of every metric.
+	estimators map[string]sets.String
+	// observed are the sums of the observed histories of the containers, which the previous aggregation is
+	// evaluated against.
+	observed map[string]timeseries.Series
+}
+
+// predictPodGroup predicts the metrics of every container of the pod group and their sum.
+func (c *Controller) predictPodGroup(ctx context.Context, pgp *v1alpha1.PodGroupPrediction, now time.Time) (*podGroupPredictions, error) {
+	pods, err := c.podsOf(pgp)
+	if err != nil {
+		return nil, err
+	}
+
+	predicted := &podGroupPredictions{
+		aggregation: make(map[string]timeseries.Series),
+		containers:  make(map[string]map[string]timeseries.Series),
+		estimators:  make(map[string]sets.String),
+		observed:    make(map[string]timeseries.Series),
+	}
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			target := metricsource.Target{Namespace: pod.Namespace, Pod: pod.Name, Container: container.Name}
+			predictions, containerEstimators, observed, err := c.predict(ctx, pgp.Spec.MetricPredictionConfigs, target, pgp.Spec.Mode, now, 0, pgp.Spec.PredictionLength.Duration)
+			if err != nil {
+				return nil, fmt.Errorf("failed to predict container %s: %v", target, err)
+			}
+			for metric, history := range observed {
+				predicted.observed[metric] = sum(predicted.observed[metric], history)
+			}
+			if len(predictions) == 0 {
+				continue
+			}
+			predicted.containers[target.String()] = predictions
+			for metric, series := range predictions {
+				predicted.aggregation[metric] = sum(predicted.aggregation[metric], series)
+				if predicted.estimators[metric] == nil {
+					predicted.estimators[metric] = sets.NewString()
+				}
+				predicted.estimators[metric].Insert(containerEstimators[metric])
+			}
+		}
+	}
+	return predicted, nil
+}
+
+// Bounds of the size of the predictions of the containers in the status, which must stay far below the size
+// limit of an object.
+const (
+	// MaxContainerSeriesPoints is the largest number of vectors of a predicted series of a container.
+	MaxContainerSeriesPoints = 24
+	// MaxContainerVectors is the largest number of vectors of the predictions of all the containers, the
+	// series of a large pod group are reduced to fewer vectors, down to one.
+	MaxContainerVectors = 2400
+)
+
+// formatContainers formats the predictions of the containers. Unlike the aggregation, the series of the
+// containers are downsampled to their peaks so that the status of a pod group of many containers predicted
+// over a long range stays small.
+func formatContainers(containers map[string]map[string]timeseries.Series) map[string]v1alpha1.Prediction {
+	numSeries := 0
+	for _, predictions := range containers {
+		numSeries += len(predictions)
+	}
+	points := MaxContainerSeriesPoints
+	if numSeries > 0 && MaxContainerVectors/numSeries < points {
+		points = MaxContainerVectors / numSeries
+	}
+	if points < 1 {
+		points = 1
+	}
+
+	formatted := make(map[string]v1alpha1.Prediction, len(containers))
+	for key, predictions := range containers {
+		downsampled := make(map[string]timeseries.Series, len(predictions))
+		for metric, series := range predictions {
+			downsampled[metric] = downsample(series, points)
+		}
+		formatted[key] = formatPrediction(downsampled)
+	}
+	return formatted
+}
+
+// refreshInterval returns the shortest sample interval of the metrics.
+func refreshInterval(configs []v1alpha1.AlgorithmProviderConfig) time.Duration {
+	var interval time.Duration
+	for i := range configs {
+		if d := sampleInterval(&configs[i]); interval == 0 || d < interval {
+			interval = d
+		}
+	}
+	if interval == 0 {
+		interval = parseDuration(v1alpha1.DefaultSampleInterval, v1alpha1.DefaultSampleInterval)
+	}
+	return interval
+}
+
+func (c *Controller) updatePodGroupPredictionStatus(ctx context.Context, pgp *v1alpha1.PodGroupPrediction, status *v1alpha1.PodGroupPredictionStatus) error {
+	if apiequality.Semantic.DeepEqual(&pgp.Status, status) {
+		return nil
+	}
+	updated := pgp.DeepCopy()
+	updated.Status = *status
+	_, err := c.predictionClient.PredictionV1alpha1().PodGroupPredictions(pgp.Namespace).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
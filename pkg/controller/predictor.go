@@ -0,0 +1,307 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/gocrane-io/api/pkg/estimator"
+	"github.com/gocrane-io/api/pkg/metricsource"
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// ErrInsufficientHistory is returned by a Predictor that does not have enough history to predict yet,
+// the prediction keeps charging until it can. It is the error of the estimators so that a Predictor can
+// return their errors as is.
+var ErrInsufficientHistory = estimator.ErrInsufficientHistory
+
+// Predictor forecasts a metric from its history according to the config of the metric.
+type Predictor interface {
+	// Predict returns the predicted samples of the metric at the given unix timestamps, and the name of the
+	// estimator that produced them which is recorded in the status.
+	Predict(config *v1alpha1.AlgorithmProviderConfig, history timeseries.Series, timestamps []int64) (timeseries.Series, string, error)
+}
+
+// MaxValuePredictor predicts the largest value of the history at every timestamp, whatever the config.
+type MaxValuePredictor struct{}
+
+var _ Predictor = MaxValuePredictor{}
+
+// Predict implements Predictor.
+func (MaxValuePredictor) Predict(_ *v1alpha1.AlgorithmProviderConfig, history timeseries.Series, timestamps []int64) (timeseries.Series, string, error) {
+	predicted, err := estimator.MaxValueEstimator{}.Estimate(history, timestamps)
+	return predicted, "maxValue", err
+}
+
+// algorithms returns every algorithm that may predict the metric: its algorithm or the members of its
+// ensemble, and its fallbacks.
+func algorithms(config *v1alpha1.AlgorithmProviderConfig) []v1alpha1.AlgorithmConfig {
+	var all []v1alpha1.AlgorithmConfig
+	if config.Ensemble != nil {
+		for i := range config.Ensemble.Members {
+			all = append(all, config.Ensemble.Members[i].AlgorithmConfig)
+		}
+	} else {
+		all = append(all, v1alpha1.AlgorithmConfig{DSP: config.DSP, Percentile: config.Percentile, Custom: config.Custom})
+	}
+	return append(all, config.Fallbacks...)
+}
+
+// sampleInterval returns the sampling interval of the history of the metric, the shortest of its algorithms.
+func sampleInterval(config *v1alpha1.AlgorithmProviderConfig) time.Duration {
+	var shortest time.Duration
+	for _, algorithm := range algorithms(config) {
+		interval := v1alpha1.DefaultSampleInterval
+		switch {
+		case algorithm.DSP != nil && algorithm.DSP.SampleInterval != "":
+			interval = algorithm.DSP.SampleInterval
+		case algorithm.Percentile != nil && algorithm.Percentile.SampleInterval != "":
+			interval = algorithm.Percentile.SampleInterval
+		case algorithm.Custom != nil && algorithm.Custom.SampleInterval != "":
+			interval = algorithm.Custom.SampleInterval
+		}
+		if d := parseDuration(interval, v1alpha1.DefaultSampleInterval); shortest == 0 || d < shortest {
+			shortest = d
+		}
+	}
+	return shortest
+}
+
+// historyLength returns how far back the history of the metric is queried, the longest of its algorithms.
+func historyLength(config *v1alpha1.AlgorithmProviderConfig) time.Duration {
+	var longest time.Duration
+	for _, algorithm := range algorithms(config) {
+		length := v1alpha1.DefaultHistoryLength
+		switch {
+		case algorithm.DSP != nil && algorithm.DSP.HistoryLength != "":
+			length = algorithm.DSP.HistoryLength
+		case algorithm.Custom != nil && algorithm.Custom.HistoryLength != "":
+			length = algorithm.Custom.HistoryLength
+		}
+		if d := parseDuration(length, v1alpha1.DefaultHistoryLength); d > longest {
+			longest = d
+		}
+	}
+	return longest
+}
+
+// parseDuration parses a positive duration, falling back to the default one which must be valid.
+func parseDuration(value, defaultValue string) time.Duration {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	d, _ := time.ParseDuration(defaultValue)
+	return d
+}
+
+// InstantPredictionLength is the time range whose maximum is predicted by PredictionModeInstant.
+const InstantPredictionLength = time.Hour
+
+// timestamps returns the unix timestamps predicted after now: every multiple of step within length for
+// PredictionModeRange, within InstantPredictionLength for PredictionModeInstant.
+func timestamps(mode v1alpha1.PredictionMode, now time.Time, step, length time.Duration) []int64 {
+	seconds := int64(step / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	next := (now.Unix()/seconds + 1) * seconds
+	if mode != v1alpha1.PredictionModeRange {
+		length = InstantPredictionLength
+	}
+	end := now.Add(length).Unix()
+	if end < next {
+		return []int64{next}
+	}
+	var ts []int64
+	for t := next; t <= end; t += seconds {
+		ts = append(ts, t)
+	}
+	return ts
+}
+
+// predict predicts every metric of the target at the timestamps of the mode, every period, or every
+// sample interval of the metric for a zero period. A PredictionModeInstant prediction is reduced to its peak
+// at the first timestamp. It returns the estimators that produced them and the
+// observed histories they were predicted from. The metrics without enough history are left out.
+func (f *Forecaster) predict(ctx context.Context, configs []v1alpha1.AlgorithmProviderConfig, target metricsource.Target,
+	mode v1alpha1.PredictionMode, now time.Time, period, length time.Duration) (map[string]timeseries.Series, map[string]string, map[string]timeseries.Series, error) {
+	predictions := make(map[string]timeseries.Series, len(configs))
+	estimators := make(map[string]string, len(configs))
+	observed := make(map[string]timeseries.Series, len(configs))
+	for i := range configs {
+		config := &configs[i]
+		step := period
+		if step == 0 {
+			step = sampleInterval(config)
+		}
+
+		history, predicted, name, err := f.predictMetric(ctx, config, target, now, timestamps(mode, now, step, length))
+		if errors.Is(err, metricsource.ErrNotFound) {
+			continue
+		}
+		if err != nil && !errors.Is(err, ErrInsufficientHistory) {
+			return nil, nil, nil, err
+		}
+		observed[config.MetricName] = history
+		if err == nil && len(predicted) > 0 {
+			if mode != v1alpha1.PredictionModeRange {
+				predicted = timeseries.Series{peak(predicted)}
+			}
+			predictions[config.MetricName] = predicted
+			estimators[config.MetricName] = name
+		}
+	}
+	return predictions, estimators, observed, nil
+}
+
+// predictMetric queries the history of the metric of the target until now and predicts the metric at the
+// timestamps. The history is also returned when the prediction fails.
+func (f *Forecaster) predictMetric(ctx context.Context, config *v1alpha1.AlgorithmProviderConfig, target metricsource.Target,
+	now time.Time, timestamps []int64) (history, predicted timeseries.Series, name string, err error) {
+	history, err = f.source.QueryRange(ctx, metricsource.SpecOf(config), target, now.Add(-historyLength(config)), now, sampleInterval(config))
+	if err != nil {
+		return nil, nil, "", err
+	}
+	predicted, name, err = f.predictor.Predict(config, history, timestamps)
+	return history, predicted, name, err
+}
+
+// sum adds the samples of the same timestamp. The bounds and the quantiles of the sum are the sums of the
+// ones of the samples, a conservative estimate that assumes the samples are fully correlated; those that are
+// not provided by both samples are left out.
+func sum(a, b timeseries.Series) timeseries.Series {
+	sums := make(map[int64]helper.Sample, len(a)+len(b))
+	for _, series := range []timeseries.Series{a, b} {
+		for _, s := range series {
+			total, ok := sums[s.Timestamp]
+			if !ok {
+				sums[s.Timestamp] = copySample(s)
+				continue
+			}
+			sums[s.Timestamp] = addSamples(total, s)
+		}
+	}
+	samples := make([]helper.Sample, 0, len(sums))
+	for _, s := range sums {
+		samples = append(samples, s)
+	}
+	return timeseries.New(samples)
+}
+
+// peak returns a sample at the timestamp of the first sample of the non empty series whose value, bounds and
+// quantiles are the largest ones of the series; the bounds and quantiles not provided by every sample are
+// left out.
+func peak(series timeseries.Series) helper.Sample {
+	max := copySample(series[0])
+	for _, s := range series[1:] {
+		max.Value = math.Max(max.Value, s.Value)
+		lowerMax, upperMax, okMax := max.Bounds()
+		lower, upper, ok := s.Bounds()
+		if okMax && ok {
+			max.SetBounds(math.Max(lowerMax, lower), math.Max(upperMax, upper))
+		} else {
+			max.LowerBound, max.UpperBound = nil, nil
+		}
+		for name, q := range max.Quantiles {
+			if other, ok := s.Quantiles[name]; ok {
+				max.Quantiles[name] = math.Max(q, other)
+			} else {
+				delete(max.Quantiles, name)
+			}
+		}
+	}
+	return max
+}
+
+// downsample reduces the series to at most n samples, the peaks of consecutive groups of samples.
+func downsample(series timeseries.Series, n int) timeseries.Series {
+	if n < 1 || len(series) <= n {
+		return series
+	}
+	size := (len(series) + n - 1) / n
+	reduced := make(timeseries.Series, 0, n)
+	for i := 0; i < len(series); i += size {
+		end := i + size
+		if end > len(series) {
+			end = len(series)
+		}
+		reduced = append(reduced, peak(series[i:end]))
+	}
+	return reduced
+}
+
+// addSamples returns the sum of two samples of the same timestamp.
+func addSamples(a, b helper.Sample) helper.Sample {
+	total := helper.Sample{Timestamp: a.Timestamp, Value: a.Value + b.Value}
+	lowerA, upperA, okA := a.Bounds()
+	lowerB, upperB, okB := b.Bounds()
+	if okA && okB {
+		total.SetBounds(lowerA+lowerB, upperA+upperB)
+	}
+	for name, qa := range a.Quantiles {
+		if qb, ok := b.Quantiles[name]; ok {
+			if total.Quantiles == nil {
+				total.Quantiles = make(map[string]float64, len(a.Quantiles))
+			}
+			total.Quantiles[name] = qa + qb
+		}
+	}
+	return total
+}
+
+// copySample returns a copy of the sample that does not share its bounds and quantiles.
+func copySample(s helper.Sample) helper.Sample {
+	if lower, upper, ok := s.Bounds(); ok {
+		s.SetBounds(lower, upper)
+	} else {
+		s.LowerBound, s.UpperBound = nil, nil
+	}
+	if s.Quantiles != nil {
+		quantiles := make(map[string]float64, len(s.Quantiles))
+		for name, q := range s.Quantiles {
+			quantiles[name] = q
+		}
+		s.Quantiles = quantiles
+	}
+	return s
+}
+
+// formatEstimators returns the estimators of the metrics sorted by metric. The estimators of a metric
+// predicted by several estimators, for the containers of a pod group, are sorted and separated by semicolons.
+func formatEstimators(estimators map[string]sets.String) []v1alpha1.MetricEstimator {
+	if len(estimators) == 0 {
+		return nil
+	}
+	formatted := make([]v1alpha1.MetricEstimator, 0, len(estimators))
+	for metric, names := range estimators {
+		formatted = append(formatted, v1alpha1.MetricEstimator{MetricName: metric, Estimator: strings.Join(names.List(), ";")})
+	}
+	sort.Slice(formatted, func(i, j int) bool {
+		return formatted[i].MetricName < formatted[j].MetricName
+	})
+	return formatted
+}
+
+// singleEstimators returns the estimators of metrics predicted by a single estimator in the form of formatEstimators.
+func singleEstimators(estimators map[string]string) map[string]sets.String {
+	named := make(map[string]sets.String, len(estimators))
+	for metric, name := range estimators {
+		named[metric] = sets.NewString(name)
+	}
+	return named
+}
+
+func formatPrediction(predictions map[string]timeseries.Series) v1alpha1.Prediction {
+	samples := make(map[string]helper.Samples, len(predictions))
+	for metric, series := range predictions {
+		samples[metric] = helper.Samples(series)
+	}
+	return helper.FormatPrediction(samples)
+}
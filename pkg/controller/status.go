@@ -0,0 +1,51 @@
+package controller
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// Reasons of the conditions set by the controller.
+const (
+	ReasonBeforeStart         = "BeforeStart"
+	ReasonAfterEnd            = "AfterEnd"
+	ReasonInsufficientHistory = "InsufficientHistory"
+	ReasonPredicted           = "Predicted"
+	ReasonPredictionFailed    = "PredictionFailed"
+)
+
+// phaseConditions are the conditions of the successive phases of a prediction, only one of them is true at a time.
+var phaseConditions = []v1alpha1.PredictionConditionType{
+	v1alpha1.PredictionConditionNotStarted,
+	v1alpha1.PredictionConditionCharging,
+	v1alpha1.PredictionConditionPredicting,
+	v1alpha1.PredictionConditionFinished,
+}
+
+// enterPhase sets the condition of the phase to true and the existing conditions of the other phases to false.
+// A prediction entering a phase is not failing anymore.
+func enterPhase(conditions *[]v1alpha1.PredictionCondition, phase v1alpha1.PredictionConditionType, reason, message string) {
+	for _, conditionType := range phaseConditions {
+		switch {
+		case conditionType == phase:
+			helper.SetCondition(conditions, v1alpha1.PredictionCondition{Type: conditionType, Status: v1.ConditionTrue, Reason: reason, Message: message})
+		case helper.GetCondition(*conditions, conditionType) != nil:
+			helper.SetCondition(conditions, v1alpha1.PredictionCondition{Type: conditionType, Status: v1.ConditionFalse, Reason: reason})
+		}
+	}
+	if helper.GetCondition(*conditions, v1alpha1.PredictionConditionFailed) != nil {
+		helper.SetCondition(conditions, v1alpha1.PredictionCondition{Type: v1alpha1.PredictionConditionFailed, Status: v1.ConditionFalse, Reason: reason})
+	}
+}
+
+// markFailed sets the failed condition, the conditions of the phases are left untouched.
+func markFailed(conditions *[]v1alpha1.PredictionCondition, err error) {
+	helper.SetCondition(conditions, v1alpha1.PredictionCondition{
+		Type:    v1alpha1.PredictionConditionFailed,
+		Status:  v1.ConditionTrue,
+		Reason:  ReasonPredictionFailed,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,173 @@
+package estimator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// Tracer is implemented by the estimators that delegate to other estimators, to tell which of them produced
+// an estimation.
+type Tracer interface {
+	Estimator
+	// EstimateTraced is Estimate that also returns the name of the estimators that produced the estimation.
+	EstimateTraced(history timeseries.Series, timestamps []int64) (timeseries.Series, string, error)
+}
+
+// Named is an estimator and its name.
+type Named struct {
+	Name      string
+	Estimator Estimator
+}
+
+// Trace estimates the history with the named estimator, and returns its name followed by the names of the
+// estimators it delegated to in parentheses, for example dsp(fft).
+func Trace(n Named, history timeseries.Series, timestamps []int64) (timeseries.Series, string, error) {
+	tracer, ok := n.Estimator.(Tracer)
+	if !ok {
+		estimation, err := n.Estimator.Estimate(history, timestamps)
+		return estimation, n.Name, err
+	}
+	estimation, name, err := tracer.EstimateTraced(history, timestamps)
+	if name == "" {
+		return estimation, n.Name, err
+	}
+	return estimation, n.Name + "(" + name + ")", err
+}
+
+// CanFallBack returns whether an estimation failed because of the history, too short or not periodic, rather
+// than because of the config, in which case another estimator may succeed.
+func CanFallBack(err error) bool {
+	return errors.Is(err, ErrInsufficientHistory) || errors.Is(err, ErrNotPeriodic)
+}
+
+// Chain estimates the history with the first of its estimators that can, an estimator falls back to the
+// next one when CanFallBack its error. The error of the last estimator is returned when none can.
+type Chain []Named
+
+var _ Tracer = Chain{}
+
+// Estimate implements Estimator.
+func (c Chain) Estimate(history timeseries.Series, timestamps []int64) (timeseries.Series, error) {
+	estimation, _, err := c.EstimateTraced(history, timestamps)
+	return estimation, err
+}
+
+// EstimateTraced implements Tracer.
+func (c Chain) EstimateTraced(history timeseries.Series, timestamps []int64) (timeseries.Series, string, error) {
+	err := errors.New("no estimator is configured")
+	for _, n := range c {
+		var estimation timeseries.Series
+		var name string
+		estimation, name, err = Trace(n, history, timestamps)
+		if err == nil {
+			return estimation, name, nil
+		}
+		if !CanFallBack(err) {
+			return nil, "", fmt.Errorf("%s: %w", n.Name, err)
+		}
+	}
+	return nil, "", err
+}
+
+// EnsembleMember is an estimator of an ensemble and its weight.
+type EnsembleMember struct {
+	Named
+	Weight float64
+}
+
+// Ensemble estimates the history with the weighted mean of the estimations of its members. The members whose
+// estimation fails with an error that CanFallBack are left out, if all are the error of the last member is
+// returned. The bounds and the quantiles are the weighted means of the ones of the members, those that are not
+// provided by every member that contributed are left out.
+type Ensemble []EnsembleMember
+
+var _ Tracer = Ensemble{}
+
+// Estimate implements Estimator.
+func (e Ensemble) Estimate(history timeseries.Series, timestamps []int64) (timeseries.Series, error) {
+	estimation, _, err := e.EstimateTraced(history, timestamps)
+	return estimation, err
+}
+
+// EstimateTraced implements Tracer, the name lists the members that contributed with their weights, for
+// example dsp(fft):2,percentile:1.
+func (e Ensemble) EstimateTraced(history timeseries.Series, timestamps []int64) (timeseries.Series, string, error) {
+	err := errors.New("the ensemble has no member")
+	sums := make([]weightedSum, len(timestamps))
+	var totalWeight float64
+	var names []string
+	for _, member := range e {
+		var estimation timeseries.Series
+		var name string
+		estimation, name, err = Trace(member.Named, history, timestamps)
+		if err != nil {
+			if !CanFallBack(err) {
+				return nil, "", fmt.Errorf("%s: %w", member.Name, err)
+			}
+			continue
+		}
+		if len(estimation) != len(timestamps) {
+			return nil, "", fmt.Errorf("%s estimated %d samples for %d timestamps", member.Name, len(estimation), len(timestamps))
+		}
+		for i, s := range estimation {
+			sums[i].add(s, member.Weight)
+		}
+		totalWeight += member.Weight
+		names = append(names, fmt.Sprintf("%s:%s", name, helper.FormatValue(member.Weight)))
+	}
+	if totalWeight == 0 {
+		return nil, "", err
+	}
+
+	estimation := make(timeseries.Series, 0, len(timestamps))
+	for i, t := range timestamps {
+		estimation = append(estimation, sums[i].mean(t, totalWeight))
+	}
+	return estimation, strings.Join(names, ","), nil
+}
+
+// weightedSum sums weighted samples, keeping the weight of their bounds and of every quantile.
+type weightedSum struct {
+	value           float64
+	lower, upper    float64
+	boundsWeight    float64
+	quantiles       map[string]float64
+	quantileWeights map[string]float64
+}
+
+func (w *weightedSum) add(s helper.Sample, weight float64) {
+	w.value += weight * s.Value
+	if lower, upper, ok := s.Bounds(); ok {
+		w.lower += weight * lower
+		w.upper += weight * upper
+		w.boundsWeight += weight
+	}
+	for name, q := range s.Quantiles {
+		if w.quantiles == nil {
+			w.quantiles, w.quantileWeights = make(map[string]float64), make(map[string]float64)
+		}
+		w.quantiles[name] += weight * q
+		w.quantileWeights[name] += weight
+	}
+}
+
+// mean returns the weighted mean sample, with the bounds and the quantiles of all the weight.
+func (w *weightedSum) mean(t int64, totalWeight float64) helper.Sample {
+	sample := helper.Sample{Timestamp: t, Value: w.value / totalWeight}
+	if w.boundsWeight == totalWeight {
+		sample.SetBounds(w.lower/totalWeight, w.upper/totalWeight)
+	}
+	for name, q := range w.quantiles {
+		if w.quantileWeights[name] == totalWeight {
+			if sample.Quantiles == nil {
+				sample.Quantiles = make(map[string]float64, len(w.quantiles))
+			}
+			sample.Quantiles[name] = q / totalWeight
+		}
+	}
+	return sample
+}
@@ -0,0 +1,214 @@
+package estimator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gocrane-io/api/pkg/timeseries"
+)
+
+// stub estimates a constant value with optional bounds and quantiles, or fails with its error.
+type stub struct {
+	value     float64
+	bounds    *[2]float64
+	quantiles map[string]float64
+	err       error
+	calls     int
+}
+
+func (s *stub) Estimate(_ timeseries.Series, timestamps []int64) (timeseries.Series, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	estimation := constant(s.value, timestamps)
+	for i := range estimation {
+		if s.bounds != nil {
+			estimation[i].SetBounds(s.bounds[0], s.bounds[1])
+		}
+		for name, q := range s.quantiles {
+			if estimation[i].Quantiles == nil {
+				estimation[i].Quantiles = make(map[string]float64, len(s.quantiles))
+			}
+			estimation[i].Quantiles[name] = q
+		}
+	}
+	return estimation, nil
+}
+
+// stubTracer delegates to its estimator and traces it under its name.
+type stubTracer struct {
+	Named
+}
+
+func (s stubTracer) Estimate(history timeseries.Series, timestamps []int64) (timeseries.Series, error) {
+	return s.Estimator.Estimate(history, timestamps)
+}
+
+func (s stubTracer) EstimateTraced(history timeseries.Series, timestamps []int64) (timeseries.Series, string, error) {
+	return Trace(s.Named, history, timestamps)
+}
+
+// stubTimestamps are the timestamps estimated by the stubs.
+var stubTimestamps = []int64{60, 120}
+
+func TestTrace(t *testing.T) {
+	cases := []struct {
+		name      string
+		estimator Named
+		expected  string
+	}{
+		{name: "plain", estimator: Named{Name: "percentile", Estimator: &stub{}}, expected: "percentile"},
+		{name: "tracer", estimator: Named{Name: "dsp", Estimator: stubTracer{Named{Name: "fft", Estimator: &stub{}}}}, expected: "dsp(fft)"},
+		{name: "nested", estimator: Named{Name: "a", Estimator: stubTracer{Named{Name: "b", Estimator: stubTracer{Named{Name: "c", Estimator: &stub{}}}}}}, expected: "a(b(c))"},
+		{name: "empty trace", estimator: Named{Name: "dsp", Estimator: Chain{}}, expected: "dsp"},
+	}
+	for _, c := range cases {
+		if _, name, _ := Trace(c.estimator, nil, stubTimestamps); name != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.name, c.expected, name)
+		}
+	}
+}
+
+func TestChain(t *testing.T) {
+	hard := errors.New("invalid config")
+	cases := []struct {
+		name     string
+		first    *stub
+		second   *stub
+		expected string
+		value    float64
+		err      error
+		calls    [2]int
+	}{
+		{
+			name:     "first succeeds",
+			first:    &stub{value: 1},
+			second:   &stub{value: 2},
+			expected: "first",
+			value:    1,
+			calls:    [2]int{1, 0},
+		},
+		{
+			name:     "falls back on insufficient history",
+			first:    &stub{err: ErrInsufficientHistory},
+			second:   &stub{value: 2},
+			expected: "second",
+			value:    2,
+			calls:    [2]int{1, 1},
+		},
+		{
+			name:     "falls back on a wrapped not periodic",
+			first:    &stub{err: fmt.Errorf("estimating: %w", ErrNotPeriodic)},
+			second:   &stub{value: 2},
+			expected: "second",
+			value:    2,
+			calls:    [2]int{1, 1},
+		},
+		{
+			name:   "stops on a hard error",
+			first:  &stub{err: hard},
+			second: &stub{value: 2},
+			err:    hard,
+			calls:  [2]int{1, 0},
+		},
+		{
+			name:   "returns the last error",
+			first:  &stub{err: ErrNotPeriodic},
+			second: &stub{err: ErrInsufficientHistory},
+			err:    ErrInsufficientHistory,
+			calls:  [2]int{1, 1},
+		},
+	}
+	for _, c := range cases {
+		chain := Chain{{Name: "first", Estimator: c.first}, {Name: "second", Estimator: c.second}}
+		estimation, name, err := chain.EstimateTraced(nil, stubTimestamps)
+		switch {
+		case c.err != nil && !errors.Is(err, c.err):
+			t.Errorf("%s: expected the error %v, got %v", c.name, c.err, err)
+		case c.err == nil && err != nil:
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+		case c.err == nil && (name != c.expected || len(estimation) != 2 || estimation[0].Value != c.value):
+			t.Errorf("%s: expected %g by %s, got %v by %s", c.name, c.value, c.expected, estimation, name)
+		}
+		if calls := [2]int{c.first.calls, c.second.calls}; calls != c.calls {
+			t.Errorf("%s: expected the calls %v, got %v", c.name, c.calls, calls)
+		}
+	}
+	if _, err := (Chain{}).Estimate(nil, stubTimestamps); err == nil || CanFallBack(err) {
+		t.Errorf("expected a hard error for an empty chain, got %v", err)
+	}
+}
+
+func TestEnsemble(t *testing.T) {
+	ensemble := Ensemble{
+		{Named: Named{Name: "dsp", Estimator: stubTracer{Named{Name: "fft", Estimator: &stub{value: 10, bounds: &[2]float64{8, 12}, quantiles: map[string]float64{"p50": 10, "p90": 11}}}}}, Weight: 3},
+		{Named: Named{Name: "short", Estimator: &stub{err: ErrInsufficientHistory}}, Weight: 5},
+		{Named: Named{Name: "percentile", Estimator: &stub{value: 20, quantiles: map[string]float64{"p50": 18}}}, Weight: 1},
+	}
+	estimation, name, err := ensemble.EstimateTraced(nil, stubTimestamps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "dsp(fft):3,percentile:1"; name != expected {
+		t.Errorf("expected the name %s, got %s", expected, name)
+	}
+	if len(estimation) != len(stubTimestamps) {
+		t.Fatalf("expected %d samples, got %v", len(stubTimestamps), estimation)
+	}
+	for i, s := range estimation {
+		if s.Timestamp != stubTimestamps[i] || s.Value != 12.5 {
+			t.Errorf("expected 12.5 at %d, got %v", stubTimestamps[i], s)
+		}
+		if _, _, ok := s.Bounds(); ok {
+			t.Errorf("expected the bounds of a single member to be dropped, got %v", s)
+		}
+		if len(s.Quantiles) != 1 || s.Quantiles["p50"] != 12 {
+			t.Errorf("expected only the p50 quantile 12, got %v", s.Quantiles)
+		}
+	}
+
+	bounded := Ensemble{
+		{Named: Named{Name: "a", Estimator: &stub{value: 10, bounds: &[2]float64{8, 12}}}, Weight: 1},
+		{Named: Named{Name: "b", Estimator: &stub{value: 20, bounds: &[2]float64{16, 24}}}, Weight: 1},
+	}
+	estimation, _, _ = bounded.EstimateTraced(nil, stubTimestamps)
+	if lower, upper, ok := estimation[0].Bounds(); !ok || lower != 12 || upper != 18 {
+		t.Errorf("expected the bounds [12, 18], got %v", estimation[0])
+	}
+}
+
+func TestEnsembleErrors(t *testing.T) {
+	hard := errors.New("invalid config")
+	cases := []struct {
+		name     string
+		ensemble Ensemble
+		err      error
+	}{
+		{
+			name: "hard error",
+			ensemble: Ensemble{
+				{Named: Named{Name: "a", Estimator: &stub{value: 1}}, Weight: 1},
+				{Named: Named{Name: "b", Estimator: &stub{err: hard}}, Weight: 1},
+			},
+			err: hard,
+		},
+		{
+			name: "every member falls back",
+			ensemble: Ensemble{
+				{Named: Named{Name: "a", Estimator: &stub{err: ErrNotPeriodic}}, Weight: 1},
+				{Named: Named{Name: "b", Estimator: &stub{err: ErrInsufficientHistory}}, Weight: 1},
+			},
+			err: ErrInsufficientHistory,
+		},
+	}
+	for _, c := range cases {
+		if _, err := c.ensemble.Estimate(nil, stubTimestamps); !errors.Is(err, c.err) {
+			t.Errorf("%s: expected the error %v, got %v", c.name, c.err, err)
+		}
+	}
+	if _, err := (Ensemble{}).Estimate(nil, stubTimestamps); err == nil || CanFallBack(err) {
+		t.Errorf("expected a hard error for an empty ensemble, got %v", err)
+	}
+}
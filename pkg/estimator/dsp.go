@@ -0,0 +1,77 @@
+package estimator
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+)
+
+// DSP is the estimator of a DspConfig. It keeps the last HistoryLength of the history and estimates it
+// with the configured estimators in the order FFT, Holt-Winters, linear regression and max value: an
+// estimator that finds the history not periodic or too short falls back to the next one.
+type DSP struct {
+	historyLength time.Duration
+	estimators    Chain
+}
+
+var _ Tracer = &DSP{}
+
+// NewDSP returns the estimator of the config, which is expected to be defaulted.
+func NewDSP(config *v1alpha1.DspConfig) (*DSP, error) {
+	sampleInterval, err := parseDuration("sampleInterval", config.SampleInterval)
+	if err != nil {
+		return nil, err
+	}
+	historyLength, err := parseDuration("historyLength", config.HistoryLength)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &DSP{historyLength: historyLength}
+	if config.Estimators != nil && config.Estimators.FFT != nil {
+		fft, err := NewFFTEstimator(config.Estimators.FFT, sampleInterval)
+		if err != nil {
+			return nil, err
+		}
+		d.estimators = append(d.estimators, Named{Name: "fft", Estimator: fft})
+	}
+	if config.Estimators != nil && config.Estimators.HoltWinters != nil {
+		hw, err := NewHoltWintersEstimator(config.Estimators.HoltWinters, sampleInterval)
+		if err != nil {
+			return nil, err
+		}
+		d.estimators = append(d.estimators, Named{Name: "holtWinters", Estimator: hw})
+	}
+	if config.Estimators != nil && config.Estimators.LinearRegression != nil {
+		lr, err := NewLinearRegressionEstimator(config.Estimators.LinearRegression)
+		if err != nil {
+			return nil, err
+		}
+		d.estimators = append(d.estimators, Named{Name: "linearRegression", Estimator: lr})
+	}
+	if config.Estimators != nil && config.Estimators.MaxValue != nil {
+		d.estimators = append(d.estimators, Named{Name: "maxValue", Estimator: MaxValueEstimator{}})
+	}
+	if len(d.estimators) == 0 {
+		return nil, errors.New("no estimator is configured")
+	}
+	return d, nil
+}
+
+// Estimate implements Estimator.
+func (d *DSP) Estimate(history timeseries.Series, timestamps []int64) (timeseries.Series, error) {
+	estimation, _, err := d.EstimateTraced(history, timestamps)
+	return estimation, err
+}
+
+// EstimateTraced implements Tracer, the name is the one of the estimator field of the DspConfig, for example fft.
+func (d *DSP) EstimateTraced(history timeseries.Series, timestamps []int64) (timeseries.Series, string, error) {
+	end, err := history.End()
+	if err != nil {
+		return nil, "", ErrInsufficientHistory
+	}
+	history = history.Window(end-int64(d.historyLength/time.Second), end+1)
+	return d.estimators.EstimateTraced(history, timestamps)
+}
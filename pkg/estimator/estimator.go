@@ -0,0 +1,98 @@
+// Package estimator implements the estimators of the prediction API: they forecast a metric from the
+// history of its samples, as configured by the DspConfig or the PercentileConfig of an AlgorithmProviderConfig.
+package estimator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+var (
+	// ErrInsufficientHistory is returned when the history is too short to estimate anything.
+	ErrInsufficientHistory = errors.New("insufficient history to estimate")
+	// ErrNotPeriodic is returned by the FFT estimator when the history has no significant spectrum item.
+	ErrNotPeriodic = errors.New("history is not periodic")
+)
+
+// Estimator forecasts a metric from its history.
+type Estimator interface {
+	// Estimate returns the estimated samples at the given unix timestamps, which are expected to follow the history.
+	Estimate(history timeseries.Series, timestamps []int64) (timeseries.Series, error)
+}
+
+// MaxValueEstimator estimates the largest value of the history at every timestamp.
+type MaxValueEstimator struct{}
+
+var _ Estimator = MaxValueEstimator{}
+
+// Estimate implements Estimator.
+func (MaxValueEstimator) Estimate(history timeseries.Series, timestamps []int64) (timeseries.Series, error) {
+	max, err := history.Max()
+	if err == timeseries.ErrEmpty {
+		return nil, ErrInsufficientHistory
+	}
+	if err != nil {
+		return nil, err
+	}
+	return constant(max.Value, timestamps), nil
+}
+
+func constant(value float64, timestamps []int64) timeseries.Series {
+	s := make(timeseries.Series, 0, len(timestamps))
+	for _, t := range timestamps {
+		s = append(s, helper.Sample{Timestamp: t, Value: value})
+	}
+	return s
+}
+
+// quantile returns the quantile q in [0, 1] of sorted values, interpolating linearly between the values.
+func quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	position := q * float64(len(sorted)-1)
+	i := int(math.Floor(position))
+	if i >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (position-float64(i))*(sorted[i+1]-sorted[i])
+}
+
+// uniform resamples the history on multiples of step, averaging the samples of a step, and fills the gaps
+// by linear interpolation so that there is exactly one sample every step.
+func uniform(history timeseries.Series, step time.Duration) (timeseries.Series, error) {
+	seconds := int64(step / time.Second)
+	if seconds < 1 {
+		return nil, fmt.Errorf("sample interval %s must be at least one second", step)
+	}
+	resampled, err := history.Resample(seconds, timeseries.AggregateMean)
+	if err != nil {
+		return nil, err
+	}
+	return resampled.Fill(seconds, 0)
+}
+
+func parseFloat(name, value string) (float64, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %v", name, value, err)
+	}
+	return f, nil
+}
+
+func parseDuration(name, value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %v", name, value, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be positive", name, value)
+	}
+	return d, nil
+}
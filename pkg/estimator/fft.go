@@ -0,0 +1,187 @@
+package estimator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// FFTEstimator forecasts a periodic metric by extending the dominant frequencies of its history.
+//
+// The history is resampled every sample interval, the N samples are transformed by a discrete Fourier
+// transform and every spectrum item k in [1, N/2] becomes a cosine of frequency k/(N*interval) Hz whose
+// amplitude is in the unit of the metric. The items whose frequency is above HighFrequencyThreshold are
+// dropped and the others are ranked by decreasing amplitude: the first MinNumOfSpectrumItems items are always
+// kept, the following ones up to MaxNumOfSpectrumItems only while their amplitude is at least
+// LowAmplitudeThreshold. If no item reaches LowAmplitudeThreshold the history is not periodic and
+// ErrNotPeriodic is returned. The estimation is the mean of the history plus the kept cosines, extended after
+// the history, multiplied by 1+MarginFraction and floored at zero.
+//
+// The quantiles and the bounds of the estimation are the extended cosines plus the quantiles of the residuals
+// of the history, the differences between the history and the cosines, with the same margin as the value.
+type FFTEstimator struct {
+	sampleInterval         time.Duration
+	marginFraction         float64
+	lowAmplitudeThreshold  float64
+	highFrequencyThreshold float64
+	minNumOfSpectrumItems  int
+	maxNumOfSpectrumItems  int
+}
+
+var _ Estimator = &FFTEstimator{}
+
+// NewFFTEstimator returns the FFT estimator of the config for a history sampled every sampleInterval.
+// The config is expected to be defaulted.
+func NewFFTEstimator(config *v1alpha1.FFTEstimatorConfig, sampleInterval time.Duration) (*FFTEstimator, error) {
+	e := &FFTEstimator{
+		sampleInterval:        sampleInterval,
+		minNumOfSpectrumItems: int(config.MinNumOfSpectrumItems),
+		maxNumOfSpectrumItems: int(config.MaxNumOfSpectrumItems),
+	}
+	var err error
+	if e.marginFraction, err = parseFloat("marginFraction", config.MarginFraction); err != nil {
+		return nil, err
+	}
+	if e.lowAmplitudeThreshold, err = parseFloat("lowAmplitudeThreshold", config.LowAmplitudeThreshold); err != nil {
+		return nil, err
+	}
+	if e.highFrequencyThreshold, err = parseFloat("highFrequencyThreshold", config.HighFrequencyThreshold); err != nil {
+		return nil, err
+	}
+	if sampleInterval < time.Second {
+		return nil, fmt.Errorf("sample interval %s must be at least one second", sampleInterval)
+	}
+	if e.minNumOfSpectrumItems < 1 || e.maxNumOfSpectrumItems < e.minNumOfSpectrumItems {
+		return nil, fmt.Errorf("invalid number of spectrum items [%d, %d]", e.minNumOfSpectrumItems, e.maxNumOfSpectrumItems)
+	}
+	return e, nil
+}
+
+// spectrumItem is a cosine of the history.
+type spectrumItem struct {
+	// frequency in Hz.
+	frequency float64
+	amplitude float64
+	phase     float64
+}
+
+// Estimate implements Estimator.
+func (e *FFTEstimator) Estimate(history timeseries.Series, timestamps []int64) (timeseries.Series, error) {
+	samples, err := uniform(history, e.sampleInterval)
+	if err != nil {
+		return nil, err
+	}
+	if len(samples) < 2 {
+		return nil, ErrInsufficientHistory
+	}
+
+	mean, items := e.spectrum(samples.Values())
+	if len(items) == 0 || items[0].amplitude < e.lowAmplitudeThreshold {
+		return nil, fmt.Errorf("%w: no spectrum item has an amplitude of at least %g", ErrNotPeriodic, e.lowAmplitudeThreshold)
+	}
+
+	start := samples[0].Timestamp
+	residuals := make([]float64, 0, len(samples))
+	for _, s := range samples {
+		residuals = append(residuals, s.Value-fit(mean, items, float64(s.Timestamp-start)))
+	}
+	sort.Float64s(residuals)
+
+	margin := func(v float64) float64 {
+		return math.Max(v*(1+e.marginFraction), 0)
+	}
+	estimation := make(timeseries.Series, 0, len(timestamps))
+	for _, t := range timestamps {
+		value := fit(mean, items, float64(t-start))
+		sample := helper.Sample{Timestamp: t, Value: margin(value)}
+		for _, q := range helper.DefaultQuantiles {
+			sample.SetQuantile(q, margin(value+quantile(residuals, q)))
+		}
+		sample.SetBounds(margin(value+quantile(residuals, helper.LowerBoundQuantile)),
+			margin(value+quantile(residuals, helper.UpperBoundQuantile)))
+		estimation = append(estimation, sample)
+	}
+	return estimation, nil
+}
+
+// fit returns the value of the mean plus the spectrum items, elapsed seconds after the start of the history.
+func fit(mean float64, items []spectrumItem, elapsed float64) float64 {
+	value := mean
+	for _, item := range items {
+		value += item.amplitude * math.Cos(2*math.Pi*item.frequency*elapsed+item.phase)
+	}
+	return value
+}
+
+// spectrum returns the mean of the values and the kept spectrum items, sorted by decreasing amplitude.
+func (e *FFTEstimator) spectrum(values []float64) (float64, []spectrumItem) {
+	n := len(values)
+	duration := float64(n) * e.sampleInterval.Seconds()
+	// only the items below the frequency threshold are computed, frequency k/duration <= threshold.
+	maxK := n / 2
+	if k := int(math.Floor(e.highFrequencyThreshold * duration)); k < maxK {
+		maxK = k
+	}
+
+	coefficients := dft(values, maxK)
+	mean := real(coefficients[0]) / float64(n)
+
+	var items []spectrumItem
+	for k := 1; k <= maxK; k++ {
+		c := coefficients[k]
+		amplitude := 2 * math.Hypot(real(c), imag(c)) / float64(n)
+		if n%2 == 0 && k == n/2 {
+			// the Nyquist item has no conjugate.
+			amplitude /= 2
+		}
+		items = append(items, spectrumItem{
+			frequency: float64(k) / duration,
+			amplitude: amplitude,
+			phase:     math.Atan2(imag(c), real(c)),
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].amplitude > items[j].amplitude
+	})
+	kept := 0
+	for kept < len(items) && kept < e.maxNumOfSpectrumItems {
+		if kept >= e.minNumOfSpectrumItems && items[kept].amplitude < e.lowAmplitudeThreshold {
+			break
+		}
+		kept++
+	}
+	return mean, items[:kept]
+}
+
+// dft returns the coefficients 0 to maxK of the discrete Fourier transform of the values,
+// X[k] = sum x[j] * exp(-2*pi*i*j*k/n). Computing the low frequency band directly is cheaper than a
+// full FFT for the usual thresholds and works for any number of values.
+func dft(values []float64, maxK int) []complex128 {
+	n := len(values)
+	cos := make([]float64, n)
+	sin := make([]float64, n)
+	for j := range cos {
+		sin[j], cos[j] = math.Sincos(2 * math.Pi * float64(j) / float64(n))
+	}
+
+	coefficients := make([]complex128, maxK+1)
+	for k := 0; k <= maxK; k++ {
+		var re, im float64
+		index := 0
+		for _, v := range values {
+			re += v * cos[index]
+			im -= v * sin[index]
+			if index += k; index >= n {
+				index -= n
+			}
+		}
+		coefficients[k] = complex(re, im)
+	}
+	return coefficients
+}
@@ -0,0 +1,130 @@
+package estimator
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+const historyStart = int64(1634860800)
+
+// signal returns a day of samples every minute of the function of the seconds elapsed since historyStart.
+func signal(f func(elapsed float64) float64) timeseries.Series {
+	var s timeseries.Series
+	for t := historyStart; t < historyStart+int64(24*time.Hour/time.Second); t += 60 {
+		s = append(s, helper.Sample{Timestamp: t, Value: f(float64(t - historyStart))})
+	}
+	return s
+}
+
+// sine is a wave of the given period in seconds around 500.
+func sine(period, amplitude float64) func(float64) float64 {
+	return func(elapsed float64) float64 {
+		return 500 + amplitude*math.Sin(2*math.Pi*elapsed/period)
+	}
+}
+
+func newFFTEstimator(t *testing.T, marginFraction string) *FFTEstimator {
+	t.Helper()
+	config := &v1alpha1.FFTEstimatorConfig{MarginFraction: marginFraction}
+	v1alpha1.SetDefaults_FFTEstimatorConfig(config)
+	e, err := NewFFTEstimator(config, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create the estimator: %v", err)
+	}
+	return e
+}
+
+// future returns the timestamps of the next hours after the day of history.
+func future(hours int) []int64 {
+	var ts []int64
+	for t := historyStart + 24*3600; t < historyStart+int64(24+hours)*3600; t += 60 {
+		ts = append(ts, t)
+	}
+	return ts
+}
+
+func TestFFTEstimatorExtendsPeriodicSignal(t *testing.T) {
+	wave := sine(4*3600, 100)
+	estimation, err := newFFTEstimator(t, "0").Estimate(signal(wave), future(6))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, s := range estimation {
+		expected := wave(float64(s.Timestamp - historyStart))
+		if math.Abs(s.Value-expected) > 1e-6 {
+			t.Fatalf("expected %g at %d, got %g", expected, s.Timestamp, s.Value)
+		}
+		lower, upper, ok := s.Bounds()
+		if !ok || math.Abs(lower-expected) > 1e-6 || math.Abs(upper-expected) > 1e-6 {
+			t.Fatalf("expected bounds equal to %g without residuals, got [%g, %g]", expected, lower, upper)
+		}
+	}
+}
+
+func TestFFTEstimatorAppliesMarginToDistribution(t *testing.T) {
+	wave := sine(4*3600, 100)
+	// a two minute oscillation is above the high frequency threshold, it is left in the residuals.
+	noisy := func(elapsed float64) float64 {
+		if int64(elapsed/60)%2 == 0 {
+			return wave(elapsed) + 20
+		}
+		return wave(elapsed) - 20
+	}
+	estimation, err := newFFTEstimator(t, "0.1").Estimate(signal(noisy), future(6))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, s := range estimation {
+		expected := 1.1 * wave(float64(s.Timestamp-historyStart))
+		if math.Abs(s.Value-expected) > 1e-6 {
+			t.Fatalf("expected %g at %d, got %g", expected, s.Timestamp, s.Value)
+		}
+		lower, upper, ok := s.Bounds()
+		if !ok || math.Abs(lower-(expected-1.1*20)) > 1e-6 || math.Abs(upper-(expected+1.1*20)) > 1e-6 {
+			t.Fatalf("expected bounds %g +/- 22, got [%g, %g]", expected, lower, upper)
+		}
+		p99, _ := s.Quantile(0.99)
+		if s.Value < lower || s.Value > upper || upper > p99 {
+			t.Fatalf("expected %g within [%g, %g] and below p99 %g", s.Value, lower, upper, p99)
+		}
+	}
+}
+
+func TestFFTEstimatorErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		history timeseries.Series
+		err     error
+	}{
+		{
+			name:    "single sample",
+			history: timeseries.Series{{Timestamp: historyStart, Value: 500}},
+			err:     ErrInsufficientHistory,
+		},
+		{
+			name:    "constant",
+			history: signal(func(float64) float64 { return 500 }),
+			err:     ErrNotPeriodic,
+		},
+		{
+			// a ten minute period is above the default threshold of once per hour.
+			name:    "high frequency",
+			history: signal(sine(600, 100)),
+			err:     ErrNotPeriodic,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := newFFTEstimator(t, "0").Estimate(c.history, future(1))
+			if !errors.Is(err, c.err) {
+				t.Errorf("expected error %v, got %v", c.err, err)
+			}
+		})
+	}
+}
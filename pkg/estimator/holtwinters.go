@@ -0,0 +1,124 @@
+package estimator
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// HoltWintersEstimator forecasts a seasonal metric with a trend by additive triple exponential smoothing.
+//
+// The history is resampled every sample interval, a season spans m = SeasonLength/interval samples. The trend
+// is initialized with the mean growth per sample between the first two seasons, the level with the mean of
+// the first season extended by the trend to its last sample and the seasonal components with the deviations
+// of the first season from its detrended mean. Every following sample x then updates them:
+//
+//	level    = alpha*(x-seasonal) + (1-alpha)*(level+trend)
+//	trend    = beta*(level-previous level) + (1-beta)*trend
+//	seasonal = gamma*(x-level) + (1-gamma)*seasonal
+//
+// and the estimation k samples after the history is level + k*trend + seasonal, floored at zero. The history
+// must cover at least two seasons.
+type HoltWintersEstimator struct {
+	sampleInterval time.Duration
+	seasonLength   int
+	alpha          float64
+	beta           float64
+	gamma          float64
+}
+
+var _ Estimator = &HoltWintersEstimator{}
+
+// NewHoltWintersEstimator returns the Holt-Winters estimator of the config for a history sampled every
+// sampleInterval. The config is expected to be defaulted.
+func NewHoltWintersEstimator(config *v1alpha1.HoltWintersEstimatorConfig, sampleInterval time.Duration) (*HoltWintersEstimator, error) {
+	if sampleInterval < time.Second {
+		return nil, fmt.Errorf("sample interval %s must be at least one second", sampleInterval)
+	}
+	seasonLength, err := parseDuration("seasonLength", config.SeasonLength)
+	if err != nil {
+		return nil, err
+	}
+	if seasonLength%sampleInterval != 0 {
+		return nil, fmt.Errorf("season length %s must be a multiple of the sample interval %s", seasonLength, sampleInterval)
+	}
+
+	e := &HoltWintersEstimator{sampleInterval: sampleInterval, seasonLength: int(seasonLength / sampleInterval)}
+	factors := []struct {
+		name  string
+		value string
+		out   *float64
+	}{
+		{"alpha", config.Alpha, &e.alpha},
+		{"beta", config.Beta, &e.beta},
+		{"gamma", config.Gamma, &e.gamma},
+	}
+	for _, f := range factors {
+		v, err := parseFloat(f.name, f.value)
+		if err != nil {
+			return nil, err
+		}
+		if v < 0 || v > 1 {
+			return nil, fmt.Errorf("invalid %s %q: must be in [0, 1]", f.name, f.value)
+		}
+		*f.out = v
+	}
+	return e, nil
+}
+
+// Estimate implements Estimator.
+func (e *HoltWintersEstimator) Estimate(history timeseries.Series, timestamps []int64) (timeseries.Series, error) {
+	samples, err := uniform(history, e.sampleInterval)
+	if err != nil {
+		return nil, err
+	}
+	m := e.seasonLength
+	if len(samples) < 2*m {
+		return nil, fmt.Errorf("%w: %d samples do not cover two seasons of %d samples", ErrInsufficientHistory, len(samples), m)
+	}
+
+	values := samples.Values()
+	// the mean of the first season is its level at its middle sample, the seasonal components are the
+	// deviations from that level extended by the trend, so that they do not absorb the growth of the season.
+	first, second := mean(values[:m]), mean(values[m:2*m])
+	trend := (second - first) / float64(m)
+	middle := float64(m-1) / 2
+	level := first + trend*middle
+	seasonal := make([]float64, m)
+	for i := 0; i < m; i++ {
+		seasonal[i] = values[i] - (first + trend*(float64(i)-middle))
+	}
+	for i := m; i < len(values); i++ {
+		s := seasonal[i%m]
+		previous := level
+		level = e.alpha*(values[i]-s) + (1-e.alpha)*(level+trend)
+		trend = e.beta*(level-previous) + (1-e.beta)*trend
+		seasonal[i%m] = e.gamma*(values[i]-level) + (1-e.gamma)*s
+	}
+
+	last := len(values) - 1
+	step := int64(e.sampleInterval / time.Second)
+	end := samples[last].Timestamp
+	estimation := make(timeseries.Series, 0, len(timestamps))
+	for _, t := range timestamps {
+		k := int(math.Round(float64(t-end) / float64(step)))
+		if k < 1 {
+			k = 1
+		}
+		value := level + float64(k)*trend + seasonal[(last+k)%m]
+		estimation = append(estimation, helper.Sample{Timestamp: t, Value: math.Max(value, 0)})
+	}
+	return estimation, nil
+}
+
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,103 @@
+package estimator
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+)
+
+// seasonality is a season of 7 samples one minute apart whose mean is zero.
+var seasonality = []float64{30, -10, 5, -25, 0, 15, -15}
+
+// ramp grows by 2 every minute from 100, like the memory of a leaking process.
+func ramp(elapsed float64) float64 {
+	return 100 + 2*elapsed/60
+}
+
+// seasonalRamp is the ramp with the seasonality, like the organic growth of a daily traffic.
+func seasonalRamp(elapsed float64) float64 {
+	return ramp(elapsed) + seasonality[int(elapsed/60)%len(seasonality)]
+}
+
+func newHoltWintersEstimator(t *testing.T, seasonLength string) *HoltWintersEstimator {
+	t.Helper()
+	config := &v1alpha1.HoltWintersEstimatorConfig{SeasonLength: seasonLength}
+	v1alpha1.SetDefaults_HoltWintersEstimatorConfig(config)
+	e, err := NewHoltWintersEstimator(config, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create the estimator: %v", err)
+	}
+	return e
+}
+
+func TestHoltWintersEstimatorExtrapolatesTrend(t *testing.T) {
+	estimation, err := newHoltWintersEstimator(t, "7m").Estimate(signal(ramp), future(6))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(estimation) != 6*60 {
+		t.Fatalf("expected %d samples, got %d", 6*60, len(estimation))
+	}
+	for _, s := range estimation {
+		if expected := ramp(float64(s.Timestamp - historyStart)); math.Abs(s.Value-expected) > 1e-6 {
+			t.Fatalf("at %d: expected %g, got %g", s.Timestamp, expected, s.Value)
+		}
+	}
+}
+
+func TestHoltWintersEstimatorExtendsSeasonalTrend(t *testing.T) {
+	history := signal(seasonalRamp)
+	// the day of history does not end with a whole season, the estimation k samples after the last one uses the
+	// seasonal component (last+k)%m.
+	if last := len(history) - 1; last%len(seasonality) == len(seasonality)-1 {
+		t.Fatalf("the history must not end with a whole season")
+	}
+	estimation, err := newHoltWintersEstimator(t, "7m").Estimate(history, future(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, s := range estimation {
+		if expected := seasonalRamp(float64(s.Timestamp - historyStart)); math.Abs(s.Value-expected) > 1e-6 {
+			t.Fatalf("at %d: expected %g, got %g", s.Timestamp, expected, s.Value)
+		}
+	}
+}
+
+func TestHoltWintersEstimatorFloorsAtZero(t *testing.T) {
+	shrinking := func(elapsed float64) float64 { return 3000 - 2*elapsed/60 }
+	estimation, err := newHoltWintersEstimator(t, "7m").Estimate(signal(shrinking), future(24))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if last := estimation[len(estimation)-1]; last.Value != 0 {
+		t.Errorf("expected the estimation to be floored at zero, got %g", last.Value)
+	}
+}
+
+func TestHoltWintersEstimatorRequiresTwoSeasons(t *testing.T) {
+	history := signal(ramp)
+	e := newHoltWintersEstimator(t, "12h")
+	if _, err := e.Estimate(history[:2*12*60-1], future(1)); !errors.Is(err, ErrInsufficientHistory) {
+		t.Errorf("expected ErrInsufficientHistory for less than two seasons, got %v", err)
+	}
+	if _, err := e.Estimate(history, future(1)); err != nil {
+		t.Errorf("unexpected error for two seasons: %v", err)
+	}
+}
+
+func TestNewHoltWintersEstimatorRejectsInvalidConfigs(t *testing.T) {
+	cases := map[string]v1alpha1.HoltWintersEstimatorConfig{
+		"season not a multiple of the interval": {SeasonLength: "90s"},
+		"alpha above one":                       {SeasonLength: "1h", Alpha: "1.5"},
+		"invalid gamma":                         {SeasonLength: "1h", Gamma: "x"},
+	}
+	for name, config := range cases {
+		v1alpha1.SetDefaults_HoltWintersEstimatorConfig(&config)
+		if _, err := NewHoltWintersEstimator(&config, time.Minute); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
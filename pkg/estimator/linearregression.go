@@ -0,0 +1,71 @@
+package estimator
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// LinearRegressionEstimator forecasts a steadily growing or shrinking metric by extending the least squares
+// line of the last Window of its history, or of the whole history without a window. The estimation is
+// floored at zero.
+type LinearRegressionEstimator struct {
+	window time.Duration
+}
+
+var _ Estimator = &LinearRegressionEstimator{}
+
+// NewLinearRegressionEstimator returns the linear regression estimator of the config.
+func NewLinearRegressionEstimator(config *v1alpha1.LinearRegressionEstimatorConfig) (*LinearRegressionEstimator, error) {
+	e := &LinearRegressionEstimator{}
+	if config.Window != "" {
+		window, err := parseDuration("window", config.Window)
+		if err != nil {
+			return nil, err
+		}
+		e.window = window
+	}
+	return e, nil
+}
+
+// Estimate implements Estimator.
+func (e *LinearRegressionEstimator) Estimate(history timeseries.Series, timestamps []int64) (timeseries.Series, error) {
+	end, err := history.End()
+	if err != nil {
+		return nil, ErrInsufficientHistory
+	}
+	if e.window > 0 {
+		history = history.Window(end-int64(e.window/time.Second), end+1)
+	}
+
+	// fit value = intercept + slope*(t-end), centering the timestamps keeps the sums small.
+	var meanT, meanV float64
+	for _, s := range history {
+		meanT += float64(s.Timestamp - end)
+		meanV += s.Value
+	}
+	n := float64(len(history))
+	meanT, meanV = meanT/n, meanV/n
+	var covariance, variance float64
+	for _, s := range history {
+		dt := float64(s.Timestamp-end) - meanT
+		covariance += dt * (s.Value - meanV)
+		variance += dt * dt
+	}
+	if variance == 0 {
+		return nil, fmt.Errorf("%w: the history has a single timestamp", ErrInsufficientHistory)
+	}
+	slope := covariance / variance
+	intercept := meanV - slope*meanT
+
+	estimation := make(timeseries.Series, 0, len(timestamps))
+	for _, t := range timestamps {
+		value := intercept + slope*float64(t-end)
+		estimation = append(estimation, helper.Sample{Timestamp: t, Value: math.Max(value, 0)})
+	}
+	return estimation, nil
+}
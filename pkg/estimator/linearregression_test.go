@@ -0,0 +1,77 @@
+package estimator
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+)
+
+func TestLinearRegressionEstimatorExtrapolatesTrend(t *testing.T) {
+	e, err := NewLinearRegressionEstimator(&v1alpha1.LinearRegressionEstimatorConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	estimation, err := e.Estimate(signal(ramp), future(6))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, s := range estimation {
+		if expected := ramp(float64(s.Timestamp - historyStart)); math.Abs(s.Value-expected) > 1e-6 {
+			t.Fatalf("at %d: expected %g, got %g", s.Timestamp, expected, s.Value)
+		}
+	}
+}
+
+func TestLinearRegressionEstimatorFitsWindow(t *testing.T) {
+	// flat for 17 hours, then growing: the window of the last 6 hours only follows the growth.
+	leak := func(elapsed float64) float64 {
+		return ramp(math.Max(elapsed-17*3600, 0))
+	}
+	windowed, err := NewLinearRegressionEstimator(&v1alpha1.LinearRegressionEstimatorConfig{Window: "6h"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	estimation, err := windowed.Estimate(signal(leak), future(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, s := range estimation {
+		if expected := leak(float64(s.Timestamp - historyStart)); math.Abs(s.Value-expected) > 1e-6 {
+			t.Fatalf("at %d: expected %g, got %g", s.Timestamp, expected, s.Value)
+		}
+	}
+
+	whole, _ := NewLinearRegressionEstimator(&v1alpha1.LinearRegressionEstimatorConfig{})
+	estimation, err = whole.Estimate(signal(leak), future(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := leak(24 * 3600); estimation[0].Value >= expected {
+		t.Errorf("expected the fit of the whole history to lag behind the growth %g, got %g", expected, estimation[0].Value)
+	}
+}
+
+func TestLinearRegressionEstimatorFloorsAtZero(t *testing.T) {
+	e, _ := NewLinearRegressionEstimator(&v1alpha1.LinearRegressionEstimatorConfig{})
+	shrinking := func(elapsed float64) float64 { return 3000 - 2*elapsed/60 }
+	estimation, err := e.Estimate(signal(shrinking), future(24))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if last := estimation[len(estimation)-1]; last.Value != 0 {
+		t.Errorf("expected the estimation to be floored at zero, got %g", last.Value)
+	}
+}
+
+func TestLinearRegressionEstimatorRequiresTwoTimestamps(t *testing.T) {
+	e, _ := NewLinearRegressionEstimator(&v1alpha1.LinearRegressionEstimatorConfig{})
+	history := signal(ramp)
+	for name, h := range map[string]timeseries.Series{"empty": nil, "single timestamp": history[:1]} {
+		if _, err := e.Estimate(h, future(1)); !errors.Is(err, ErrInsufficientHistory) {
+			t.Errorf("%s: expected ErrInsufficientHistory, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package estimator
+
+import (
+	"math"
+	"time"
+
+	"github.com/gocrane-io/api/pkg/histogram"
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// DefaultPercentile is the percentile estimated by the percentile algorithm.
+const DefaultPercentile = 0.99
+
+// PercentileEstimator estimates a percentile of the history, weighted by a decaying histogram so that
+// recent samples count more than old ones. The estimation is the same at every timestamp, its quantiles are the
+// ones of the histogram, and its bounds the LowerBoundQuantile and UpperBoundQuantile of the histogram widened to
+// hold the value, since a percentile above the upper bound quantile, e.g. the default p99, falls out of them.
+type PercentileEstimator struct {
+	options        *histogram.Options
+	sampleInterval time.Duration
+	percentile     float64
+}
+
+var _ Estimator = &PercentileEstimator{}
+
+// NewPercentileEstimator returns the estimator of the given percentile, in [0, 1], for a defaulted config.
+func NewPercentileEstimator(config *v1alpha1.PercentileConfig, percentile float64) (*PercentileEstimator, error) {
+	options, err := histogram.NewOptions(config)
+	if err != nil {
+		return nil, err
+	}
+	sampleInterval, err := parseDuration("sampleInterval", config.SampleInterval)
+	if err != nil {
+		return nil, err
+	}
+	return &PercentileEstimator{options: options, sampleInterval: sampleInterval, percentile: percentile}, nil
+}
+
+// Histogram returns the histogram of the history resampled every sample interval, every sample weighs one
+// before decay.
+func (e *PercentileEstimator) Histogram(history timeseries.Series) (*histogram.Histogram, error) {
+	h := histogram.New(e.options)
+	if len(history) == 0 {
+		return h, nil
+	}
+	samples, err := history.Resample(int64(e.sampleInterval/time.Second), timeseries.AggregateMax)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range samples {
+		h.AddSample(s.Value, 1, time.Unix(s.Timestamp, 0))
+	}
+	return h, nil
+}
+
+// Estimate implements Estimator.
+func (e *PercentileEstimator) Estimate(history timeseries.Series, timestamps []int64) (timeseries.Series, error) {
+	h, err := e.Histogram(history)
+	if err != nil {
+		return nil, err
+	}
+	return e.EstimateFromHistogram(h, timestamps)
+}
+
+// EstimateFromHistogram estimates the percentile of a histogram, e.g. one restored from a checkpoint.
+func (e *PercentileEstimator) EstimateFromHistogram(h *histogram.Histogram, timestamps []int64) (timeseries.Series, error) {
+	if h.IsEmpty() {
+		return nil, ErrInsufficientHistory
+	}
+	value := h.Percentile(e.percentile)
+	quantiles := make([]float64, len(helper.DefaultQuantiles))
+	for i, q := range helper.DefaultQuantiles {
+		quantiles[i] = h.Percentile(q)
+	}
+	lower := math.Min(h.Percentile(helper.LowerBoundQuantile), value)
+	upper := math.Max(h.Percentile(helper.UpperBoundQuantile), value)
+
+	estimation := make(timeseries.Series, 0, len(timestamps))
+	for _, t := range timestamps {
+		sample := helper.Sample{Timestamp: t, Value: value}
+		for i, q := range helper.DefaultQuantiles {
+			sample.SetQuantile(q, quantiles[i])
+		}
+		sample.SetBounds(lower, upper)
+		estimation = append(estimation, sample)
+	}
+	return estimation, nil
+}
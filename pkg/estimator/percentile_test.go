@@ -0,0 +1,116 @@
+package estimator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+func newPercentileEstimator(t *testing.T, percentile float64) *PercentileEstimator {
+	t.Helper()
+	config := &v1alpha1.PercentileConfig{}
+	v1alpha1.SetDefaults_PercentileConfig(config)
+	v1alpha1.SetDefaults_HistogramConfig(&config.Histogram)
+	e, err := NewPercentileEstimator(config, percentile)
+	if err != nil {
+		t.Fatalf("failed to create the estimator: %v", err)
+	}
+	return e
+}
+
+// spikes is 500 but for a spike to 1000 and a drop to 10 every half hour, so that the p01 is below the lower bound
+// quantile and the p99 above the upper one.
+func spikes(elapsed float64) float64 {
+	switch int(elapsed/60) % 30 {
+	case 0:
+		return 1000
+	case 15:
+		return 10
+	}
+	return 500
+}
+
+func TestPercentileEstimator(t *testing.T) {
+	cases := []struct {
+		name       string
+		percentile float64
+		// expected are the lower bound, the value and the upper bound.
+		expected [3]float64
+	}{
+		{
+			name:       "the bounds are widened to hold a value above the upper bound quantile",
+			percentile: DefaultPercentile,
+			expected:   [3]float64{helper.LowerBoundQuantile, DefaultPercentile, DefaultPercentile},
+		},
+		{
+			name:       "the bounds hold a value between the bound quantiles",
+			percentile: 0.5,
+			expected:   [3]float64{helper.LowerBoundQuantile, 0.5, helper.UpperBoundQuantile},
+		},
+		{
+			name:       "the bounds are widened to hold a value below the lower bound quantile",
+			percentile: 0.01,
+			expected:   [3]float64{0.01, 0.01, helper.UpperBoundQuantile},
+		},
+	}
+	history := signal(spikes)
+	for _, c := range cases {
+		e := newPercentileEstimator(t, c.percentile)
+		h, err := e.Histogram(history)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.name, err)
+		}
+		estimation, err := e.Estimate(history, future(1))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", c.name, err)
+		}
+		if len(estimation) != len(future(1)) {
+			t.Fatalf("%s: expected an estimation at every timestamp, got %d", c.name, len(estimation))
+		}
+		for _, s := range estimation {
+			if expected := h.Percentile(c.expected[1]); s.Value != expected {
+				t.Fatalf("%s: expected the value %g, got %g", c.name, expected, s.Value)
+			}
+			for _, q := range helper.DefaultQuantiles {
+				if got, ok := s.Quantile(q); !ok || got != h.Percentile(q) {
+					t.Errorf("%s: expected the quantile %g to be %g, got %g", c.name, q, h.Percentile(q), got)
+				}
+			}
+			lower, upper, ok := s.Bounds()
+			if expected := [2]float64{h.Percentile(c.expected[0]), h.Percentile(c.expected[2])}; !ok || lower != expected[0] || upper != expected[1] {
+				t.Errorf("%s: expected the bounds %v, got [%g %g]", c.name, expected, lower, upper)
+			}
+			if lower > s.Value || s.Value > upper {
+				t.Errorf("%s: expected the bounds [%g %g] to hold the value %g", c.name, lower, upper, s.Value)
+			}
+		}
+	}
+}
+
+func TestPercentileEstimatorQuantilesGrow(t *testing.T) {
+	// a day of samples growing by one every minute.
+	estimation, err := newPercentileEstimator(t, DefaultPercentile).Estimate(signal(func(elapsed float64) float64 {
+		return elapsed / 60
+	}), future(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	previous := 0.0
+	for _, q := range helper.DefaultQuantiles {
+		value, _ := estimation[0].Quantile(q)
+		if value <= previous || value > 1500 {
+			t.Errorf("expected the quantile %g to grow in the range of the history, got %g after %g", q, value, previous)
+		}
+		previous = value
+	}
+}
+
+func TestPercentileEstimatorEmptyHistory(t *testing.T) {
+	_, err := newPercentileEstimator(t, DefaultPercentile).Estimate(timeseries.Series{}, future(1))
+	if !errors.Is(err, ErrInsufficientHistory) {
+		t.Errorf("expected ErrInsufficientHistory, got %v", err)
+	}
+}
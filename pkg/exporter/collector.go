@@ -0,0 +1,229 @@
+// Package exporter exposes the predictions as Prometheus metrics, so that the forecasts are graphed next to the
+// observed values in the existing dashboards.
+package exporter
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+
+	predictionlisters "github.com/gocrane-io/api/pkg/generated/listers/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+const (
+	// ForecastMetric is the metric of the forecasts of the NodePredictions and of the aggregations of the
+	// PodGroupPredictions, ContainerForecastMetric the metric of the forecasts of their containers.
+	ForecastMetric          = "crane_prediction_forecast"
+	ContainerForecastMetric = "crane_prediction_container_forecast"
+
+	// KindNodePrediction and KindPodGroupPrediction are the values of the kind label.
+	KindNodePrediction     = "NodePrediction"
+	KindPodGroupPrediction = "PodGroupPrediction"
+)
+
+var (
+	// statuses are the statuses exported by the status metric, one series each.
+	statuses = []v1alpha1.PredictionStatus{
+		v1alpha1.PredictionStatusNotStarted,
+		v1alpha1.PredictionStatusCharging,
+		v1alpha1.PredictionStatusPredicting,
+		v1alpha1.PredictionStatusStale,
+		v1alpha1.PredictionStatusFinished,
+		v1alpha1.PredictionStatusFailed,
+	}
+	// conditionStatuses are the statuses exported by the condition metric, one series each.
+	conditionStatuses = []corev1.ConditionStatus{corev1.ConditionTrue, corev1.ConditionFalse, corev1.ConditionUnknown}
+
+	forecastDesc = prometheus.NewDesc(ForecastMetric,
+		"Forecast of a metric, the consumption of a NodePrediction or the aggregation of a PodGroupPrediction, at a horizon after the last update of the prediction.",
+		[]string{"kind", "namespace", "prediction", "metric", "horizon"}, nil)
+	containerForecastDesc = prometheus.NewDesc(ContainerForecastMetric,
+		"Forecast of a metric of a container of a PodGroupPrediction, at a horizon after the last update of the prediction.",
+		[]string{"namespace", "prediction", "metric", "container", "horizon"}, nil)
+	statusDesc = prometheus.NewDesc("crane_prediction_status",
+		"Status of a prediction, 1 for its current status and 0 for the others.",
+		[]string{"kind", "namespace", "prediction", "status"}, nil)
+	conditionDesc = prometheus.NewDesc("crane_prediction_condition",
+		"Condition of a prediction, 1 for the current status of the condition and 0 for the others.",
+		[]string{"kind", "namespace", "prediction", "condition", "status"}, nil)
+	lastUpdateDesc = prometheus.NewDesc("crane_prediction_last_update_timestamp_seconds",
+		"Unix timestamp of the last update of the forecasts of a prediction.",
+		[]string{"kind", "namespace", "prediction"}, nil)
+)
+
+// Collector collects the metrics of the predictions from the listers on every scrape. The forecasts are in the
+// base unit of their metric, cores for cpu and bytes for memory, and every point of a series is a sample whose
+// horizon label is its distance to the last update of the prediction, rounded up to the interval of the series:
+// the horizons stay the same from an update to the next. The points beyond the max horizon are left out.
+type Collector struct {
+	podGroupPredictionLister predictionlisters.PodGroupPredictionLister
+	nodePredictionLister     predictionlisters.NodePredictionLister
+	maxHorizon               time.Duration
+}
+
+var _ prometheus.Collector = &Collector{}
+
+// NewCollector returns a collector of the predictions of the listers, every point is exported for a max horizon
+// that is not positive.
+func NewCollector(podGroupPredictionLister predictionlisters.PodGroupPredictionLister, nodePredictionLister predictionlisters.NodePredictionLister, maxHorizon time.Duration) *Collector {
+	return &Collector{
+		podGroupPredictionLister: podGroupPredictionLister,
+		nodePredictionLister:     nodePredictionLister,
+		maxHorizon:               maxHorizon,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- forecastDesc
+	ch <- containerForecastDesc
+	ch <- statusDesc
+	ch <- conditionDesc
+	ch <- lastUpdateDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	nps, err := c.nodePredictionLister.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+	}
+	for _, np := range nps {
+		status := &np.Status
+		c.collectStatus(ch, KindNodePrediction, "", np.Name, status.Status, status.Conditions, status.LastUpdateTime)
+		if status.LastUpdateTime == nil {
+			continue
+		}
+		for _, metric := range sortedMetrics(status.Consumed) {
+			c.collectSeries(ch, forecastDesc, metric, status.Consumed[metric], status.LastUpdateTime.Time,
+				KindNodePrediction, "", np.Name, metric)
+		}
+	}
+
+	pgps, err := c.podGroupPredictionLister.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+	}
+	for _, pgp := range pgps {
+		status := &pgp.Status
+		c.collectStatus(ch, KindPodGroupPrediction, pgp.Namespace, pgp.Name, status.Status, status.Conditions, status.LastUpdateTime)
+		if status.LastUpdateTime == nil {
+			continue
+		}
+		for _, metric := range sortedMetrics(status.Aggregation) {
+			c.collectSeries(ch, forecastDesc, metric, status.Aggregation[metric], status.LastUpdateTime.Time,
+				KindPodGroupPrediction, pgp.Namespace, pgp.Name, metric)
+		}
+		containers := make([]string, 0, len(status.Containers))
+		for container := range status.Containers {
+			containers = append(containers, container)
+		}
+		sort.Strings(containers)
+		for _, container := range containers {
+			prediction := status.Containers[container]
+			for _, metric := range sortedMetrics(prediction) {
+				c.collectSeries(ch, containerForecastDesc, metric, prediction[metric], status.LastUpdateTime.Time,
+					pgp.Namespace, pgp.Name, metric, container)
+			}
+		}
+	}
+}
+
+// collectStatus collects the status, the conditions and the last update of a prediction, whose last update is
+// only collected when set.
+func (c *Collector) collectStatus(ch chan<- prometheus.Metric, kind, namespace, name string, status v1alpha1.PredictionStatus,
+	conditions []v1alpha1.PredictionCondition, lastUpdate *metav1.Time) {
+	for _, s := range statuses {
+		ch <- prometheus.MustNewConstMetric(statusDesc, prometheus.GaugeValue, boolValue(s == status), kind, namespace, name, string(s))
+	}
+	for _, condition := range conditions {
+		for _, s := range conditionStatuses {
+			ch <- prometheus.MustNewConstMetric(conditionDesc, prometheus.GaugeValue, boolValue(s == condition.Status),
+				kind, namespace, name, string(condition.Type), strings.ToLower(string(s)))
+		}
+	}
+	if lastUpdate != nil {
+		ch <- prometheus.MustNewConstMetric(lastUpdateDesc, prometheus.GaugeValue, float64(lastUpdate.Unix()), kind, namespace, name)
+	}
+}
+
+// collectSeries collects a sample per point of the series of the metric, the horizon label is appended to the
+// given label values.
+func (c *Collector) collectSeries(ch chan<- prometheus.Metric, desc *prometheus.Desc, metric string, ts v1alpha1.TimeSeries,
+	updated time.Time, labelValues ...string) {
+	resourceName := v1alpha1.ResourceName(metric)
+	samples, err := helper.ParseTimeSeries(resourceName, ts)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(desc, err)
+		return
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Timestamp < samples[j].Timestamp
+	})
+
+	for i, horizon := range Horizons(samples, updated) {
+		if horizon == 0 || (c.maxHorizon > 0 && time.Duration(horizon)*time.Second > c.maxHorizon) {
+			continue
+		}
+		value := helper.ToQuantity(resourceName, samples[i].Value)
+		values := append(append(make([]string, 0, len(labelValues)+1), labelValues...), FormatHorizon(horizon))
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value.AsApproximateFloat64(), values...)
+	}
+}
+
+// Horizons returns the horizon of every sample of a series sorted by timestamp, in seconds: its distance to the
+// update of the prediction rounded up to the interval of the series, so that the horizons stay the same from an
+// update to the next. The samples up to the update, and those whose horizon is the one of a previous sample, have
+// a zero horizon.
+func Horizons(samples helper.Samples, updated time.Time) []int64 {
+	interval := int64(time.Minute / time.Second)
+	if len(samples) > 1 && samples[1].Timestamp > samples[0].Timestamp {
+		interval = samples[1].Timestamp - samples[0].Timestamp
+	}
+	horizons := make([]int64, len(samples))
+	seen := make(map[int64]bool, len(samples))
+	for i, s := range samples {
+		offset := s.Timestamp - updated.Unix()
+		if offset <= 0 {
+			continue
+		}
+		horizon := (offset + interval - 1) / interval * interval
+		if seen[horizon] {
+			continue
+		}
+		seen[horizon] = true
+		horizons[i] = horizon
+	}
+	return horizons
+}
+
+// FormatHorizon formats a horizon in seconds as the value of the horizon label, for example 10m.
+func FormatHorizon(horizon int64) string {
+	return model.Duration(time.Duration(horizon) * time.Second).String()
+}
+
+// sortedMetrics returns the sorted metric names of a prediction.
+func sortedMetrics(p v1alpha1.Prediction) []string {
+	metrics := make([]string, 0, len(p))
+	for metric := range p {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+	return metrics
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
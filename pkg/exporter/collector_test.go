@@ -0,0 +1,171 @@
+package exporter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/gocrane-io/api/pkg/generated/clientset/versioned/fake"
+	"github.com/gocrane-io/api/pkg/generated/informers/externalversions"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+	"github.com/gocrane-io/api/prediction/v1alpha1/predictiontest"
+)
+
+var now = predictiontest.Now
+
+// updated is the last update of the predictions, 20 seconds after the first point of their series.
+var updated = metav1.NewTime(now.Add(20 * time.Second))
+
+// newPodGroupPrediction returns the fixture forecasting the cpu of its aggregation and container, out of order.
+func newPodGroupPrediction() *v1alpha1.PodGroupPrediction {
+	cpu := v1alpha1.TimeSeries{
+		{Value: "1000", Timestamp: now.Unix()},
+		{Value: "2500", Timestamp: now.Add(2 * time.Minute).Unix()},
+		{Value: "1500", Timestamp: now.Add(time.Minute).Unix()},
+		{Value: "500", Timestamp: now.Add(time.Hour).Unix()},
+		{Value: "750", Timestamp: now.Add(2 * time.Hour).Unix()},
+	}
+	pgp := predictiontest.NewPodGroupPrediction("default", "web")
+	pgp.Status = v1alpha1.PodGroupPredictionStatus{
+		Conditions: []v1alpha1.PredictionCondition{{
+			Type:   v1alpha1.PredictionConditionPredicting,
+			Status: corev1.ConditionTrue,
+		}},
+		Status:         v1alpha1.PredictionStatusPredicting,
+		LastUpdateTime: &updated,
+		Aggregation:    v1alpha1.Prediction{"cpu": cpu},
+		Containers:     map[string]v1alpha1.Prediction{"default/web-0/web": {"cpu": cpu[:3]}},
+	}
+	return pgp
+}
+
+// newNodePrediction returns the fixture forecasting the memory of node-1 every 10 minutes.
+func newNodePrediction() *v1alpha1.NodePrediction {
+	np := predictiontest.NewNodePrediction("node-1")
+	np.Status = v1alpha1.NodePredictionResourceStatus{
+		Status:         v1alpha1.PredictionStatusPredicting,
+		LastUpdateTime: &updated,
+		Consumed:       v1alpha1.Prediction{"memory": predictiontest.Series(now.Add(10*time.Minute), 10*time.Minute, "1Gi", "2Gi")},
+	}
+	return np
+}
+
+// newCollector returns a collector whose listers are synced with the predictions.
+func newCollector(t *testing.T, maxHorizon time.Duration, predictions ...runtime.Object) *Collector {
+	t.Helper()
+	informers := externalversions.NewSharedInformerFactory(fake.NewSimpleClientset(predictions...), 0)
+	collector := NewCollector(informers.Prediction().V1alpha1().PodGroupPredictions().Lister(), informers.Prediction().V1alpha1().NodePredictions().Lister(), maxHorizon)
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	informers.Start(stopCh)
+	informers.WaitForCacheSync(stopCh)
+	return collector
+}
+
+func TestCollectSeries(t *testing.T) {
+	collector := newCollector(t, time.Hour, newPodGroupPrediction(), newNodePrediction())
+	expected := `
+# HELP crane_prediction_container_forecast Forecast of a metric of a container of a PodGroupPrediction, at a horizon after the last update of the prediction.
+# TYPE crane_prediction_container_forecast gauge
+crane_prediction_container_forecast{container="default/web-0/web",horizon="1m",metric="cpu",namespace="default",prediction="web"} 1.5
+crane_prediction_container_forecast{container="default/web-0/web",horizon="2m",metric="cpu",namespace="default",prediction="web"} 2.5
+# HELP crane_prediction_forecast Forecast of a metric, the consumption of a NodePrediction or the aggregation of a PodGroupPrediction, at a horizon after the last update of the prediction.
+# TYPE crane_prediction_forecast gauge
+crane_prediction_forecast{horizon="10m",kind="NodePrediction",metric="memory",namespace="",prediction="node-1"} 1.073741824e+09
+crane_prediction_forecast{horizon="20m",kind="NodePrediction",metric="memory",namespace="",prediction="node-1"} 2.147483648e+09
+crane_prediction_forecast{horizon="1h",kind="PodGroupPrediction",metric="cpu",namespace="default",prediction="web"} 0.5
+crane_prediction_forecast{horizon="1m",kind="PodGroupPrediction",metric="cpu",namespace="default",prediction="web"} 1.5
+crane_prediction_forecast{horizon="2m",kind="PodGroupPrediction",metric="cpu",namespace="default",prediction="web"} 2.5
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), ForecastMetric, ContainerForecastMetric); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCollectSeriesWithoutMaxHorizon(t *testing.T) {
+	collector := newCollector(t, 0, newPodGroupPrediction())
+	expected := `
+# HELP crane_prediction_forecast Forecast of a metric, the consumption of a NodePrediction or the aggregation of a PodGroupPrediction, at a horizon after the last update of the prediction.
+# TYPE crane_prediction_forecast gauge
+crane_prediction_forecast{horizon="1h",kind="PodGroupPrediction",metric="cpu",namespace="default",prediction="web"} 0.5
+crane_prediction_forecast{horizon="1m",kind="PodGroupPrediction",metric="cpu",namespace="default",prediction="web"} 1.5
+crane_prediction_forecast{horizon="2h",kind="PodGroupPrediction",metric="cpu",namespace="default",prediction="web"} 0.75
+crane_prediction_forecast{horizon="2m",kind="PodGroupPrediction",metric="cpu",namespace="default",prediction="web"} 2.5
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), ForecastMetric); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCollectStatus(t *testing.T) {
+	pgp := newPodGroupPrediction()
+	pgp.Status.LastUpdateTime = nil
+	collector := newCollector(t, time.Hour, pgp)
+	expected := `
+# HELP crane_prediction_condition Condition of a prediction, 1 for the current status of the condition and 0 for the others.
+# TYPE crane_prediction_condition gauge
+crane_prediction_condition{condition="Predicting",kind="PodGroupPrediction",namespace="default",prediction="web",status="false"} 0
+crane_prediction_condition{condition="Predicting",kind="PodGroupPrediction",namespace="default",prediction="web",status="true"} 1
+crane_prediction_condition{condition="Predicting",kind="PodGroupPrediction",namespace="default",prediction="web",status="unknown"} 0
+# HELP crane_prediction_status Status of a prediction, 1 for its current status and 0 for the others.
+# TYPE crane_prediction_status gauge
+crane_prediction_status{kind="PodGroupPrediction",namespace="default",prediction="web",status="Charging"} 0
+crane_prediction_status{kind="PodGroupPrediction",namespace="default",prediction="web",status="Failed"} 0
+crane_prediction_status{kind="PodGroupPrediction",namespace="default",prediction="web",status="Finished"} 0
+crane_prediction_status{kind="PodGroupPrediction",namespace="default",prediction="web",status="NotStarted"} 0
+crane_prediction_status{kind="PodGroupPrediction",namespace="default",prediction="web",status="Predicting"} 1
+crane_prediction_status{kind="PodGroupPrediction",namespace="default",prediction="web",status="Stale"} 0
+`
+	// without a last update, neither the forecasts nor the last update are collected.
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHorizons(t *testing.T) {
+	updated := time.Unix(1000, 0)
+	cases := []struct {
+		name     string
+		samples  helper.Samples
+		expected []int64
+	}{
+		{
+			name:     "rounded up to the interval",
+			samples:  helper.Samples{{Timestamp: 960}, {Timestamp: 1020}, {Timestamp: 1080}, {Timestamp: 1140}},
+			expected: []int64{0, 60, 120, 180},
+		},
+		{
+			name:     "aligned on the update",
+			samples:  helper.Samples{{Timestamp: 1000}, {Timestamp: 1300}, {Timestamp: 1600}},
+			expected: []int64{0, 300, 600},
+		},
+		{
+			name:     "repeated horizon",
+			samples:  helper.Samples{{Timestamp: 1060}, {Timestamp: 1120}, {Timestamp: 1130}, {Timestamp: 1180}},
+			expected: []int64{60, 120, 180, 0},
+		},
+		{
+			name:     "single sample",
+			samples:  helper.Samples{{Timestamp: 1090}},
+			expected: []int64{120},
+		},
+	}
+	for _, c := range cases {
+		if got := Horizons(c.samples, updated); !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, got)
+		}
+	}
+	for horizon, expected := range map[int64]string{60: "1m", 90: "1m30s", 3600: "1h", 86400: "1d"} {
+		if got := FormatHorizon(horizon); got != expected {
+			t.Errorf("FormatHorizon(%d): expected %s, got %s", horizon, expected, got)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package externalmetrics
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/handlers/responsewriters"
+	"k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
+)
+
+// PathPrefix is the path of the external metrics API group.
+const PathPrefix = "/apis/" + v1beta1.GroupName
+
+var (
+	scheme = runtime.NewScheme()
+	// codecs only serve json, which the clients of the group request.
+	codecs = runtime.NewSimpleNegotiatedSerializer(runtime.SerializerInfo{
+		MediaType:        runtime.ContentTypeJSON,
+		MediaTypeType:    "application",
+		MediaTypeSubType: "json",
+		EncodesAsText:    true,
+		Serializer:       json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme, json.SerializerOptions{}),
+		PrettySerializer: json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme, json.SerializerOptions{Pretty: true}),
+	})
+)
+
+func init() {
+	metav1.AddToGroupVersion(scheme, schema.GroupVersion{Version: "v1"})
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+}
+
+// APIGroup returns the discovery of the external metrics API group.
+func APIGroup() metav1.APIGroup {
+	version := metav1.GroupVersionForDiscovery{GroupVersion: v1beta1.SchemeGroupVersion.String(), Version: v1beta1.SchemeGroupVersion.Version}
+	return metav1.APIGroup{
+		TypeMeta:         metav1.TypeMeta{APIVersion: "v1", Kind: "APIGroup"},
+		Name:             v1beta1.GroupName,
+		Versions:         []metav1.GroupVersionForDiscovery{version},
+		PreferredVersion: version,
+	}
+}
+
+// NewHandler returns the handler of the paths under PathPrefix, which serves the discovery of the group and the
+// values of the metrics at /apis/external.metrics.k8s.io/v1beta1/namespaces/<namespace>/<metric>, selected by
+// the labelSelector parameter.
+func NewHandler(provider *Provider) http.Handler {
+	return &handler{provider: provider}
+}
+
+type handler struct {
+	provider *Provider
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	gv := v1beta1.SchemeGroupVersion
+	if req.Method != http.MethodGet {
+		err := statusError(http.StatusMethodNotAllowed, metav1.StatusReasonMethodNotAllowed, fmt.Sprintf("%s is not supported, only GET is", req.Method))
+		responsewriters.ErrorNegotiated(err, codecs, gv, w, req)
+		return
+	}
+
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(req.URL.Path, PathPrefix), "/"), "/")
+	switch {
+	case len(segments) == 1 && segments[0] == "":
+		group := APIGroup()
+		responsewriters.WriteObjectNegotiated(codecs, negotiation.DefaultEndpointRestrictions, gv, w, req, http.StatusOK, &group)
+	case len(segments) == 1 && segments[0] == gv.Version:
+		resources, err := h.resources()
+		if err != nil {
+			responsewriters.ErrorNegotiated(err, codecs, gv, w, req)
+			return
+		}
+		responsewriters.WriteObjectNegotiated(codecs, negotiation.DefaultEndpointRestrictions, gv, w, req, http.StatusOK, resources)
+	case len(segments) == 4 && segments[0] == gv.Version && segments[1] == "namespaces":
+		selector, err := labels.Parse(req.URL.Query().Get("labelSelector"))
+		if err != nil {
+			responsewriters.ErrorNegotiated(apierrors.NewBadRequest(fmt.Sprintf("invalid labelSelector: %v", err)), codecs, gv, w, req)
+			return
+		}
+		values, err := h.provider.GetExternalMetric(segments[2], segments[3], selector)
+		if err != nil {
+			responsewriters.ErrorNegotiated(apierrors.NewInternalError(err), codecs, gv, w, req)
+			return
+		}
+		responsewriters.WriteObjectNegotiated(codecs, negotiation.DefaultEndpointRestrictions, gv, w, req, http.StatusOK, values)
+	default:
+		err := statusError(http.StatusNotFound, metav1.StatusReasonNotFound, fmt.Sprintf("the path %s is not served", req.URL.Path))
+		responsewriters.ErrorNegotiated(err, codecs, gv, w, req)
+	}
+}
+
+// resources returns the discovery of the version, a resource per metric.
+func (h *handler) resources() (*metav1.APIResourceList, error) {
+	names, err := h.provider.ListAllExternalMetrics()
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	list := &metav1.APIResourceList{
+		TypeMeta:     metav1.TypeMeta{APIVersion: "v1", Kind: "APIResourceList"},
+		GroupVersion: v1beta1.SchemeGroupVersion.String(),
+		APIResources: []metav1.APIResource{},
+	}
+	for _, name := range names {
+		list.APIResources = append(list.APIResources, metav1.APIResource{
+			Name:       name,
+			Namespaced: true,
+			Kind:       "ExternalMetricValueList",
+			Verbs:      metav1.Verbs{"get"},
+		})
+	}
+	return list, nil
+}
+
+func statusError(code int32, reason metav1.StatusReason, message string) *apierrors.StatusError {
+	return &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    code,
+		Reason:  reason,
+		Message: message,
+	}}
+}
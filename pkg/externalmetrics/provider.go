@@ -0,0 +1,138 @@
+// Package externalmetrics serves the aggregated predictions of the PodGroupPredictions as external metrics, so
+// that the horizontal pod autoscaler scales a workload on its predicted usage rather than its current one.
+package externalmetrics
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
+
+	predictionlisters "github.com/gocrane-io/api/pkg/generated/listers/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// PredictionLabel is the label holding the name of the PodGroupPrediction of a value. The values carry the
+// labels of their prediction as well, and the selector of a query is matched against them.
+const PredictionLabel = "prediction"
+
+// DefaultLookahead is the default window of the predictions the values are the maximum of.
+const DefaultLookahead = 10 * time.Minute
+
+// Provider provides the external metrics of the PodGroupPredictions. An external metric is named after a metric
+// of the predictions, and its value for a prediction is the maximum of its aggregation over the lookahead: from
+// the predicted value in effect now, the latest at or before now, until now plus the lookahead. Only predictions
+// whose status is Predicting have values, and a predicted value older than the lookahead is stale: a prediction
+// that is no longer refreshed has no value once its last predicted value is older than the lookahead.
+type Provider struct {
+	lister    predictionlisters.PodGroupPredictionLister
+	lookahead time.Duration
+	now       func() time.Time
+}
+
+// NewProvider returns a provider reading the predictions with the lister, DefaultLookahead is used for a lookahead
+// that is not positive.
+func NewProvider(lister predictionlisters.PodGroupPredictionLister, lookahead time.Duration) *Provider {
+	if lookahead <= 0 {
+		lookahead = DefaultLookahead
+	}
+	return &Provider{lister: lister, lookahead: lookahead, now: time.Now}
+}
+
+// GetExternalMetric returns the values of the metric of the predictions of the namespace whose labels match the
+// selector, sorted by prediction. The autoscaler sums the values when several predictions match.
+func (p *Provider) GetExternalMetric(namespace, metricName string, selector labels.Selector) (*v1beta1.ExternalMetricValueList, error) {
+	pgps, err := p.lister.PodGroupPredictions(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pgps, func(i, j int) bool {
+		return pgps[i].Name < pgps[j].Name
+	})
+
+	now := p.now()
+	window := int64(p.lookahead / time.Second)
+	list := &v1beta1.ExternalMetricValueList{
+		TypeMeta: metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: "ExternalMetricValueList"},
+		Items:    []v1beta1.ExternalMetricValue{},
+	}
+	for _, pgp := range pgps {
+		metricLabels := labels.Merge(pgp.Labels, labels.Set{PredictionLabel: pgp.Name})
+		if !selector.Matches(metricLabels) {
+			continue
+		}
+		if helper.StatusFromConditions(pgp.Status.Conditions) != v1alpha1.PredictionStatusPredicting {
+			continue
+		}
+		ts, ok := pgp.Status.Aggregation[metricName]
+		if !ok {
+			continue
+		}
+		resourceName := v1alpha1.ResourceName(metricName)
+		samples, err := helper.ParseTimeSeries(resourceName, ts)
+		if err != nil {
+			klog.Errorf("Failed to parse the %s aggregation of PodGroupPrediction %s/%s: %v", metricName, pgp.Namespace, pgp.Name, err)
+			continue
+		}
+		value, ok := maxOver(samples, now.Unix(), now.Add(p.lookahead).Unix(), window)
+		if !ok {
+			continue
+		}
+		list.Items = append(list.Items, v1beta1.ExternalMetricValue{
+			MetricName:    metricName,
+			MetricLabels:  metricLabels,
+			Timestamp:     metav1.NewTime(now),
+			WindowSeconds: &window,
+			Value:         helper.ToQuantity(resourceName, value),
+		})
+	}
+	return list, nil
+}
+
+// ListAllExternalMetrics returns the sorted names of the metrics of every PodGroupPrediction.
+func (p *Provider) ListAllExternalMetrics() ([]string, error) {
+	pgps, err := p.lister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PodGroupPredictions: %v", err)
+	}
+	names := sets.NewString()
+	for _, pgp := range pgps {
+		for _, config := range pgp.Spec.MetricPredictionConfigs {
+			names.Insert(config.MetricName)
+		}
+	}
+	return names.List(), nil
+}
+
+// maxOver returns the largest value of the samples in [start, end] and of the latest sample before start, which
+// is the value in effect at start unless it is more than maxAge seconds older than start.
+func maxOver(samples helper.Samples, start, end, maxAge int64) (float64, bool) {
+	var value float64
+	var latest *helper.Sample
+	found := false
+	for i := range samples {
+		s := &samples[i]
+		switch {
+		case s.Timestamp < start:
+			if s.Timestamp >= start-maxAge && (latest == nil || s.Timestamp > latest.Timestamp) {
+				latest = s
+			}
+		case s.Timestamp <= end:
+			if !found || s.Value > value {
+				value = s.Value
+			}
+			found = true
+		}
+	}
+	if latest != nil && (!found || latest.Value > value) {
+		value = latest.Value
+		found = true
+	}
+	return value, found
+}
@@ -0,0 +1,140 @@
+package externalmetrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/metrics/pkg/apis/external_metrics/v1beta1"
+
+	"github.com/gocrane-io/api/pkg/generated/clientset/versioned/fake"
+	"github.com/gocrane-io/api/pkg/generated/informers/externalversions"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+	"github.com/gocrane-io/api/prediction/v1alpha1/predictiontest"
+)
+
+var now = predictiontest.Now
+
+// newPodGroupPrediction returns a predicting PodGroupPrediction whose cpu aggregation has the values, in milli
+// cores, every minute from the start.
+func newPodGroupPrediction(name string, start time.Time, values ...float64) *v1alpha1.PodGroupPrediction {
+	pgp := predictiontest.NewPodGroupPrediction("default", name)
+	pgp.Labels = map[string]string{"app": "web"}
+	pgp.Status.Conditions = []v1alpha1.PredictionCondition{{
+		Type:   v1alpha1.PredictionConditionPredicting,
+		Status: corev1.ConditionTrue,
+	}}
+	var samples helper.Samples
+	for i, v := range values {
+		samples = append(samples, helper.Sample{Timestamp: start.Add(time.Duration(i) * time.Minute).Unix(), Value: v})
+	}
+	pgp.Status.Aggregation = helper.FormatPrediction(map[string]helper.Samples{"cpu": samples})
+	return pgp
+}
+
+// newProvider returns a provider at now whose lister is synced with the predictions.
+func newProvider(t *testing.T, predictions ...runtime.Object) *Provider {
+	t.Helper()
+	informers := externalversions.NewSharedInformerFactory(fake.NewSimpleClientset(predictions...), 0)
+	provider := NewProvider(informers.Prediction().V1alpha1().PodGroupPredictions().Lister(), 0)
+	provider.now = func() time.Time { return now }
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	informers.Start(stopCh)
+	informers.WaitForCacheSync(stopCh)
+	return provider
+}
+
+func TestGetExternalMetric(t *testing.T) {
+	charging := newPodGroupPrediction("charging", now, 100)
+	charging.Status.Conditions[0].Type = v1alpha1.PredictionConditionCharging
+	other := newPodGroupPrediction("other", now, 100)
+	other.Labels = nil
+	provider := newProvider(t,
+		// the value in effect now, 2500, is larger than the next ten minutes.
+		newPodGroupPrediction("web", now.Add(-time.Minute), 2500, 1000, 2000),
+		// the last predicted value is older than the lookahead.
+		newPodGroupPrediction("stale", now.Add(-DefaultLookahead-time.Minute), 3000),
+		// only the values within the lookahead count.
+		newPodGroupPrediction("later", now, 500, 700, 600, 600, 600, 600, 600, 600, 600, 600, 600, 9000),
+		charging,
+		other,
+	)
+
+	list, err := provider.GetExternalMetric("default", "cpu", labels.SelectorFromSet(labels.Set{"app": "web"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]int64{"later": 700, "web": 2500}
+	if len(list.Items) != len(expected) {
+		t.Fatalf("expected the values of %v, got %+v", expected, list.Items)
+	}
+	for _, item := range list.Items {
+		name := item.MetricLabels[PredictionLabel]
+		if value, ok := expected[name]; !ok || item.Value.MilliValue() != value {
+			t.Errorf("unexpected value %s of prediction %s", item.Value.String(), name)
+		}
+		if item.MetricLabels["app"] != "web" || !item.Timestamp.Equal(&metav1.Time{Time: now}) {
+			t.Errorf("unexpected value %+v", item)
+		}
+	}
+
+	list, err = provider.GetExternalMetric("default", "memory", labels.Everything())
+	if err != nil || len(list.Items) != 0 {
+		t.Errorf("expected no value of an unpredicted metric, got %+v, %v", list, err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	provider := newProvider(t, newPodGroupPrediction("web", now, 2500))
+	server := httptest.NewServer(NewHandler(provider))
+	defer server.Close()
+
+	get := func(path string, into interface{}) int {
+		t.Helper()
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("failed to get %s: %v", path, err)
+		}
+		defer resp.Body.Close()
+		if into != nil && resp.StatusCode == http.StatusOK {
+			if err := json.NewDecoder(resp.Body).Decode(into); err != nil {
+				t.Fatalf("failed to decode %s: %v", path, err)
+			}
+		}
+		return resp.StatusCode
+	}
+
+	resources := &metav1.APIResourceList{}
+	if code := get(PathPrefix+"/v1beta1", resources); code != http.StatusOK {
+		t.Fatalf("unexpected status %d of the discovery", code)
+	}
+	if len(resources.APIResources) != 1 || resources.APIResources[0].Name != "cpu" {
+		t.Errorf("unexpected resources %+v", resources.APIResources)
+	}
+
+	list := &v1beta1.ExternalMetricValueList{}
+	path := PathPrefix + "/v1beta1/namespaces/default/cpu?labelSelector=" + url.QueryEscape(PredictionLabel+"=web")
+	if code := get(path, list); code != http.StatusOK {
+		t.Fatalf("unexpected status %d of the values", code)
+	}
+	if len(list.Items) != 1 || list.Items[0].Value.MilliValue() != 2500 {
+		t.Errorf("unexpected values %+v", list.Items)
+	}
+
+	if code := get(PathPrefix+"/v1beta1/namespaces/default/cpu?labelSelector="+url.QueryEscape("!!"), nil); code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid selector, got %d", http.StatusBadRequest, code)
+	}
+	if code := get(PathPrefix+"/v1beta1/pods", nil); code != http.StatusNotFound {
+		t.Errorf("expected status %d for an unknown path, got %d", http.StatusNotFound, code)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 
 	predictionv1alpha1 "github.com/gocrane-io/api/pkg/generated/clientset/versioned/typed/prediction/v1alpha1"
+	predictionv1beta1 "github.com/gocrane-io/api/pkg/generated/clientset/versioned/typed/prediction/v1beta1"
+	queryv1alpha1 "github.com/gocrane-io/api/pkg/generated/clientset/versioned/typed/query/v1alpha1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
@@ -14,6 +16,8 @@ import (
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	PredictionV1alpha1() predictionv1alpha1.PredictionV1alpha1Interface
+	PredictionV1beta1() predictionv1beta1.PredictionV1beta1Interface
+	QueryV1alpha1() queryv1alpha1.QueryV1alpha1Interface
 }
 
 // Clientset contains the clients for groups. Each group has exactly one
@@ -21,6 +25,8 @@ type Interface interface {
 type Clientset struct {
 	*discovery.DiscoveryClient
 	predictionV1alpha1 *predictionv1alpha1.PredictionV1alpha1Client
+	predictionV1beta1  *predictionv1beta1.PredictionV1beta1Client
+	queryV1alpha1      *queryv1alpha1.QueryV1alpha1Client
 }
 
 // PredictionV1alpha1 retrieves the PredictionV1alpha1Client
@@ -28,6 +34,16 @@ func (c *Clientset) PredictionV1alpha1() predictionv1alpha1.PredictionV1alpha1In
 	return c.predictionV1alpha1
 }
 
+// PredictionV1beta1 retrieves the PredictionV1beta1Client
+func (c *Clientset) PredictionV1beta1() predictionv1beta1.PredictionV1beta1Interface {
+	return c.predictionV1beta1
+}
+
+// QueryV1alpha1 retrieves the QueryV1alpha1Client
+func (c *Clientset) QueryV1alpha1() queryv1alpha1.QueryV1alpha1Interface {
+	return c.queryV1alpha1
+}
+
 // Discovery retrieves the DiscoveryClient
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	if c == nil {
@@ -53,6 +69,14 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	cs.predictionV1beta1, err = predictionv1beta1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
+	cs.queryV1alpha1, err = queryv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfig(&configShallowCopy)
 	if err != nil {
@@ -66,6 +90,8 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 func NewForConfigOrDie(c *rest.Config) *Clientset {
 	var cs Clientset
 	cs.predictionV1alpha1 = predictionv1alpha1.NewForConfigOrDie(c)
+	cs.predictionV1beta1 = predictionv1beta1.NewForConfigOrDie(c)
+	cs.queryV1alpha1 = queryv1alpha1.NewForConfigOrDie(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
 	return &cs
@@ -75,6 +101,8 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.predictionV1alpha1 = predictionv1alpha1.New(c)
+	cs.predictionV1beta1 = predictionv1beta1.New(c)
+	cs.queryV1alpha1 = queryv1alpha1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
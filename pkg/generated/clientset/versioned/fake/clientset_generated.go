@@ -6,6 +6,10 @@ import (
 	clientset "github.com/gocrane-io/api/pkg/generated/clientset/versioned"
 	predictionv1alpha1 "github.com/gocrane-io/api/pkg/generated/clientset/versioned/typed/prediction/v1alpha1"
 	fakepredictionv1alpha1 "github.com/gocrane-io/api/pkg/generated/clientset/versioned/typed/prediction/v1alpha1/fake"
+	predictionv1beta1 "github.com/gocrane-io/api/pkg/generated/clientset/versioned/typed/prediction/v1beta1"
+	fakepredictionv1beta1 "github.com/gocrane-io/api/pkg/generated/clientset/versioned/typed/prediction/v1beta1/fake"
+	queryv1alpha1 "github.com/gocrane-io/api/pkg/generated/clientset/versioned/typed/query/v1alpha1"
+	fakequeryv1alpha1 "github.com/gocrane-io/api/pkg/generated/clientset/versioned/typed/query/v1alpha1/fake"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
@@ -67,3 +71,13 @@ var (
 func (c *Clientset) PredictionV1alpha1() predictionv1alpha1.PredictionV1alpha1Interface {
 	return &fakepredictionv1alpha1.FakePredictionV1alpha1{Fake: &c.Fake}
 }
+
+// PredictionV1beta1 retrieves the PredictionV1beta1Client
+func (c *Clientset) PredictionV1beta1() predictionv1beta1.PredictionV1beta1Interface {
+	return &fakepredictionv1beta1.FakePredictionV1beta1{Fake: &c.Fake}
+}
+
+// QueryV1alpha1 retrieves the QueryV1alpha1Client
+func (c *Clientset) QueryV1alpha1() queryv1alpha1.QueryV1alpha1Interface {
+	return &fakequeryv1alpha1.FakeQueryV1alpha1{Fake: &c.Fake}
+}
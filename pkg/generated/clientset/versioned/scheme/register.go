@@ -4,6 +4,8 @@ package scheme
 
 import (
 	predictionv1alpha1 "github.com/gocrane-io/api/prediction/v1alpha1"
+	predictionv1beta1 "github.com/gocrane-io/api/prediction/v1beta1"
+	queryv1alpha1 "github.com/gocrane-io/api/query/v1alpha1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
@@ -16,6 +18,8 @@ var Codecs = serializer.NewCodecFactory(Scheme)
 var ParameterCodec = runtime.NewParameterCodec(Scheme)
 var localSchemeBuilder = runtime.SchemeBuilder{
 	predictionv1alpha1.AddToScheme,
+	predictionv1beta1.AddToScheme,
+	queryv1alpha1.AddToScheme,
 }
 
 // AddToScheme adds all types of this clientset into the given scheme. This allows composition
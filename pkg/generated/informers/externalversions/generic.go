@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	v1alpha1 "github.com/gocrane-io/api/prediction/v1alpha1"
+	v1beta1 "github.com/gocrane-io/api/prediction/v1beta1"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 )
@@ -42,6 +43,12 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	case v1alpha1.SchemeGroupVersion.WithResource("podgrouppredictions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Prediction().V1alpha1().PodGroupPredictions().Informer()}, nil
 
+		// Group=prediction.crane.io, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("nodepredictions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Prediction().V1beta1().NodePredictions().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("podgrouppredictions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Prediction().V1beta1().PodGroupPredictions().Informer()}, nil
+
 	}
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
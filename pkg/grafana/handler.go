@@ -0,0 +1,332 @@
+// Package grafana implements the Grafana JSON datasource protocol on top of the predictions, so that their
+// forecast series are charted, and their condition transitions annotated, without going through Prometheus.
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	"github.com/gocrane-io/api/pkg/exporter"
+	predictionlisters "github.com/gocrane-io/api/pkg/generated/listers/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// Paths of the endpoints of the datasource.
+const (
+	SearchPath      = "/search"
+	QueryPath       = "/query"
+	AnnotationsPath = "/annotations"
+)
+
+const maxRequestBodyBytes = 1024 * 1024
+
+// Range is the time range of a query or of an annotation query.
+type Range struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// contains returns whether t is within the range, bounds included.
+func (r Range) contains(t time.Time) bool {
+	return !t.Before(r.From) && !t.After(r.To)
+}
+
+// SearchRequest is the body of a search request.
+type SearchRequest struct {
+	Target string `json:"target"`
+}
+
+// QueryRequest is the body of a query request.
+type QueryRequest struct {
+	Range   Range    `json:"range"`
+	Targets []Target `json:"targets"`
+}
+
+// Target is a target of a query request, see Handler for its format.
+type Target struct {
+	Target string `json:"target"`
+	RefID  string `json:"refId,omitempty"`
+}
+
+// TimeSeries is a series of the response of a query, every data point is a value and a unix timestamp in
+// milliseconds.
+type TimeSeries struct {
+	Target     string       `json:"target"`
+	DataPoints [][2]float64 `json:"datapoints"`
+}
+
+// AnnotationsRequest is the body of an annotations request.
+type AnnotationsRequest struct {
+	Range      Range      `json:"range"`
+	Annotation Annotation `json:"annotation"`
+}
+
+// Annotation is the annotation query of an annotations request, its query is a prefix of the targets of the
+// annotated predictions, empty for every prediction.
+type Annotation struct {
+	Name       string `json:"name"`
+	Datasource string `json:"datasource,omitempty"`
+	Enable     bool   `json:"enable"`
+	IconColor  string `json:"iconColor,omitempty"`
+	Query      string `json:"query"`
+}
+
+// AnnotationEvent is an annotation of the response of an annotations request, its time is a unix timestamp in
+// milliseconds.
+type AnnotationEvent struct {
+	Annotation Annotation `json:"annotation"`
+	Time       int64      `json:"time"`
+	Title      string     `json:"title"`
+	Text       string     `json:"text"`
+	Tags       []string   `json:"tags"`
+}
+
+// Handler serves the Grafana JSON datasource protocol from the listers of the predictions. The targets are
+//
+//	NodePrediction/<name>/<metric>
+//	PodGroupPrediction/<namespace>/<name>/<metric>
+//	PodGroupPrediction/<namespace>/<name>/<metric>/<namespace>/<pod>/<container>
+//
+// for the forecast of a NodePrediction, of the aggregation of a PodGroupPrediction and of one of its containers.
+// The values are in the base unit of their metric, cores for cpu and bytes for memory, and the transitions of
+// the conditions of the predictions to True are the annotations. A condition only records its last transition,
+// so the earlier transitions of a prediction entering the same phase again are not annotated.
+type Handler struct {
+	podGroupPredictionLister predictionlisters.PodGroupPredictionLister
+	nodePredictionLister     predictionlisters.NodePredictionLister
+}
+
+// NewHandler returns the http handler of the datasource.
+func NewHandler(podGroupPredictionLister predictionlisters.PodGroupPredictionLister, nodePredictionLister predictionlisters.NodePredictionLister) http.Handler {
+	h := &Handler{podGroupPredictionLister: podGroupPredictionLister, nodePredictionLister: nodePredictionLister}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		// Grafana tests the connection to the datasource with a GET of its root.
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc(SearchPath, h.serveSearch)
+	mux.HandleFunc(QueryPath, h.serveQuery)
+	mux.HandleFunc(AnnotationsPath, h.serveAnnotations)
+	return mux
+}
+
+func (h *Handler) serveSearch(w http.ResponseWriter, r *http.Request) {
+	req := &SearchRequest{}
+	if !decodeRequest(w, r, req) {
+		return
+	}
+	targets, err := h.targets()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to list predictions: %v", err), http.StatusInternalServerError)
+		return
+	}
+	matched := make([]string, 0, len(targets))
+	for _, target := range targets {
+		if strings.Contains(target, req.Target) {
+			matched = append(matched, target)
+		}
+	}
+	writeResponse(w, matched)
+}
+
+func (h *Handler) serveQuery(w http.ResponseWriter, r *http.Request) {
+	req := &QueryRequest{}
+	if !decodeRequest(w, r, req) {
+		return
+	}
+	series := make([]TimeSeries, 0, len(req.Targets))
+	for _, target := range req.Targets {
+		if target.Target == "" {
+			continue
+		}
+		ts, err := h.query(target.Target, req.Range)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to query target %q: %v", target.Target, err), http.StatusBadRequest)
+			return
+		}
+		series = append(series, ts)
+	}
+	writeResponse(w, series)
+}
+
+// serveAnnotations annotates the conditions of the predictions that are True at the time of their last
+// transition: the phase the prediction entered then. The conditions that are False are left out, their
+// transition is the one of the condition that became True. The query selects the predictions whose target is
+// the query or under it, e.g. PodGroupPrediction/default selects the predictions of the default namespace but
+// PodGroupPrediction/default/web does not select PodGroupPrediction/default/web2.
+func (h *Handler) serveAnnotations(w http.ResponseWriter, r *http.Request) {
+	req := &AnnotationsRequest{}
+	if !decodeRequest(w, r, req) {
+		return
+	}
+	query := strings.TrimSuffix(req.Annotation.Query, "/")
+	events := []AnnotationEvent{}
+	appendEvents := func(target, kind, namespace, name string, conditions []v1alpha1.PredictionCondition) {
+		if query != "" && target != query && !strings.HasPrefix(target, query+"/") {
+			return
+		}
+		for _, condition := range conditions {
+			if condition.Status != corev1.ConditionTrue || !req.Range.contains(condition.LastTransitionTime.Time) {
+				continue
+			}
+			events = append(events, AnnotationEvent{
+				Annotation: req.Annotation,
+				Time:       condition.LastTransitionTime.UnixNano() / int64(time.Millisecond),
+				Title:      string(condition.Type),
+				Text:       fmt.Sprintf("%s: %s", condition.Reason, condition.Message),
+				Tags:       []string{kind, namespace, name, string(condition.Type)},
+			})
+		}
+	}
+
+	nps, err := h.nodePredictionLister.List(labels.Everything())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to list node predictions: %v", err), http.StatusInternalServerError)
+		return
+	}
+	for _, np := range nps {
+		appendEvents(exporter.KindNodePrediction+"/"+np.Name, exporter.KindNodePrediction, "", np.Name, np.Status.Conditions)
+	}
+	pgps, err := h.podGroupPredictionLister.List(labels.Everything())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to list pod group predictions: %v", err), http.StatusInternalServerError)
+		return
+	}
+	for _, pgp := range pgps {
+		target := exporter.KindPodGroupPrediction + "/" + pgp.Namespace + "/" + pgp.Name
+		appendEvents(target, exporter.KindPodGroupPrediction, pgp.Namespace, pgp.Name, pgp.Status.Conditions)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time < events[j].Time
+	})
+	writeResponse(w, events)
+}
+
+// targets returns the sorted targets of all the forecast series.
+func (h *Handler) targets() ([]string, error) {
+	var targets []string
+	nps, err := h.nodePredictionLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, np := range nps {
+		for metric := range np.Status.Consumed {
+			targets = append(targets, strings.Join([]string{exporter.KindNodePrediction, np.Name, metric}, "/"))
+		}
+	}
+	pgps, err := h.podGroupPredictionLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, pgp := range pgps {
+		prefix := strings.Join([]string{exporter.KindPodGroupPrediction, pgp.Namespace, pgp.Name}, "/")
+		for metric := range pgp.Status.Aggregation {
+			targets = append(targets, prefix+"/"+metric)
+		}
+		for container, prediction := range pgp.Status.Containers {
+			for metric := range prediction {
+				targets = append(targets, prefix+"/"+metric+"/"+container)
+			}
+		}
+	}
+	sort.Strings(targets)
+	return targets, nil
+}
+
+// query returns the forecast series of a target within the range.
+func (h *Handler) query(target string, r Range) (TimeSeries, error) {
+	var (
+		metric string
+		ts     v1alpha1.TimeSeries
+		found  bool
+	)
+	parts := strings.SplitN(target, "/", 5)
+	switch {
+	case parts[0] == exporter.KindNodePrediction && len(parts) == 3:
+		np, err := h.nodePredictionLister.Get(parts[1])
+		if err != nil {
+			return TimeSeries{}, err
+		}
+		metric = parts[2]
+		ts, found = np.Status.Consumed[metric]
+	case parts[0] == exporter.KindPodGroupPrediction && len(parts) >= 4:
+		pgp, err := h.podGroupPredictionLister.PodGroupPredictions(parts[1]).Get(parts[2])
+		if err != nil {
+			return TimeSeries{}, err
+		}
+		metric = parts[3]
+		if len(parts) == 4 {
+			ts, found = pgp.Status.Aggregation[metric]
+		} else {
+			ts, found = pgp.Status.Containers[parts[4]][metric]
+		}
+	default:
+		return TimeSeries{}, fmt.Errorf("the target is not one of %s/<name>/<metric> or %s/<namespace>/<name>/<metric>[/<container>]",
+			exporter.KindNodePrediction, exporter.KindPodGroupPrediction)
+	}
+	if !found {
+		return TimeSeries{}, fmt.Errorf("metric %s is not predicted", metric)
+	}
+
+	resourceName := v1alpha1.ResourceName(metric)
+	samples, err := helper.ParseTimeSeries(resourceName, ts)
+	if err != nil {
+		return TimeSeries{}, err
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Timestamp < samples[j].Timestamp
+	})
+	series := TimeSeries{Target: target, DataPoints: make([][2]float64, 0, len(samples))}
+	for _, sample := range samples {
+		if !r.contains(time.Unix(sample.Timestamp, 0)) {
+			continue
+		}
+		value := helper.ToQuantity(resourceName, sample.Value)
+		series.DataPoints = append(series.DataPoints, [2]float64{value.AsApproximateFloat64(), float64(sample.Timestamp * 1000)})
+	}
+	return series, nil
+}
+
+// decodeRequest decodes the JSON body of a POST request, it writes the error and returns false if it fails.
+func decodeRequest(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+		return false
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read request body: %v", err), http.StatusBadRequest)
+		return false
+	}
+	if err := json.Unmarshal(body, req); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode request: %v", err), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func writeResponse(w http.ResponseWriter, resp interface{}) {
+	body, err := json.Marshal(resp)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to encode response: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(body); err != nil {
+		klog.Errorf("Failed to write response: %v", err)
+	}
+}
@@ -0,0 +1,241 @@
+package grafana
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/gocrane-io/api/pkg/generated/clientset/versioned/fake"
+	"github.com/gocrane-io/api/pkg/generated/informers/externalversions"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/predictiontest"
+)
+
+var now = predictiontest.Now
+
+// newPodGroupPrediction returns the fixture predicting since a minute, whose cpu forecast is the same for its
+// aggregation and container.
+func newPodGroupPrediction() *v1alpha1.PodGroupPrediction {
+	cpu := v1alpha1.TimeSeries{
+		{Value: "1500", Timestamp: now.Unix()},
+		{Value: "2500", Timestamp: now.Add(time.Minute).Unix()},
+		{Value: "500", Timestamp: now.Add(time.Hour).Unix()},
+	}
+	pgp := predictiontest.NewPodGroupPrediction("default", "web")
+	pgp.Status = v1alpha1.PodGroupPredictionStatus{
+		Conditions: []v1alpha1.PredictionCondition{
+			{
+				Type:               v1alpha1.PredictionConditionPredicting,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(now.Add(-time.Minute)),
+				Reason:             "Predicted",
+			},
+			{
+				Type:               v1alpha1.PredictionConditionCharging,
+				Status:             corev1.ConditionFalse,
+				LastTransitionTime: metav1.NewTime(now.Add(-time.Minute)),
+			},
+		},
+		Aggregation: v1alpha1.Prediction{"cpu": cpu},
+		Containers:  map[string]v1alpha1.Prediction{"default/web-0/web": {"cpu": cpu}},
+	}
+	return pgp
+}
+
+// newNodePrediction returns the fixture of node-1 charging since 2 hours.
+func newNodePrediction() *v1alpha1.NodePrediction {
+	np := predictiontest.NewNodePrediction("node-1")
+	np.Status = v1alpha1.NodePredictionResourceStatus{
+		Conditions: []v1alpha1.PredictionCondition{{
+			Type:               v1alpha1.PredictionConditionCharging,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(now.Add(-2 * time.Hour)),
+		}},
+		Consumed: v1alpha1.Prediction{"memory": predictiontest.Series(now, time.Minute, "1073741824")},
+	}
+	return np
+}
+
+// newServer returns a datasource whose listers are synced with the predictions.
+func newServer(t *testing.T, predictions ...runtime.Object) *httptest.Server {
+	t.Helper()
+	informers := externalversions.NewSharedInformerFactory(fake.NewSimpleClientset(predictions...), 0)
+	handler := NewHandler(informers.Prediction().V1alpha1().PodGroupPredictions().Lister(), informers.Prediction().V1alpha1().NodePredictions().Lister())
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	informers.Start(stopCh)
+	informers.WaitForCacheSync(stopCh)
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// post posts the request and decodes the response, it returns the status code.
+func post(t *testing.T, server *httptest.Server, path string, req, resp interface{}) int {
+	t.Helper()
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("failed to encode request: %v", err)
+	}
+	r, err := http.Post(server.URL+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to post to %s: %v", path, err)
+	}
+	defer r.Body.Close()
+	if r.StatusCode == http.StatusOK && resp != nil {
+		if err := json.NewDecoder(r.Body).Decode(resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+	return r.StatusCode
+}
+
+func TestSearch(t *testing.T) {
+	server := newServer(t, newPodGroupPrediction(), newNodePrediction())
+
+	var targets []string
+	if code := post(t, server, SearchPath, &SearchRequest{}, &targets); code != http.StatusOK {
+		t.Fatalf("unexpected status %d", code)
+	}
+	expected := []string{
+		"NodePrediction/node-1/memory",
+		"PodGroupPrediction/default/web/cpu",
+		"PodGroupPrediction/default/web/cpu/default/web-0/web",
+	}
+	if !reflect.DeepEqual(targets, expected) {
+		t.Errorf("expected targets %v, got %v", expected, targets)
+	}
+
+	if code := post(t, server, SearchPath, &SearchRequest{Target: "web-0"}, &targets); code != http.StatusOK || len(targets) != 1 {
+		t.Errorf("expected the container target, got %d %v", code, targets)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	server := newServer(t, newPodGroupPrediction(), newNodePrediction())
+
+	req := &QueryRequest{
+		Range: Range{From: now, To: now.Add(30 * time.Minute)},
+		Targets: []Target{
+			{Target: "PodGroupPrediction/default/web/cpu"},
+			{Target: "PodGroupPrediction/default/web/cpu/default/web-0/web"},
+			{Target: "NodePrediction/node-1/memory"},
+		},
+	}
+	var series []TimeSeries
+	if code := post(t, server, QueryPath, req, &series); code != http.StatusOK {
+		t.Fatalf("unexpected status %d", code)
+	}
+	cpu := [][2]float64{{1.5, float64(now.Unix() * 1000)}, {2.5, float64(now.Add(time.Minute).Unix() * 1000)}}
+	expected := []TimeSeries{
+		{Target: req.Targets[0].Target, DataPoints: cpu},
+		{Target: req.Targets[1].Target, DataPoints: cpu},
+		{Target: req.Targets[2].Target, DataPoints: [][2]float64{{1 << 30, float64(now.Unix() * 1000)}}},
+	}
+	if !reflect.DeepEqual(series, expected) {
+		t.Errorf("expected series %+v, got %+v", expected, series)
+	}
+
+	for _, target := range []string{"PodGroupPrediction/default/web/memory", "PodGroupPrediction/web", "NodePrediction/node-2/cpu"} {
+		req.Targets = []Target{{Target: target}}
+		if code := post(t, server, QueryPath, req, nil); code != http.StatusBadRequest {
+			t.Errorf("expected status %d for target %s, got %d", http.StatusBadRequest, target, code)
+		}
+	}
+}
+
+func TestAnnotations(t *testing.T) {
+	// web2 and web-canary share the name of web as a prefix, their conditions must not annotate web.
+	web2, canary := newPodGroupPrediction(), newPodGroupPrediction()
+	web2.Name, canary.Name = "web2", "web-canary"
+	web2.Status.Conditions[0].Type, canary.Status.Conditions[0].Type = v1alpha1.PredictionConditionFailed, v1alpha1.PredictionConditionCharging
+	web2.Status.Conditions[0].LastTransitionTime = metav1.NewTime(now.Add(-40 * time.Second))
+	canary.Status.Conditions[0].LastTransitionTime = metav1.NewTime(now.Add(-50 * time.Second))
+	server := newServer(t, newPodGroupPrediction(), newNodePrediction(), web2, canary)
+
+	cases := []struct {
+		name   string
+		query  string
+		from   time.Time
+		titles []string
+	}{
+		{name: "every prediction", from: now.Add(-3 * time.Hour), titles: []string{"Charging", "Predicting", "Charging", "Failed"}},
+		{name: "within the range", query: "PodGroupPrediction/default/web", from: now.Add(-time.Hour), titles: []string{"Predicting"}},
+		{name: "kind of the targets", query: "NodePrediction", from: now.Add(-3 * time.Hour), titles: []string{"Charging"}},
+		{name: "kind with a trailing slash", query: "NodePrediction/", from: now.Add(-3 * time.Hour), titles: []string{"Charging"}},
+		{name: "namespace of the targets", query: "PodGroupPrediction/default", from: now.Add(-time.Hour), titles: []string{"Predicting", "Charging", "Failed"}},
+		{name: "name sharing a prefix", query: "PodGroupPrediction/default/web2", from: now.Add(-time.Hour), titles: []string{"Failed"}},
+		{name: "partial name", query: "PodGroupPrediction/default/we", from: now.Add(-time.Hour)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := &AnnotationsRequest{Range: Range{From: c.from, To: now}, Annotation: Annotation{Name: "conditions", Query: c.query}}
+			var events []AnnotationEvent
+			if code := post(t, server, AnnotationsPath, req, &events); code != http.StatusOK {
+				t.Fatalf("unexpected status %d", code)
+			}
+			var titles []string
+			for _, event := range events {
+				titles = append(titles, event.Title)
+			}
+			if !reflect.DeepEqual(titles, c.titles) {
+				t.Errorf("expected annotations %v, got %v", c.titles, titles)
+			}
+		})
+	}
+
+	req := &AnnotationsRequest{Range: Range{From: now.Add(-time.Hour), To: now}, Annotation: Annotation{Name: "conditions", Query: "PodGroupPrediction/default/web"}}
+	var events []AnnotationEvent
+	post(t, server, AnnotationsPath, req, &events)
+	expected := AnnotationEvent{
+		Annotation: req.Annotation,
+		Time:       now.Add(-time.Minute).Unix() * 1000,
+		Title:      "Predicting",
+		Text:       "Predicted: ",
+		Tags:       []string{"PodGroupPrediction", "default", "web", "Predicting"},
+	}
+	if len(events) != 1 || !reflect.DeepEqual(events[0], expected) {
+		t.Errorf("expected annotation %+v, got %+v", expected, events)
+	}
+}
+
+func TestInvalidRequests(t *testing.T) {
+	server := newServer(t)
+
+	resp, err := http.Get(server.URL + QueryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected status %d for GET, got %d", http.StatusMethodNotAllowed, resp.StatusCode)
+	}
+
+	resp, err = http.Post(server.URL+QueryPath, "application/json", bytes.NewReader([]byte("{")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid body, got %d", http.StatusBadRequest, resp.StatusCode)
+	}
+
+	resp, err = http.Get(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status %d for the connection test, got %d", http.StatusOK, resp.StatusCode)
+	}
+}
@@ -0,0 +1,58 @@
+package histogram
+
+import (
+	"fmt"
+	"time"
+)
+
+// Checkpoint is the serializable state of a histogram. The weights are stored as is, a histogram restored
+// from a checkpoint answers exactly the same percentiles as the saved one.
+type Checkpoint struct {
+	// ReferenceTimestamp is the timestamp the weights are relative to.
+	ReferenceTimestamp time.Time `json:"referenceTimestamp"`
+	// NumBuckets is the number of buckets of the histogram, a checkpoint can only be loaded into a histogram
+	// of the same number of buckets.
+	NumBuckets int `json:"numBuckets"`
+	// TotalWeight is the sum of the weights of all buckets.
+	TotalWeight float64 `json:"totalWeight"`
+	// BucketWeights are the weights of the non empty buckets, keyed by bucket index.
+	BucketWeights map[int]float64 `json:"bucketWeights,omitempty"`
+}
+
+// SaveToCheckpoint returns the state of the histogram.
+func (h *Histogram) SaveToCheckpoint() *Checkpoint {
+	c := &Checkpoint{
+		ReferenceTimestamp: h.referenceTimestamp,
+		NumBuckets:         h.options.NumBuckets(),
+		TotalWeight:        h.totalWeight,
+		BucketWeights:      make(map[int]float64),
+	}
+	for bucket, weight := range h.bucketWeight {
+		if weight != 0 {
+			c.BucketWeights[bucket] = weight
+		}
+	}
+	return c
+}
+
+// LoadFromCheckpoint replaces the state of the histogram with the checkpoint.
+func (h *Histogram) LoadFromCheckpoint(c *Checkpoint) error {
+	if c.NumBuckets != h.options.NumBuckets() {
+		return fmt.Errorf("checkpoint has %d buckets, the histogram has %d", c.NumBuckets, h.options.NumBuckets())
+	}
+	weights := make([]float64, h.options.NumBuckets())
+	for bucket, weight := range c.BucketWeights {
+		if bucket < 0 || bucket >= len(weights) {
+			return fmt.Errorf("bucket %d of checkpoint is out of range [0, %d)", bucket, len(weights))
+		}
+		if weight < 0 {
+			return fmt.Errorf("bucket %d of checkpoint has a negative weight %g", bucket, weight)
+		}
+		weights[bucket] = weight
+	}
+	h.referenceTimestamp = c.ReferenceTimestamp
+	h.totalWeight = c.TotalWeight
+	h.bucketWeight = weights
+	h.updateMinAndMaxBucket()
+	return nil
+}
@@ -0,0 +1,144 @@
+// Package histogram implements the exponentially decaying histogram described by the HistogramConfig of a
+// PercentileConfig: weighted samples lose half of their weight every HalfLife, and percentiles are answered
+// with the upper bound of the bucket they fall into.
+package histogram
+
+import (
+	"math"
+	"time"
+)
+
+// maxDecayExponent is the exponent of the decay factor of a sample above which the reference timestamp
+// is moved forward, to keep the weights in the range of a float64.
+const maxDecayExponent = 100
+
+// Histogram is an exponentially decaying histogram. The weights are stored relative to a reference timestamp:
+// a sample of time t and weight w is stored with weight w*2^((t-reference)/halfLife), so that older samples
+// weigh less without having to update the existing buckets when a sample is added.
+type Histogram struct {
+	options *Options
+
+	referenceTimestamp time.Time
+	bucketWeight       []float64
+	totalWeight        float64
+	// minBucket and maxBucket are the first and last buckets whose weight is at least epsilon, minBucket is
+	// larger than maxBucket when the histogram is empty.
+	minBucket int
+	maxBucket int
+}
+
+// New returns an empty histogram.
+func New(options *Options) *Histogram {
+	return &Histogram{
+		options:      options,
+		bucketWeight: make([]float64, options.NumBuckets()),
+		minBucket:    options.NumBuckets() - 1,
+		maxBucket:    0,
+	}
+}
+
+// Options returns the options of the histogram.
+func (h *Histogram) Options() *Options {
+	return h.options
+}
+
+// AddSample adds a sample of the given value, weight and time. Weights below MinSampleWeight are raised to it.
+func (h *Histogram) AddSample(value, weight float64, t time.Time) {
+	if weight < h.options.MinSampleWeight {
+		weight = h.options.MinSampleWeight
+	}
+	if h.referenceTimestamp.IsZero() {
+		h.referenceTimestamp = t.Truncate(h.options.HalfLife)
+	}
+	if h.decayExponent(t) > maxDecayExponent {
+		h.shiftReferenceTimestamp(t.Truncate(h.options.HalfLife))
+	}
+	h.add(h.options.FindBucket(value), weight*h.decayFactor(t))
+}
+
+// Percentile returns the upper bound of the bucket holding the given percentile of the weight, p in [0, 1],
+// or the start of the last bucket which has no upper bound. It returns zero for an empty histogram.
+func (h *Histogram) Percentile(p float64) float64 {
+	if h.IsEmpty() {
+		return 0
+	}
+	threshold := p * h.totalWeight
+	partialSum := 0.0
+	bucket := h.minBucket
+	for ; bucket < h.maxBucket; bucket++ {
+		partialSum += h.bucketWeight[bucket]
+		if partialSum >= threshold {
+			break
+		}
+	}
+	if bucket < h.options.NumBuckets()-1 {
+		return h.options.BucketStart(bucket + 1)
+	}
+	return h.options.BucketStart(bucket)
+}
+
+// IsEmpty returns whether no bucket has a weight of at least Epsilon.
+func (h *Histogram) IsEmpty() bool {
+	return h.minBucket > h.maxBucket
+}
+
+// Merge adds the samples of another histogram of the same options.
+func (h *Histogram) Merge(other *Histogram) {
+	if other.IsEmpty() {
+		return
+	}
+	if h.referenceTimestamp.IsZero() {
+		h.referenceTimestamp = other.referenceTimestamp
+	}
+	if other.referenceTimestamp.After(h.referenceTimestamp) {
+		h.shiftReferenceTimestamp(other.referenceTimestamp)
+	}
+	factor := math.Exp2(other.referenceTimestamp.Sub(h.referenceTimestamp).Seconds() / h.options.HalfLife.Seconds())
+	for bucket := other.minBucket; bucket <= other.maxBucket; bucket++ {
+		h.add(bucket, other.bucketWeight[bucket]*factor)
+	}
+}
+
+func (h *Histogram) add(bucket int, weight float64) {
+	h.bucketWeight[bucket] += weight
+	h.totalWeight += weight
+	if h.bucketWeight[bucket] >= h.options.Epsilon {
+		if bucket < h.minBucket {
+			h.minBucket = bucket
+		}
+		if bucket > h.maxBucket {
+			h.maxBucket = bucket
+		}
+	}
+}
+
+func (h *Histogram) decayExponent(t time.Time) float64 {
+	return t.Sub(h.referenceTimestamp).Seconds() / h.options.HalfLife.Seconds()
+}
+
+func (h *Histogram) decayFactor(t time.Time) float64 {
+	return math.Exp2(h.decayExponent(t))
+}
+
+// shiftReferenceTimestamp moves the reference timestamp and scales the weights accordingly.
+func (h *Histogram) shiftReferenceTimestamp(reference time.Time) {
+	factor := math.Exp2(-reference.Sub(h.referenceTimestamp).Seconds() / h.options.HalfLife.Seconds())
+	h.referenceTimestamp = reference
+	h.totalWeight *= factor
+	for bucket := range h.bucketWeight {
+		h.bucketWeight[bucket] *= factor
+	}
+	h.updateMinAndMaxBucket()
+}
+
+func (h *Histogram) updateMinAndMaxBucket() {
+	h.minBucket, h.maxBucket = h.options.NumBuckets()-1, 0
+	for bucket, weight := range h.bucketWeight {
+		if weight >= h.options.Epsilon {
+			if bucket < h.minBucket {
+				h.minBucket = bucket
+			}
+			h.maxBucket = bucket
+		}
+	}
+}
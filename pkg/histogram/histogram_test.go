@@ -0,0 +1,224 @@
+package histogram
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var t0 = time.Unix(1634860800, 0)
+
+func newOptions(t *testing.T, o Options) *Options {
+	t.Helper()
+	if err := o.init(); err != nil {
+		t.Fatalf("invalid options: %v", err)
+	}
+	return &o
+}
+
+// linearOptions has the 11 buckets [0, 1), [1, 2) ... [9, 10) and [10, +inf).
+func linearOptions(t *testing.T) *Options {
+	return newOptions(t, Options{MaxValue: 10, Epsilon: 1e-3, HalfLife: time.Hour, BucketSize: 1})
+}
+
+func TestFindBucketLinear(t *testing.T) {
+	o := linearOptions(t)
+	if o.NumBuckets() != 11 {
+		t.Fatalf("expected 11 buckets, got %d", o.NumBuckets())
+	}
+	cases := []struct {
+		value  float64
+		bucket int
+	}{
+		{value: -5, bucket: 0},
+		{value: math.NaN(), bucket: 0},
+		{value: 0, bucket: 0},
+		{value: 0.999, bucket: 0},
+		{value: 1, bucket: 1},
+		{value: 9.999, bucket: 9},
+		{value: 10, bucket: 10},
+		{value: 1000, bucket: 10},
+	}
+	for _, c := range cases {
+		if got := o.FindBucket(c.value); got != c.bucket {
+			t.Errorf("FindBucket(%g): expected %d, got %d", c.value, c.bucket, got)
+		}
+	}
+}
+
+func TestFindBucketExponentialEdges(t *testing.T) {
+	for _, ratio := range []float64{1, 0.05} {
+		o := newOptions(t, Options{MaxValue: 1000, Epsilon: 1e-3, HalfLife: time.Hour, FirstBucketSize: 0.1, BucketSizeGrowthRatio: ratio})
+		for bucket := 1; bucket < o.NumBuckets(); bucket++ {
+			start := o.BucketStart(bucket)
+			if got := o.FindBucket(start); got != bucket {
+				t.Errorf("ratio %g: FindBucket(%g), the start of bucket %d, got %d", ratio, start, bucket, got)
+			}
+			if below := math.Nextafter(start, 0); o.FindBucket(below) != bucket-1 {
+				t.Errorf("ratio %g: FindBucket(%g), just below bucket %d, got %d", ratio, below, bucket, o.FindBucket(below))
+			}
+		}
+		if last := o.NumBuckets() - 1; o.FindBucket(1e9) != last || o.BucketStart(last) < o.MaxValue {
+			t.Errorf("ratio %g: expected the values above %g in the last bucket starting at %g", ratio, o.MaxValue, o.BucketStart(last))
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	h := New(linearOptions(t))
+	if !h.IsEmpty() || h.Percentile(0.5) != 0 {
+		t.Errorf("expected an empty histogram")
+	}
+	for v := 1; v <= 10; v++ {
+		h.AddSample(float64(v), 1, t0)
+	}
+	cases := []struct {
+		p, expected float64
+	}{
+		{p: 0, expected: 2},
+		{p: 0.1, expected: 2},
+		{p: 0.5, expected: 6},
+		{p: 0.95, expected: 10},
+		// the last bucket has no upper bound, its start is returned.
+		{p: 1, expected: 10},
+	}
+	for _, c := range cases {
+		if got := h.Percentile(c.p); got != c.expected {
+			t.Errorf("Percentile(%g): expected %g, got %g", c.p, c.expected, got)
+		}
+	}
+}
+
+func TestDecay(t *testing.T) {
+	h := New(linearOptions(t))
+	h.AddSample(1, 1, t0)
+	// a half life later, the first sample weighs half as much as the second one.
+	h.AddSample(9, 1, t0.Add(time.Hour))
+	if got := h.Percentile(0.3); got != 2 {
+		t.Errorf("expected the 30th percentile in the bucket of the first sample, got %g", got)
+	}
+	if got := h.Percentile(0.4); got != 10 {
+		t.Errorf("expected the 40th percentile in the bucket of the second sample, got %g", got)
+	}
+
+	h.AddSample(1, 0, t0)
+	if got := h.bucketWeight[1]; got != 1 {
+		t.Errorf("expected a zero weight sample to add nothing without a min sample weight, got %g", got)
+	}
+	h.options.MinSampleWeight = 0.5
+	h.AddSample(1, 0, t0)
+	if got := h.bucketWeight[1]; got != 1.5 {
+		t.Errorf("expected a zero weight sample to weigh the min sample weight, got %g", got)
+	}
+}
+
+func TestShiftReferenceTimestamp(t *testing.T) {
+	h := New(linearOptions(t))
+	h.AddSample(1, 1, t0)
+	h.AddSample(5, 1, t0.Add(50*time.Hour))
+	if !h.referenceTimestamp.Equal(t0) {
+		t.Fatalf("expected the reference timestamp to stay at %s, got %s", t0, h.referenceTimestamp)
+	}
+	later := t0.Add(150*time.Hour + 30*time.Minute)
+	h.AddSample(9, 1, later)
+	if expected := later.Truncate(time.Hour); !h.referenceTimestamp.Equal(expected) {
+		t.Fatalf("expected the reference timestamp to move to %s, got %s", expected, h.referenceTimestamp)
+	}
+	if math.IsInf(h.totalWeight, 0) || math.IsNaN(h.totalWeight) {
+		t.Fatalf("expected a finite total weight, got %g", h.totalWeight)
+	}
+	// the relative weights of the samples are kept.
+	if ratio := h.bucketWeight[5] / h.bucketWeight[9]; math.Abs(math.Log2(ratio)+100.5) > 1e-9 {
+		t.Errorf("expected the sample of 5 to weigh 2^-100.5 of the sample of 9, got %g", ratio)
+	}
+	// the first sample weighs less than epsilon and is out of the buckets answering the percentiles.
+	if h.minBucket != 9 || h.maxBucket != 9 || h.Percentile(0) != 10 {
+		t.Errorf("expected only the bucket of the last sample, got [%d, %d]", h.minBucket, h.maxBucket)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	all, a, b := New(linearOptions(t)), New(linearOptions(t)), New(linearOptions(t))
+	for i, v := range []float64{1, 2, 2, 3} {
+		a.AddSample(v, 1, t0.Add(time.Duration(i)*time.Minute))
+		all.AddSample(v, 1, t0.Add(time.Duration(i)*time.Minute))
+	}
+	for i, v := range []float64{7, 8, 8} {
+		b.AddSample(v, 2, t0.Add(3*time.Hour+time.Duration(i)*time.Minute))
+		all.AddSample(v, 2, t0.Add(3*time.Hour+time.Duration(i)*time.Minute))
+	}
+
+	a.Merge(b)
+	a.Merge(New(linearOptions(t)))
+	for p := 0.0; p <= 1; p += 0.05 {
+		if got, expected := a.Percentile(p), all.Percentile(p); got != expected {
+			t.Errorf("Percentile(%g): expected %g, got %g", p, expected, got)
+		}
+	}
+	// the weights are relative to the reference timestamps, the later one of the merged histograms.
+	scale := math.Exp2(a.referenceTimestamp.Sub(all.referenceTimestamp).Hours())
+	if math.Abs(a.totalWeight*scale-all.totalWeight) > 1e-9 {
+		t.Errorf("expected the total weight %g, got %g", all.totalWeight, a.totalWeight*scale)
+	}
+
+	// merging into an empty histogram takes the reference timestamp of the other one.
+	empty := New(linearOptions(t))
+	empty.Merge(b)
+	if !empty.referenceTimestamp.Equal(b.referenceTimestamp) || empty.Percentile(0.5) != b.Percentile(0.5) {
+		t.Errorf("expected the merge into an empty histogram to be the other histogram")
+	}
+}
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	options := newOptions(t, Options{MaxValue: 1000, Epsilon: 1e-4, HalfLife: 24 * time.Hour, FirstBucketSize: 0.01, BucketSizeGrowthRatio: 0.05})
+	h := New(options)
+	for i := 0; i < 500; i++ {
+		h.AddSample(float64(i%97)*3.7, float64(1+i%5), t0.Add(time.Duration(i)*17*time.Minute))
+	}
+
+	data, err := json.Marshal(h.SaveToCheckpoint())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkpoint := &Checkpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	restored := New(options)
+	if err := restored.LoadFromCheckpoint(checkpoint); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for p := 0.0; p <= 1; p += 0.01 {
+		if got, expected := restored.Percentile(p), h.Percentile(p); got != expected {
+			t.Errorf("Percentile(%g): expected %g, got %g", p, expected, got)
+		}
+	}
+	saved, resaved := h.SaveToCheckpoint(), restored.SaveToCheckpoint()
+	if !resaved.ReferenceTimestamp.Equal(saved.ReferenceTimestamp) || resaved.TotalWeight != saved.TotalWeight ||
+		!reflect.DeepEqual(resaved.BucketWeights, saved.BucketWeights) {
+		t.Errorf("expected the restored histogram to save the same checkpoint")
+	}
+
+	// both histograms keep answering the same percentiles.
+	h.AddSample(42, 1, t0.Add(300*time.Hour))
+	restored.AddSample(42, 1, t0.Add(300*time.Hour))
+	if got, expected := restored.Percentile(0.9), h.Percentile(0.9); got != expected {
+		t.Errorf("expected %g after a new sample, got %g", expected, got)
+	}
+}
+
+func TestLoadInvalidCheckpoint(t *testing.T) {
+	h := New(linearOptions(t))
+	cases := map[string]*Checkpoint{
+		"buckets":         {NumBuckets: 5},
+		"out of range":    {NumBuckets: 11, BucketWeights: map[int]float64{11: 1}},
+		"negative weight": {NumBuckets: 11, BucketWeights: map[int]float64{1: -1}},
+	}
+	for name, c := range cases {
+		if err := h.LoadFromCheckpoint(c); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
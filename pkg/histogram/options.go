@@ -0,0 +1,138 @@
+package histogram
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+)
+
+// maxNumBuckets bounds the memory of a histogram.
+const maxNumBuckets = 1 << 20
+
+// Options describe the buckets of a histogram and the decay of its samples.
+type Options struct {
+	// MaxValue is the largest value the histogram distinguishes, larger values fall into the last bucket.
+	MaxValue float64
+	// Epsilon is the weight under which a bucket is considered empty, the histogram is empty when all of its
+	// buckets are.
+	Epsilon float64
+	// HalfLife is the time after which the weight of a sample is halved.
+	HalfLife time.Duration
+	// BucketSize is the size of the buckets of a linear histogram, used when BucketSizeGrowthRatio is zero.
+	BucketSize float64
+	// FirstBucketSize is the size of the first bucket of an exponential histogram.
+	FirstBucketSize float64
+	// BucketSizeGrowthRatio is the ratio by which the size of a bucket grows over the previous one,
+	// zero for a linear histogram.
+	BucketSizeGrowthRatio float64
+	// MinSampleWeight is the weight a sample of a smaller weight is raised to.
+	MinSampleWeight float64
+
+	numBuckets int
+}
+
+// NewOptions returns the options of a defaulted PercentileConfig.
+func NewOptions(config *v1alpha1.PercentileConfig) (*Options, error) {
+	h := &config.Histogram
+	o := &Options{}
+	floats := []struct {
+		name  string
+		value string
+		out   *float64
+	}{
+		{"maxValue", h.MaxValue, &o.MaxValue},
+		{"epsilon", h.Epsilon, &o.Epsilon},
+		{"bucketSize", h.BucketSize, &o.BucketSize},
+		{"firstBucketSize", h.FirstBucketSize, &o.FirstBucketSize},
+		{"bucketSizeGrowthRatio", h.BucketSizeGrowthRatio, &o.BucketSizeGrowthRatio},
+		{"minSampleWeight", config.MinSampleWeight, &o.MinSampleWeight},
+	}
+	for _, f := range floats {
+		v, err := strconv.ParseFloat(f.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %v", f.name, f.value, err)
+		}
+		*f.out = v
+	}
+	halfLife, err := time.ParseDuration(h.HalfLife)
+	if err != nil {
+		return nil, fmt.Errorf("invalid halfLife %q: %v", h.HalfLife, err)
+	}
+	o.HalfLife = halfLife
+	if err := o.init(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// init checks the options and computes the number of buckets.
+func (o *Options) init() error {
+	switch {
+	case o.MaxValue <= 0:
+		return fmt.Errorf("maxValue %g must be positive", o.MaxValue)
+	case o.Epsilon <= 0:
+		return fmt.Errorf("epsilon %g must be positive", o.Epsilon)
+	case o.HalfLife <= 0:
+		return fmt.Errorf("halfLife %s must be positive", o.HalfLife)
+	case o.MinSampleWeight < 0:
+		return fmt.Errorf("minSampleWeight %g must not be negative", o.MinSampleWeight)
+	case o.BucketSizeGrowthRatio < 0:
+		return fmt.Errorf("bucketSizeGrowthRatio %g must not be negative", o.BucketSizeGrowthRatio)
+	case o.BucketSizeGrowthRatio == 0 && o.BucketSize <= 0:
+		return fmt.Errorf("bucketSize %g must be positive", o.BucketSize)
+	case o.BucketSizeGrowthRatio > 0 && o.FirstBucketSize <= 0:
+		return fmt.Errorf("firstBucketSize %g must be positive", o.FirstBucketSize)
+	}
+
+	var n float64
+	if o.BucketSizeGrowthRatio == 0 {
+		n = math.Ceil(o.MaxValue/o.BucketSize) + 1
+	} else {
+		n = math.Ceil(math.Log(o.MaxValue*o.BucketSizeGrowthRatio/o.FirstBucketSize+1)/math.Log(1+o.BucketSizeGrowthRatio)) + 1
+	}
+	if n > maxNumBuckets {
+		return fmt.Errorf("the histogram would have more than %d buckets, increase the bucket size", maxNumBuckets)
+	}
+	o.numBuckets = int(n)
+	return nil
+}
+
+// NumBuckets returns the number of buckets, the last one has no upper bound.
+func (o *Options) NumBuckets() int {
+	return o.numBuckets
+}
+
+// BucketStart returns the smallest value of the bucket.
+func (o *Options) BucketStart(bucket int) float64 {
+	if o.BucketSizeGrowthRatio == 0 {
+		return float64(bucket) * o.BucketSize
+	}
+	return o.FirstBucketSize * (math.Pow(1+o.BucketSizeGrowthRatio, float64(bucket)) - 1) / o.BucketSizeGrowthRatio
+}
+
+// FindBucket returns the bucket of the value, negative values fall into the first bucket.
+func (o *Options) FindBucket(value float64) int {
+	if value <= 0 || math.IsNaN(value) {
+		return 0
+	}
+	if value >= o.BucketStart(o.numBuckets-1) {
+		return o.numBuckets - 1
+	}
+	var bucket int
+	if o.BucketSizeGrowthRatio == 0 {
+		bucket = int(value / o.BucketSize)
+	} else {
+		bucket = int(math.Log(value*o.BucketSizeGrowthRatio/o.FirstBucketSize+1) / math.Log(1+o.BucketSizeGrowthRatio))
+	}
+	// the start of the buckets is subject to rounding errors, adjust to the exact bucket.
+	for bucket > 0 && bucket < o.numBuckets && o.BucketStart(bucket) > value {
+		bucket--
+	}
+	for bucket+1 < o.numBuckets && o.BucketStart(bucket+1) <= value {
+		bucket++
+	}
+	return bucket
+}
@@ -0,0 +1,97 @@
+package metricsource
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+var csvColumns = sets.NewString("metric", "query", "namespace", "pod", "container", "node", "labels", "timestamp", "value")
+
+// decodeCSV decodes the csv format of a FileSource, the rows of the same series are gathered in the order of
+// their first row.
+func decodeCSV(data []byte) ([]FileSeries, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the header: %v", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !csvColumns.Has(name) {
+			return nil, fmt.Errorf("unknown column %q, the columns are %s", name, strings.Join(csvColumns.List(), ", "))
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"timestamp", "value"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("the %s column is required", required)
+		}
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	positions := make(map[string]int)
+	var series []FileSeries
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return series, nil
+			}
+			return nil, err
+		}
+		fs := FileSeries{
+			Metric:    field(record, "metric"),
+			Query:     field(record, "query"),
+			Namespace: field(record, "namespace"),
+			Pod:       field(record, "pod"),
+			Container: field(record, "container"),
+			Node:      field(record, "node"),
+		}
+		if fs.Metric == "" && fs.Query == "" {
+			return nil, fmt.Errorf("line %d: either metric or query is required", line)
+		}
+		if value := field(record, "labels"); value != "" {
+			set, err := labels.ConvertSelectorToLabelsMap(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid labels %q: %v", line, value, err)
+			}
+			fs.Labels = set
+		}
+		timestamp, err := strconv.ParseInt(field(record, "timestamp"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid timestamp: %v", line, err)
+		}
+		value, err := helper.ParseValue(v1alpha1.ResourceName(fs.Metric), field(record, "value"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+
+		key := strings.Join([]string{fs.Metric, fs.Query, fs.Namespace, fs.Pod, fs.Container, fs.Node, labels.Set(fs.Labels).String()}, "\x00")
+		position, ok := positions[key]
+		if !ok {
+			position = len(series)
+			positions[key] = position
+			series = append(series, fs)
+		}
+		series[position].Samples = append(series[position].Samples, helper.Sample{Timestamp: timestamp, Value: value})
+	}
+}
@@ -0,0 +1,222 @@
+package metricsource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// FileSeries is a series of a metric stored in the file of a FileSource. A series is either the resource or
+// external metric named Metric, measured on its target and identified by its labels, or the result of the
+// Prometheus Query, with its variables expanded, and no target.
+type FileSeries struct {
+	Metric    string            `json:"metric,omitempty"`
+	Query     string            `json:"query,omitempty"`
+	Namespace string            `json:"namespace,omitempty"`
+	Pod       string            `json:"pod,omitempty"`
+	Container string            `json:"container,omitempty"`
+	Node      string            `json:"node,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	// Samples are the samples of the series, their timestamp is a unix timestamp in seconds.
+	Samples []helper.Sample `json:"samples"`
+}
+
+func (fs *FileSeries) target() Target {
+	return Target{Namespace: fs.Namespace, Pod: fs.Pod, Container: fs.Container, Node: fs.Node}
+}
+
+// indexedSeries is a FileSeries whose samples are sorted, the samples of the series of the same identity in
+// the file are merged.
+type indexedSeries struct {
+	metric  string
+	query   string
+	target  Target
+	labels  labels.Set
+	samples timeseries.Series
+}
+
+// FileSource is a MetricSource backed by a file holding a list of FileSeries, in json, e.g.
+//
+//	[{"metric": "cpu", "namespace": "default", "pod": "web-0", "container": "web",
+//	  "samples": [{"timestamp": 1634860800, "value": 250}]}]
+//
+// or in csv for a file with the .csv extension, with a header naming the columns among metric, query,
+// namespace, pod, container, node, labels, timestamp and value, every row being a sample, e.g.
+//
+//	metric,namespace,pod,container,timestamp,value
+//	cpu,default,web-0,web,1634860800,250m
+//
+// The labels column holds comma separated key=value pairs and the values may be quantities such as 250m for
+// cpu. The file is read again whenever its modification time changes.
+//
+// A resource metric of a target is the series of the resource with that target, a Prometheus query is the
+// series of the query with its variables expanded for the target, and an external metric is the sum of the
+// series of that name whose labels match its selector and whose target is either empty or only the namespace
+// of the target.
+type FileSource struct {
+	path string
+
+	lock    sync.RWMutex
+	modTime time.Time
+	series  []indexedSeries
+}
+
+var _ MetricSource = &FileSource{}
+
+// NewFileSource returns a FileSource reading the given file, which must exist and be valid.
+func NewFileSource(path string) (*FileSource, error) {
+	s := &FileSource{path: path}
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewFileSourceFromSeries returns a FileSource serving the given series and never reading any file.
+func NewFileSourceFromSeries(series []FileSeries) *FileSource {
+	return &FileSource{series: indexSeries(series)}
+}
+
+// QueryRange implements MetricSource.
+func (s *FileSource) QueryRange(_ context.Context, spec *v1alpha1.MetricSourceSpec, target Target, start, end time.Time, step time.Duration) (timeseries.Series, error) {
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+	match, err := matcher(spec, target)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.RLock()
+	var matched []timeseries.Series
+	for i := range s.series {
+		if match(&s.series[i]) {
+			matched = append(matched, s.series[i].samples)
+		}
+	}
+	s.lock.RUnlock()
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("%w: %s of %s", ErrNotFound, Describe(spec), target)
+	}
+
+	if len(matched) == 1 {
+		return resample(matched[0], start, end, step)
+	}
+	// the series are resampled before they are summed, so that the samples of a step share their timestamp.
+	values := make(map[int64]float64)
+	for _, series := range matched {
+		resampled, err := resample(series, start, end, step)
+		if err != nil {
+			return nil, err
+		}
+		for _, sample := range resampled {
+			values[sample.Timestamp] += sample.Value
+		}
+	}
+	summed := make([]helper.Sample, 0, len(values))
+	for t, v := range values {
+		summed = append(summed, helper.Sample{Timestamp: t, Value: v})
+	}
+	return timeseries.New(summed), nil
+}
+
+// matcher returns whether an indexed series is the metric of the spec for the target.
+func matcher(spec *v1alpha1.MetricSourceSpec, target Target) (func(*indexedSeries) bool, error) {
+	switch {
+	case spec.Type == v1alpha1.ResourceMetricSourceType && spec.Resource != nil:
+		name := string(spec.Resource.Name)
+		return func(is *indexedSeries) bool {
+			return is.query == "" && is.metric == name && is.target == target
+		}, nil
+	case spec.Type == v1alpha1.PrometheusMetricSourceType && spec.Prometheus != nil:
+		query := strings.TrimSpace(ExpandQuery(spec.Prometheus.Query, target))
+		return func(is *indexedSeries) bool {
+			return is.query == query
+		}, nil
+	case spec.Type == v1alpha1.ExternalMetricSourceType && spec.External != nil:
+		selector := labels.Everything()
+		if spec.External.Selector != nil {
+			var err error
+			if selector, err = metav1.LabelSelectorAsSelector(spec.External.Selector); err != nil {
+				return nil, err
+			}
+		}
+		name := spec.External.MetricName
+		return func(is *indexedSeries) bool {
+			return is.query == "" && is.metric == name && selector.Matches(is.labels) &&
+				(is.target == Target{} || is.target == Target{Namespace: target.Namespace})
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported metric source: %s", Describe(spec))
+}
+
+func (s *FileSource) reload() error {
+	if s.path == "" {
+		return nil
+	}
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return err
+	}
+	s.lock.RLock()
+	upToDate := info.ModTime().Equal(s.modTime)
+	s.lock.RUnlock()
+	if upToDate {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return err
+	}
+	var series []FileSeries
+	if strings.EqualFold(filepath.Ext(s.path), ".csv") {
+		series, err = decodeCSV(data)
+	} else {
+		err = json.Unmarshal(data, &series)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to decode %s: %v", s.path, err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.series = indexSeries(series)
+	s.modTime = info.ModTime()
+	return nil
+}
+
+func indexSeries(series []FileSeries) []indexedSeries {
+	type key struct {
+		metric, query, labels string
+		target                Target
+	}
+	positions := make(map[key]int, len(series))
+	var index []indexedSeries
+	for i := range series {
+		fs := &series[i]
+		set := labels.Set(fs.Labels)
+		k := key{metric: fs.Metric, query: strings.TrimSpace(fs.Query), labels: set.String(), target: fs.target()}
+		position, ok := positions[k]
+		if !ok {
+			position = len(index)
+			positions[k] = position
+			index = append(index, indexedSeries{metric: k.metric, query: k.query, target: k.target, labels: set})
+		}
+		index[position].samples = timeseries.New(append(index[position].samples, fs.Samples...))
+	}
+	return index
+}
@@ -0,0 +1,227 @@
+package metricsource
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+const fixtureStart = int64(1634860800)
+
+var web = Target{Namespace: "default", Pod: "web-0", Container: "web"}
+
+func resourceSpec(name v1alpha1.ResourceName) *v1alpha1.MetricSourceSpec {
+	return &v1alpha1.MetricSourceSpec{Type: v1alpha1.ResourceMetricSourceType, Resource: &v1alpha1.ResourceMetricSource{Name: name}}
+}
+
+func externalSpec(name string, matchLabels map[string]string) *v1alpha1.MetricSourceSpec {
+	external := &v1alpha1.ExternalMetricSource{MetricName: name}
+	if matchLabels != nil {
+		external.Selector = &metav1.LabelSelector{MatchLabels: matchLabels}
+	}
+	return &v1alpha1.MetricSourceSpec{Type: v1alpha1.ExternalMetricSourceType, External: external}
+}
+
+// samples returns the series of the values every minute from the start of the fixtures.
+func samples(values ...float64) timeseries.Series {
+	s := make(timeseries.Series, 0, len(values))
+	for i, v := range values {
+		s = append(s, helper.Sample{Timestamp: fixtureStart + int64(i)*60, Value: v})
+	}
+	return s
+}
+
+func TestFileSource(t *testing.T) {
+	start, end := time.Unix(fixtureStart, 0), time.Unix(fixtureStart+180, 0)
+	cases := []struct {
+		name     string
+		spec     *v1alpha1.MetricSourceSpec
+		target   Target
+		step     time.Duration
+		expected timeseries.Series
+		err      error
+	}{
+		{name: "resource", spec: resourceSpec(v1alpha1.ResourceCPU), target: web, expected: samples(250, 300, 200)},
+		{name: "resource quantity", spec: resourceSpec(v1alpha1.ResourceMemory), target: web, expected: samples(1 << 30)},
+		{name: "resampled", spec: resourceSpec(v1alpha1.ResourceCPU), target: web, step: 2 * time.Minute, expected: timeseries.Series{{Timestamp: fixtureStart, Value: 300}, {Timestamp: fixtureStart + 120, Value: 200}}},
+		{name: "resource of another target", spec: resourceSpec(v1alpha1.ResourceCPU), target: Target{Namespace: "default", Pod: "web-1", Container: "web"}, err: ErrNotFound},
+		{
+			name:     "expanded query",
+			spec:     &v1alpha1.MetricSourceSpec{Type: v1alpha1.PrometheusMetricSourceType, Prometheus: &v1alpha1.PrometheusMetricSource{Query: ` sum(rate(http_requests_total{namespace="${namespace}",pod="${pod}"}[5m])) `}},
+			target:   web,
+			expected: samples(10, 12),
+		},
+		{name: "external sum of the selected series", spec: externalSpec("queue_length", map[string]string{"queue": "orders"}), target: web, expected: samples(6, 8)},
+		{name: "external sum of every series", spec: externalSpec("queue_length", nil), target: web, expected: samples(56, 8)},
+		{name: "external of another namespace", spec: externalSpec("queue_length", map[string]string{"queue": "orders"}), target: Target{Namespace: "other"}, expected: samples(105, 7)},
+		{name: "external of a node", spec: externalSpec("queue_length", map[string]string{"queue": "payments"}), target: Target{Node: "worker-1"}, expected: samples(50)},
+		{name: "external without a match", spec: externalSpec("queue_length", map[string]string{"queue": "missing"}), target: web, err: ErrNotFound},
+	}
+	for _, file := range []string{"metrics.json", "metrics.csv"} {
+		source, err := NewFileSource(filepath.Join("testdata", file))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", file, err)
+		}
+		for _, c := range cases {
+			got, err := source.QueryRange(context.Background(), c.spec, c.target, start, end, c.step)
+			switch {
+			case c.err != nil && !errors.Is(err, c.err):
+				t.Errorf("%s, %s: expected the error %v, got %v", file, c.name, c.err, err)
+			case c.err == nil && err != nil:
+				t.Errorf("%s, %s: unexpected error: %v", file, c.name, err)
+			case c.err == nil && !reflect.DeepEqual(got, c.expected):
+				t.Errorf("%s, %s: expected %v, got %v", file, c.name, c.expected, got)
+			}
+		}
+	}
+}
+
+func TestFileSourceWindow(t *testing.T) {
+	source, err := NewFileSource(filepath.Join("testdata", "metrics.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := source.QueryRange(context.Background(), resourceSpec(v1alpha1.ResourceCPU), web, time.Unix(fixtureStart+60, 0), time.Unix(fixtureStart+120, 0), 0)
+	if expected := (timeseries.Series{{Timestamp: fixtureStart + 60, Value: 300}}); err != nil || !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v, %v", expected, got, err)
+	}
+	if _, err := source.QueryRange(context.Background(), &v1alpha1.MetricSourceSpec{Type: v1alpha1.PrometheusMetricSourceType}, web, time.Unix(fixtureStart, 0), time.Unix(fixtureStart+60, 0), 0); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("expected an unsupported source, got %v", err)
+	}
+}
+
+func TestFileSourceReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "metricsource")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "metrics.csv")
+	write := func(value string, modTime time.Time) {
+		data := "metric,namespace,pod,container,timestamp,value\ncpu,default,web-0,web,1634860800," + value + "\n"
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	query := func(source *FileSource) float64 {
+		s, err := source.QueryRange(context.Background(), resourceSpec(v1alpha1.ResourceCPU), web, time.Unix(fixtureStart, 0), time.Unix(fixtureStart+60, 0), 0)
+		if err != nil || len(s) != 1 {
+			t.Fatalf("expected a sample, got %v, %v", s, err)
+		}
+		return s[0].Value
+	}
+
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	write("250m", modTime)
+	source, err := NewFileSource(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value := query(source); value != 250 {
+		t.Errorf("expected 250, got %g", value)
+	}
+
+	write("300m", modTime)
+	if value := query(source); value != 250 {
+		t.Errorf("expected the file not to be read again without a new modification time, got %g", value)
+	}
+	write("300m", modTime.Add(time.Minute))
+	if value := query(source); value != 300 {
+		t.Errorf("expected the file to be read again, got %g", value)
+	}
+
+	write("invalid", modTime.Add(2*time.Minute))
+	if _, err := source.QueryRange(context.Background(), resourceSpec(v1alpha1.ResourceCPU), web, time.Unix(fixtureStart, 0), time.Unix(fixtureStart+60, 0), 0); err == nil {
+		t.Errorf("expected an error for an invalid file")
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := NewFileSource(path); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
+
+func TestDecodeCSV(t *testing.T) {
+	series, err := decodeCSV([]byte("metric, labels, timestamp, value\nrequests, \"a=1,b=2\", 60, 1.5\nrequests, \"a=1,b=2\", 0, 2\nrequests, a=2, 0, 3\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []FileSeries{
+		{Metric: "requests", Labels: map[string]string{"a": "1", "b": "2"}, Samples: []helper.Sample{{Timestamp: 60, Value: 1.5}, {Timestamp: 0, Value: 2}}},
+		{Metric: "requests", Labels: map[string]string{"a": "2"}, Samples: []helper.Sample{{Timestamp: 0, Value: 3}}},
+	}
+	if !reflect.DeepEqual(series, expected) {
+		t.Errorf("expected %v, got %v", expected, series)
+	}
+
+	cases := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{name: "empty", data: "", err: "failed to read the header"},
+		{name: "unknown column", data: "metric,timestamp,value,unit\n", err: `unknown column "unit"`},
+		{name: "missing timestamp", data: "metric,value\n", err: "the timestamp column is required"},
+		{name: "missing value", data: "metric,timestamp\n", err: "the value column is required"},
+		{name: "no metric nor query", data: "namespace,timestamp,value\ndefault,0,1\n", err: "line 2: either metric or query is required"},
+		{name: "invalid labels", data: "metric,labels,timestamp,value\nrequests,a,0,1\n", err: "line 2: invalid labels"},
+		{name: "invalid timestamp", data: "metric,timestamp,value\nrequests,0,1\nrequests,1.5,1\n", err: "line 3: invalid timestamp"},
+		{name: "invalid value", data: "metric,timestamp,value\ncpu,0,1x\n", err: "line 2:"},
+		{name: "wrong number of fields", data: "metric,timestamp,value\nrequests,0\n", err: "wrong number of fields"},
+	}
+	for _, c := range cases {
+		if _, err := decodeCSV([]byte(c.data)); err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s: expected an error containing %q, got %v", c.name, c.err, err)
+		}
+	}
+}
+
+func TestExpandQuery(t *testing.T) {
+	cases := []struct {
+		query    string
+		target   Target
+		expected string
+	}{
+		{
+			query:    `container_memory_working_set_bytes{namespace="${namespace}",pod="${pod}",container="${container}"}`,
+			target:   web,
+			expected: `container_memory_working_set_bytes{namespace="default",pod="web-0",container="web"}`,
+		},
+		{
+			query:    `node_load1{instance="${node}"} + on() ${node_offset}`,
+			target:   Target{Node: "worker-1"},
+			expected: `node_load1{instance="worker-1"} + on() ${node_offset}`,
+		},
+		{
+			query:    `up{pod="${pod}",node="${node}"} or up{pod="${pod}"}`,
+			target:   Target{Namespace: "default"},
+			expected: `up{pod="",node=""} or up{pod=""}`,
+		},
+		{
+			query:    `sum(up)`,
+			target:   web,
+			expected: `sum(up)`,
+		},
+	}
+	for _, c := range cases {
+		if got := ExpandQuery(c.query, c.target); got != c.expected {
+			t.Errorf("ExpandQuery(%q): expected %q, got %q", c.query, c.expected, got)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Package metricsource provides the history of the metrics the predictions are computed from.
+package metricsource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gocrane-io/api/pkg/timeseries"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+)
+
+// ErrNotFound is returned when a source has no data for the queried metric of a target.
+var ErrNotFound = errors.New("metric not found")
+
+// Target identifies what a metric is measured on: a container of a pod, or a node.
+type Target struct {
+	Namespace string
+	Pod       string
+	Container string
+	Node      string
+}
+
+// String returns namespace/pod/container for a container target, the node name for a node target.
+func (t Target) String() string {
+	if t.Node != "" {
+		return t.Node
+	}
+	return fmt.Sprintf("%s/%s/%s", t.Namespace, t.Pod, t.Container)
+}
+
+// MetricSource queries the history of a metric. The values follow the units of the prediction API:
+// ResourceCPU is in milli cores, ResourceMemory in bytes, other metrics are plain numbers.
+type MetricSource interface {
+	// QueryRange returns the samples of the metric of the spec for the target whose timestamp is in
+	// [start, end), resampled to step if step is positive. It returns ErrNotFound if there is no such metric.
+	QueryRange(ctx context.Context, spec *v1alpha1.MetricSourceSpec, target Target, start, end time.Time, step time.Duration) (timeseries.Series, error)
+}
+
+// SpecOf returns the source of the metric of a config. Without a source, cpu and memory are the resource
+// metrics of the target and any other metric name is an external metric without selector.
+func SpecOf(config *v1alpha1.AlgorithmProviderConfig) *v1alpha1.MetricSourceSpec {
+	if config.Source != nil {
+		return config.Source
+	}
+	switch name := v1alpha1.ResourceName(config.MetricName); name {
+	case v1alpha1.ResourceCPU, v1alpha1.ResourceMemory:
+		return &v1alpha1.MetricSourceSpec{Type: v1alpha1.ResourceMetricSourceType, Resource: &v1alpha1.ResourceMetricSource{Name: name}}
+	}
+	return &v1alpha1.MetricSourceSpec{Type: v1alpha1.ExternalMetricSourceType, External: &v1alpha1.ExternalMetricSource{MetricName: config.MetricName}}
+}
+
+// ExpandQuery replaces the ${namespace}, ${pod}, ${container} and ${node} variables of a Prometheus query with
+// the target.
+func ExpandQuery(query string, target Target) string {
+	return strings.NewReplacer(
+		"${namespace}", target.Namespace,
+		"${pod}", target.Pod,
+		"${container}", target.Container,
+		"${node}", target.Node,
+	).Replace(query)
+}
+
+// Describe returns a short description of the metric of a spec for messages, for example resource cpu.
+func Describe(spec *v1alpha1.MetricSourceSpec) string {
+	switch {
+	case spec.Type == v1alpha1.ResourceMetricSourceType && spec.Resource != nil:
+		return fmt.Sprintf("resource %s", spec.Resource.Name)
+	case spec.Type == v1alpha1.PrometheusMetricSourceType && spec.Prometheus != nil:
+		return fmt.Sprintf("query %q", spec.Prometheus.Query)
+	case spec.Type == v1alpha1.ExternalMetricSourceType && spec.External != nil:
+		if spec.External.Selector == nil {
+			return fmt.Sprintf("external metric %s", spec.External.MetricName)
+		}
+		return fmt.Sprintf("external metric %s{%s}", spec.External.MetricName, metav1.FormatLabelSelector(spec.External.Selector))
+	}
+	return fmt.Sprintf("invalid source of type %q", spec.Type)
+}
+
+// resample keeps the last sample of every step of the window [start, end), it returns the window as is for a
+// step shorter than a second.
+func resample(s timeseries.Series, start, end time.Time, step time.Duration) (timeseries.Series, error) {
+	s = s.Window(start.Unix(), end.Unix())
+	if step < time.Second || len(s) == 0 {
+		return s, nil
+	}
+	return s.Resample(int64(step/time.Second), timeseries.AggregateLast)
+}
@@ -0,0 +1,306 @@
+// Package migration migrates the prediction resources installed by a former version of the API whose schema
+// cannot be changed in place.
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/validation"
+)
+
+const (
+	// NodePredictionCRDName is the name of the CustomResourceDefinition of the NodePredictions.
+	NodePredictionCRDName = "nodepredictions.prediction.crane.io"
+	// dryRunGroup is the group of the temporary definition the NodePredictions are dry run against before the
+	// namespaced definition is deleted.
+	dryRunGroup = "migration.prediction.crane.io"
+)
+
+var (
+	// nodePredictionResource is the resource of the NodePredictions in their storage version.
+	nodePredictionResource = v1alpha1.SchemeGroupVersion.WithResource("nodepredictions")
+	// dryRunResource is the resource of the NodePredictions in the temporary definition.
+	dryRunResource = schema.GroupVersionResource{Group: dryRunGroup, Version: nodePredictionResource.Version, Resource: nodePredictionResource.Resource}
+
+	// scheme defaults the NodePredictions before they are validated, as the admission webhook does.
+	scheme = runtime.NewScheme()
+)
+
+func init() {
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+}
+
+// NodePredictionMigrator replaces a namespaced NodePrediction CustomResourceDefinition with the cluster scoped one.
+// The scope of a CustomResourceDefinition is immutable, so the migrator saves the NodePredictions, deletes the
+// definition with all of them, creates the cluster scoped definition and recreates the NodePredictions in it.
+type NodePredictionMigrator struct {
+	crdClient     apiextensionsclient.Interface
+	dynamicClient dynamic.Interface
+	// Interval and Timeout are the interval and the timeout of the waits for the deletion and the establishment of
+	// the definitions.
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+// NewNodePredictionMigrator returns a migrator using the clients.
+func NewNodePredictionMigrator(crdClient apiextensionsclient.Interface, dynamicClient dynamic.Interface) *NodePredictionMigrator {
+	return &NodePredictionMigrator{
+		crdClient:     crdClient,
+		dynamicClient: dynamicClient,
+		Interval:      time.Second,
+		Timeout:       5 * time.Minute,
+	}
+}
+
+// ErrNamespacedNodePrediction is returned by CheckNodePredictionScope when the installed NodePredictions are
+// namespaced.
+var ErrNamespacedNodePrediction = errors.New("the installed NodePredictions are namespaced, migrate them with prediction-migrate")
+
+// CheckNodePredictionScope returns ErrNamespacedNodePrediction when the installed NodePrediction definition is the
+// namespaced one of a former version. The clients of this version address the NodePredictions at the cluster
+// scope and would fail on every update of such a definition, they check it before they start.
+func CheckNodePredictionScope(ctx context.Context, crdClient apiextensionsclient.Interface) error {
+	installed, err := crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, NodePredictionCRDName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %v", NodePredictionCRDName, err)
+	}
+	if installed.Spec.Scope != apiextensionsv1.ClusterScoped {
+		return ErrNamespacedNodePrediction
+	}
+	return nil
+}
+
+// Migrate replaces the installed namespaced definition with the given cluster scoped one. Before anything is
+// deleted, the converted NodePredictions are validated as the admission webhook does and dry run against a
+// temporary copy of the cluster scoped definition in another group, which checks them against its schema, and
+// they are written to the backup as a list: nothing is changed when one of them cannot be migrated. Migrate does
+// nothing when the installed definition is already cluster scoped, and only creates the definition when none is
+// installed.
+func (m *NodePredictionMigrator) Migrate(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition, backup io.Writer) error {
+	if crd.Name != NodePredictionCRDName || crd.Spec.Scope != apiextensionsv1.ClusterScoped {
+		return fmt.Errorf("%s is not the cluster scoped definition %s", crd.Name, NodePredictionCRDName)
+	}
+	installed, err := m.crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, NodePredictionCRDName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		klog.Infof("No %s is installed, creating it", NodePredictionCRDName)
+		return m.createDefinition(ctx, crd)
+	}
+	if err != nil {
+		return err
+	}
+	if installed.Spec.Scope == apiextensionsv1.ClusterScoped {
+		klog.Infof("%s is already cluster scoped", NodePredictionCRDName)
+		return nil
+	}
+
+	list, err := m.dynamicClient.Resource(nodePredictionResource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list the NodePredictions: %v", err)
+	}
+	migrated, err := ConvertNodePredictions(list.Items)
+	if err != nil {
+		return err
+	}
+	if err := ValidateNodePredictions(migrated); err != nil {
+		return err
+	}
+	if err := m.dryRun(ctx, crd, migrated); err != nil {
+		return err
+	}
+	if err := json.NewEncoder(backup).Encode(list); err != nil {
+		return fmt.Errorf("failed to back up the NodePredictions: %v", err)
+	}
+
+	klog.Infof("Deleting the namespaced %s and its %d NodePredictions", NodePredictionCRDName, len(list.Items))
+	if err := m.deleteDefinition(ctx, NodePredictionCRDName); err != nil {
+		return err
+	}
+	if err := m.createDefinition(ctx, crd); err != nil {
+		return err
+	}
+	var errs []string
+	for _, np := range migrated {
+		if _, err := m.dynamicClient.Resource(nodePredictionResource).Create(ctx, np, metav1.CreateOptions{}); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", np.GetName(), err))
+			continue
+		}
+		klog.Infof("Recreated NodePrediction %s of node %s", np.GetName(), nodeName(np))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to recreate NodePredictions, they are in the backup: %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// dryRun creates a temporary copy of the cluster scoped definition in the dryRunGroup and dry runs the creation of
+// the NodePredictions in it, the copy is deleted afterwards. The scope of the installed definition cannot be
+// changed, so this is the only way to have the API server check the NodePredictions against the cluster scoped
+// schema before the namespaced definition is deleted.
+func (m *NodePredictionMigrator) dryRun(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition, nps []*unstructured.Unstructured) (err error) {
+	temporary := crd.DeepCopy()
+	temporary.ObjectMeta = metav1.ObjectMeta{Name: crd.Spec.Names.Plural + "." + dryRunGroup}
+	temporary.Spec.Group = dryRunGroup
+	// the conversion webhook only serves the prediction group, the temporary definition has no conversion.
+	temporary.Spec.Conversion = nil
+	temporary.Status = apiextensionsv1.CustomResourceDefinitionStatus{}
+	if err := m.createDefinition(ctx, temporary); err != nil {
+		return err
+	}
+	defer func() {
+		if deleteErr := m.deleteDefinition(ctx, temporary.Name); deleteErr != nil && err == nil {
+			err = deleteErr
+		}
+	}()
+
+	var errs []string
+	for _, np := range nps {
+		np = np.DeepCopy()
+		np.SetAPIVersion(dryRunResource.GroupVersion().String())
+		_, err := m.dynamicClient.Resource(dryRunResource).Create(ctx, np, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", np.GetName(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("the NodePredictions are not valid in the cluster scoped definition: %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func (m *NodePredictionMigrator) deleteDefinition(ctx context.Context, name string) error {
+	crds := m.crdClient.ApiextensionsV1().CustomResourceDefinitions()
+	if err := crds.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	err := wait.PollImmediate(m.Interval, m.Timeout, func() (bool, error) {
+		_, err := crds.Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to wait for the deletion of %s: %v", name, err)
+	}
+	return nil
+}
+
+func (m *NodePredictionMigrator) createDefinition(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition) error {
+	crds := m.crdClient.ApiextensionsV1().CustomResourceDefinitions()
+	if _, err := crds.Create(ctx, crd, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create %s: %v", crd.Name, err)
+	}
+	err := wait.PollImmediate(m.Interval, m.Timeout, func() (bool, error) {
+		created, err := crds.Get(ctx, crd.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, condition := range created.Status.Conditions {
+			if condition.Type == apiextensionsv1.Established && condition.Status == apiextensionsv1.ConditionTrue {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to wait for %s to be established: %v", crd.Name, err)
+	}
+	return nil
+}
+
+// ConvertNodePredictions converts namespaced NodePredictions into cluster scoped ones. A NodePrediction keeps its
+// name, labels, annotations and spec, and is associated with the node of its name unless its spec has a node
+// name. The status is left to the controller. The NodePredictions of the same name in different namespaces, and
+// those with finalizers, which would block the deletion of the definition, cannot be converted.
+func ConvertNodePredictions(nps []unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	namespaces := make(map[string][]string, len(nps))
+	var errs []string
+	for _, np := range nps {
+		namespaces[np.GetName()] = append(namespaces[np.GetName()], np.GetNamespace())
+		if len(np.GetFinalizers()) > 0 {
+			errs = append(errs, fmt.Sprintf("%s/%s has the finalizers %v", np.GetNamespace(), np.GetName(), np.GetFinalizers()))
+		}
+	}
+	for name, ns := range namespaces {
+		if len(ns) > 1 {
+			sort.Strings(ns)
+			errs = append(errs, fmt.Sprintf("%s is in the namespaces %v", name, ns))
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return nil, fmt.Errorf("cannot migrate the NodePredictions: %s", strings.Join(errs, ", "))
+	}
+
+	migrated := make([]*unstructured.Unstructured, 0, len(nps))
+	for _, np := range nps {
+		out := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		out.SetAPIVersion(np.GetAPIVersion())
+		out.SetKind(np.GetKind())
+		out.SetName(np.GetName())
+		out.SetLabels(np.GetLabels())
+		out.SetAnnotations(np.GetAnnotations())
+		spec, _, err := unstructured.NestedMap(np.Object, "spec")
+		if err != nil {
+			return nil, fmt.Errorf("invalid spec of %s/%s: %v", np.GetNamespace(), np.GetName(), err)
+		}
+		if spec == nil {
+			spec = map[string]interface{}{}
+		}
+		if name, _ := spec["nodeName"].(string); name == "" {
+			spec["nodeName"] = np.GetName()
+		}
+		out.Object["spec"] = spec
+		migrated = append(migrated, out)
+	}
+	sort.Slice(migrated, func(i, j int) bool {
+		return migrated[i].GetName() < migrated[j].GetName()
+	})
+	return migrated, nil
+}
+
+// ValidateNodePredictions validates the converted NodePredictions as the admission webhook does: they are
+// defaulted, and validated with ValidateNodePrediction.
+func ValidateNodePredictions(nps []*unstructured.Unstructured) error {
+	var errs []string
+	for _, u := range nps {
+		np := &v1alpha1.NodePrediction{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, np); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", u.GetName(), err))
+			continue
+		}
+		scheme.Default(np)
+		if allErrs := validation.ValidateNodePrediction(np); len(allErrs) > 0 {
+			errs = append(errs, fmt.Sprintf("%s: %v", u.GetName(), allErrs.ToAggregate()))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("the NodePredictions are not valid: %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func nodeName(np *unstructured.Unstructured) string {
+	name, _, _ := unstructured.NestedString(np.Object, "spec", "nodeName")
+	return name
+}
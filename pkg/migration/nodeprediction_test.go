@@ -0,0 +1,264 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func newNodePrediction(namespace, name, node string) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"mode":   "range",
+		"period": "1m",
+		"metricPredictionConfigs": []interface{}{
+			map[string]interface{}{"metricName": "cpu", "dsp": map[string]interface{}{}},
+		},
+	}
+	if node != "" {
+		spec["nodeName"] = node
+	}
+	np := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "prediction.crane.io/v1alpha1",
+		"kind":       "NodePrediction",
+		"spec":       spec,
+		"status":     map[string]interface{}{"status": "Predicting"},
+	}}
+	np.SetNamespace(namespace)
+	np.SetName(name)
+	np.SetUID(types.UID("uid-" + name))
+	np.SetResourceVersion("42")
+	np.SetLabels(map[string]string{"pool": "web"})
+	return np
+}
+
+func newCRD(scope apiextensionsv1.ResourceScope) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: NodePredictionCRDName},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "prediction.crane.io",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "nodepredictions", Kind: "NodePrediction"},
+			Scope: scope,
+		},
+	}
+}
+
+func TestConvertNodePredictions(t *testing.T) {
+	migrated, err := ConvertNodePredictions([]unstructured.Unstructured{
+		*newNodePrediction("default", "node-b", ""),
+		*newNodePrediction("kube-system", "node-a", "worker-1"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(migrated) != 2 || migrated[0].GetName() != "node-a" || migrated[1].GetName() != "node-b" {
+		t.Fatalf("expected node-a and node-b, got %v", migrated)
+	}
+	for _, np := range migrated {
+		if np.GetNamespace() != "" || np.GetUID() != "" || np.GetResourceVersion() != "" {
+			t.Errorf("expected a new cluster scoped object, got %v", np.Object["metadata"])
+		}
+		if np.GetLabels()["pool"] != "web" {
+			t.Errorf("expected the labels to be kept, got %v", np.GetLabels())
+		}
+		if _, ok := np.Object["status"]; ok {
+			t.Errorf("expected no status, got %v", np.Object["status"])
+		}
+		if mode, _, _ := unstructured.NestedString(np.Object, "spec", "mode"); mode != "range" {
+			t.Errorf("expected the spec to be kept, got %v", np.Object["spec"])
+		}
+	}
+	if node := nodeName(migrated[0]); node != "worker-1" {
+		t.Errorf("expected the node name of the spec, got %s", node)
+	}
+	if node := nodeName(migrated[1]); node != "node-b" {
+		t.Errorf("expected the node name to default to the name, got %s", node)
+	}
+}
+
+func TestConvertNodePredictionsRejectsConflicts(t *testing.T) {
+	finalized := newNodePrediction("default", "node-c", "")
+	finalized.SetFinalizers([]string{"example.com/cleanup"})
+	_, err := ConvertNodePredictions([]unstructured.Unstructured{
+		*newNodePrediction("default", "node-a", ""),
+		*newNodePrediction("other", "node-a", ""),
+		*finalized,
+	})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	for _, msg := range []string{"node-a is in the namespaces [default other]", "default/node-c has the finalizers"} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Errorf("expected the error to contain %q, got %v", msg, err)
+		}
+	}
+}
+
+func newMigrator(crdClient *apiextensionsfake.Clientset, objects ...runtime.Object) (*NodePredictionMigrator, *dynamicfake.FakeDynamicClient) {
+	// the fake client establishes the created definitions right away.
+	crdClient.PrependReactor("create", "customresourcedefinitions", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		crd := action.(clienttesting.CreateAction).GetObject().(*apiextensionsv1.CustomResourceDefinition)
+		crd.Status.Conditions = []apiextensionsv1.CustomResourceDefinitionCondition{
+			{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionTrue},
+		}
+		return false, nil, nil
+	})
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{nodePredictionResource: "NodePredictionList"}, objects...)
+	m := NewNodePredictionMigrator(crdClient, dynamicClient)
+	m.Interval, m.Timeout = time.Millisecond, time.Second
+	return m, dynamicClient
+}
+
+func TestMigrate(t *testing.T) {
+	crdClient := apiextensionsfake.NewSimpleClientset(newCRD(apiextensionsv1.NamespaceScoped))
+	m, dynamicClient := newMigrator(crdClient, newNodePrediction("default", "node-a", ""))
+
+	var backup bytes.Buffer
+	if err := m.Migrate(context.Background(), newCRD(apiextensionsv1.ClusterScoped), &backup); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	crd, err := crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.Background(), NodePredictionCRDName, metav1.GetOptions{})
+	if err != nil || crd.Spec.Scope != apiextensionsv1.ClusterScoped {
+		t.Errorf("expected the cluster scoped definition, got %v, %v", crd, err)
+	}
+	np, err := dynamicClient.Resource(nodePredictionResource).Get(context.Background(), "node-a", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the recreated NodePrediction: %v", err)
+	}
+	if np.GetNamespace() != "" || nodeName(np) != "node-a" {
+		t.Errorf("expected the cluster scoped NodePrediction of node-a, got %v", np.Object)
+	}
+	if _, err := crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.Background(), "nodepredictions."+dryRunGroup, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the temporary definition to be deleted, got %v", err)
+	}
+	saved := &unstructured.UnstructuredList{}
+	if err := json.Unmarshal(backup.Bytes(), saved); err != nil || len(saved.Items) != 1 || saved.Items[0].GetNamespace() != "default" {
+		t.Errorf("expected the backup of the namespaced NodePrediction, got %s, %v", backup.String(), err)
+	}
+}
+
+func TestMigrateLeavesDefinitionOnConflict(t *testing.T) {
+	crdClient := apiextensionsfake.NewSimpleClientset(newCRD(apiextensionsv1.NamespaceScoped))
+	m, _ := newMigrator(crdClient, newNodePrediction("default", "node-a", ""), newNodePrediction("other", "node-a", ""))
+
+	var backup bytes.Buffer
+	if err := m.Migrate(context.Background(), newCRD(apiextensionsv1.ClusterScoped), &backup); err == nil {
+		t.Fatalf("expected an error")
+	}
+	crd, err := crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.Background(), NodePredictionCRDName, metav1.GetOptions{})
+	if err != nil || crd.Spec.Scope != apiextensionsv1.NamespaceScoped {
+		t.Errorf("expected the namespaced definition to be left, got %v, %v", crd, err)
+	}
+}
+
+func TestValidateNodePredictions(t *testing.T) {
+	invalid := newNodePrediction("", "node-b", "")
+	unstructured.SetNestedField(invalid.Object, "forever", "spec", "mode")
+	unstructured.RemoveNestedField(invalid.Object, "metadata", "namespace")
+	migrated, err := ConvertNodePredictions([]unstructured.Unstructured{*newNodePrediction("default", "node-a", ""), *invalid})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = ValidateNodePredictions(migrated)
+	if err == nil || !strings.Contains(err.Error(), "node-b: spec.mode") || strings.Contains(err.Error(), "node-a") {
+		t.Errorf("expected node-b to be invalid, got %v", err)
+	}
+}
+
+func TestMigrateLeavesDefinitionOnInvalidNodePrediction(t *testing.T) {
+	crdClient := apiextensionsfake.NewSimpleClientset(newCRD(apiextensionsv1.NamespaceScoped))
+	invalid := newNodePrediction("default", "node-b", "")
+	unstructured.SetNestedField(invalid.Object, "forever", "spec", "mode")
+	m, dynamicClient := newMigrator(crdClient, newNodePrediction("default", "node-a", ""), invalid)
+
+	var backup bytes.Buffer
+	if err := m.Migrate(context.Background(), newCRD(apiextensionsv1.ClusterScoped), &backup); err == nil || !strings.Contains(err.Error(), "node-b") {
+		t.Fatalf("expected node-b to be invalid, got %v", err)
+	}
+	assertNothingChanged(t, crdClient, dynamicClient, &backup)
+}
+
+func TestMigrateLeavesDefinitionOnDryRunFailure(t *testing.T) {
+	crdClient := apiextensionsfake.NewSimpleClientset(newCRD(apiextensionsv1.NamespaceScoped))
+	m, dynamicClient := newMigrator(crdClient, newNodePrediction("default", "node-a", ""))
+	dynamicClient.PrependReactor("create", "nodepredictions", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		create := action.(clienttesting.CreateAction)
+		if create.GetResource().Group != dryRunGroup {
+			return false, nil, nil
+		}
+		return true, nil, apierrors.NewInvalid(schema.GroupKind{Group: dryRunGroup, Kind: "NodePrediction"}, "node-a", nil)
+	})
+
+	var backup bytes.Buffer
+	if err := m.Migrate(context.Background(), newCRD(apiextensionsv1.ClusterScoped), &backup); err == nil || !strings.Contains(err.Error(), "not valid in the cluster scoped definition") {
+		t.Fatalf("expected the dry run to fail, got %v", err)
+	}
+	assertNothingChanged(t, crdClient, dynamicClient, &backup)
+	if _, err := crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.Background(), "nodepredictions."+dryRunGroup, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the temporary definition to be deleted, got %v", err)
+	}
+}
+
+// assertNothingChanged checks that the namespaced definition and its NodePredictions are left and that nothing
+// was backed up.
+func assertNothingChanged(t *testing.T, crdClient *apiextensionsfake.Clientset, dynamicClient *dynamicfake.FakeDynamicClient, backup *bytes.Buffer) {
+	t.Helper()
+	crd, err := crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.Background(), NodePredictionCRDName, metav1.GetOptions{})
+	if err != nil || crd.Spec.Scope != apiextensionsv1.NamespaceScoped {
+		t.Errorf("expected the namespaced definition to be left, got %v, %v", crd, err)
+	}
+	if _, err := dynamicClient.Resource(nodePredictionResource).Namespace("default").Get(context.Background(), "node-a", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the NodePrediction to be left: %v", err)
+	}
+	if backup.Len() != 0 {
+		t.Errorf("expected no backup, got %s", backup.String())
+	}
+}
+
+func TestMigrateClusterScoped(t *testing.T) {
+	crdClient := apiextensionsfake.NewSimpleClientset(newCRD(apiextensionsv1.ClusterScoped))
+	m, _ := newMigrator(crdClient)
+	if err := m.Migrate(context.Background(), newCRD(apiextensionsv1.ClusterScoped), &bytes.Buffer{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, action := range crdClient.Actions() {
+		if action.GetVerb() != "get" {
+			t.Errorf("expected the definition to be left alone, got %s", action.GetVerb())
+		}
+	}
+}
+
+func TestCheckNodePredictionScope(t *testing.T) {
+	cases := []struct {
+		name    string
+		objects []runtime.Object
+		err     bool
+	}{
+		{name: "cluster scoped", objects: []runtime.Object{newCRD(apiextensionsv1.ClusterScoped)}},
+		{name: "namespaced", objects: []runtime.Object{newCRD(apiextensionsv1.NamespaceScoped)}, err: true},
+		{name: "not installed", err: true},
+	}
+	for _, c := range cases {
+		err := CheckNodePredictionScope(context.Background(), apiextensionsfake.NewSimpleClientset(c.objects...))
+		if c.err != (err != nil) {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+		}
+	}
+	if err := CheckNodePredictionScope(context.Background(), apiextensionsfake.NewSimpleClientset(newCRD(apiextensionsv1.NamespaceScoped))); err != ErrNamespacedNodePrediction {
+		t.Errorf("expected ErrNamespacedNodePrediction, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+// Package remotewrite pushes the forecast series of the predictions to a Prometheus remote write endpoint, with
+// their future timestamps, so that the whole predicted series are stored rather than the points scraped.
+package remotewrite
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/golang/snappy"
+)
+
+// maxErrorMessageLength is the length of the response body kept in the error of a failed write.
+const maxErrorMessageLength = 256
+
+// RecoverableError is returned by a write that failed but may succeed when retried, because the endpoint is
+// unreachable, overloaded or failed on its side.
+type RecoverableError struct {
+	error
+}
+
+// Unwrap returns the error of the write.
+func (e RecoverableError) Unwrap() error {
+	return e.error
+}
+
+// Writer stores the write requests.
+type Writer interface {
+	Store(ctx context.Context, req *WriteRequest) error
+}
+
+// Client is a Writer sending the requests to a remote write endpoint.
+type Client struct {
+	url        string
+	httpClient *http.Client
+}
+
+var _ Writer = &Client{}
+
+// NewClient returns a client of the endpoint at the url, http.DefaultClient is used for a nil httpClient.
+func NewClient(url string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{url: url, httpClient: httpClient}
+}
+
+// Store sends the request, snappy compressed, and returns a RecoverableError if it failed on the network, with
+// a server error or because of too many requests.
+func (c *Client) Store(ctx context.Context, req *WriteRequest) error {
+	body := snappy.Encode(nil, req.Marshal())
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Encoding", "snappy")
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	httpReq.Header.Set("User-Agent", "crane-prediction-remote-write")
+	httpReq.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return RecoverableError{err}
+	}
+	defer func() {
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+
+	scanner := bufio.NewScanner(io.LimitReader(resp.Body, maxErrorMessageLength))
+	message := ""
+	if scanner.Scan() {
+		message = scanner.Text()
+	}
+	err = fmt.Errorf("server returned HTTP status %s: %s", resp.Status, message)
+	if resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests {
+		return RecoverableError{err}
+	}
+	return err
+}
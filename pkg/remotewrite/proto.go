@@ -0,0 +1,81 @@
+package remotewrite
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// WriteRequest is the body of a remote write request, the prometheus.WriteRequest message of the remote write
+// protocol without its metadata.
+type WriteRequest struct {
+	Timeseries []TimeSeries
+}
+
+// TimeSeries is a series of samples identified by its labels, the __name__ label being its metric name.
+type TimeSeries struct {
+	Labels  []Label
+	Samples []Sample
+}
+
+// Label is a label of a TimeSeries.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample is a sample of a TimeSeries, its timestamp is a unix timestamp in milliseconds.
+type Sample struct {
+	Value     float64
+	Timestamp int64
+}
+
+// Field numbers of the messages of the remote write protocol, see prometheus/prompb/remote.proto and types.proto.
+const (
+	writeRequestTimeseries = 1
+	timeSeriesLabels       = 1
+	timeSeriesSamples      = 2
+	labelName              = 1
+	labelValue             = 2
+	sampleValue            = 1
+	sampleTimestamp        = 2
+)
+
+// Marshal encodes the request in the protobuf wire format.
+func (r *WriteRequest) Marshal() []byte {
+	var b []byte
+	for i := range r.Timeseries {
+		b = protowire.AppendTag(b, writeRequestTimeseries, protowire.BytesType)
+		b = protowire.AppendBytes(b, r.Timeseries[i].marshal())
+	}
+	return b
+}
+
+func (ts *TimeSeries) marshal() []byte {
+	var b []byte
+	for _, l := range ts.Labels {
+		b = protowire.AppendTag(b, timeSeriesLabels, protowire.BytesType)
+		b = protowire.AppendBytes(b, l.marshal())
+	}
+	for _, s := range ts.Samples {
+		b = protowire.AppendTag(b, timeSeriesSamples, protowire.BytesType)
+		b = protowire.AppendBytes(b, s.marshal())
+	}
+	return b
+}
+
+func (l Label) marshal() []byte {
+	var b []byte
+	b = protowire.AppendTag(b, labelName, protowire.BytesType)
+	b = protowire.AppendString(b, l.Name)
+	b = protowire.AppendTag(b, labelValue, protowire.BytesType)
+	return protowire.AppendString(b, l.Value)
+}
+
+func (s Sample) marshal() []byte {
+	var b []byte
+	b = protowire.AppendTag(b, sampleValue, protowire.Fixed64Type)
+	b = protowire.AppendFixed64(b, math.Float64bits(s.Value))
+	b = protowire.AppendTag(b, sampleTimestamp, protowire.VarintType)
+	return protowire.AppendVarint(b, uint64(s.Timestamp))
+}
@@ -0,0 +1,182 @@
+package remotewrite
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// QueueConfig configures the batching and the retries of a Queue.
+type QueueConfig struct {
+	// Capacity is the largest number of samples waiting to be sent, the series appended to a full queue are dropped.
+	Capacity int
+	// MaxSamplesPerSend is the number of samples a request is sent at, a request holds at least one series.
+	MaxSamplesPerSend int
+	// BatchSendDeadline is the longest time a series waits for a request to be full before it is sent.
+	BatchSendDeadline time.Duration
+	// MinBackoff and MaxBackoff bound the delay between the retries of a request, which doubles at every retry.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultQueueConfig returns the default configuration of a Queue.
+func DefaultQueueConfig() QueueConfig {
+	return QueueConfig{
+		Capacity:          10000,
+		MaxSamplesPerSend: 2000,
+		BatchSendDeadline: 5 * time.Second,
+		MinBackoff:        30 * time.Millisecond,
+		MaxBackoff:        5 * time.Second,
+	}
+}
+
+// Queue batches the series appended to it into requests sent by a Writer. A request that fails with a
+// RecoverableError is retried until it succeeds, while the series appended meanwhile wait in the queue; a
+// request that fails with another error is dropped.
+type Queue struct {
+	writer Writer
+	config QueueConfig
+
+	lock    sync.Mutex
+	pending []TimeSeries
+	samples int
+	// full is signaled when the pending series hold MaxSamplesPerSend samples.
+	full chan struct{}
+}
+
+// NewQueue returns a queue sending its series with the writer, the fields of the config that are not positive
+// take their default value.
+func NewQueue(writer Writer, config QueueConfig) *Queue {
+	defaults := DefaultQueueConfig()
+	if config.Capacity <= 0 {
+		config.Capacity = defaults.Capacity
+	}
+	if config.MaxSamplesPerSend <= 0 {
+		config.MaxSamplesPerSend = defaults.MaxSamplesPerSend
+	}
+	if config.BatchSendDeadline <= 0 {
+		config.BatchSendDeadline = defaults.BatchSendDeadline
+	}
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = defaults.MinBackoff
+	}
+	if config.MaxBackoff < config.MinBackoff {
+		config.MaxBackoff = config.MinBackoff
+	}
+	return &Queue{writer: writer, config: config, full: make(chan struct{}, 1)}
+}
+
+// Append adds the series to the queue, and returns false if their samples do not fit in the queue and they were
+// dropped.
+func (q *Queue) Append(series ...TimeSeries) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	samples := 0
+	for i := range series {
+		samples += len(series[i].Samples)
+	}
+	if q.samples+samples > q.config.Capacity {
+		return false
+	}
+	q.pending = append(q.pending, series...)
+	q.samples += samples
+	if q.samples >= q.config.MaxSamplesPerSend {
+		select {
+		case q.full <- struct{}{}:
+		default:
+		}
+	}
+	return true
+}
+
+// Len returns the number of series waiting to be sent.
+func (q *Queue) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return len(q.pending)
+}
+
+// Run sends the series of the queue until the context is done, a request is sent as soon as it is full or once
+// its oldest series waited for the batch send deadline. The pending series are sent once more when the context is
+// done, without retry.
+func (q *Queue) Run(ctx context.Context) {
+	ticker := time.NewTicker(q.config.BatchSendDeadline)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			for batch := q.next(); len(batch) > 0; batch = q.next() {
+				if err := q.writer.Store(context.Background(), &WriteRequest{Timeseries: batch}); err != nil {
+					klog.Errorf("Failed to send %d series on shutdown: %v", len(batch), err)
+					return
+				}
+			}
+			return
+		case <-q.full:
+		case <-ticker.C:
+		}
+		for batch := q.next(); len(batch) > 0; batch = q.next() {
+			if !q.send(ctx, batch) {
+				break
+			}
+		}
+	}
+}
+
+// next removes the next batch from the queue, the series holding up to MaxSamplesPerSend samples.
+func (q *Queue) next() []TimeSeries {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	n, samples := 0, 0
+	for n < len(q.pending) && (n == 0 || samples+len(q.pending[n].Samples) <= q.config.MaxSamplesPerSend) {
+		samples += len(q.pending[n].Samples)
+		n++
+	}
+	batch := make([]TimeSeries, n)
+	copy(batch, q.pending)
+	q.pending = q.pending[n:]
+	q.samples -= samples
+	return batch
+}
+
+// send sends a batch, retrying while it fails with a RecoverableError. It returns false if the context is done
+// before the batch is sent, the batch is then put back in front of the queue.
+func (q *Queue) send(ctx context.Context, batch []TimeSeries) bool {
+	backoff := q.config.MinBackoff
+	for {
+		err := q.writer.Store(ctx, &WriteRequest{Timeseries: batch})
+		if err == nil {
+			return true
+		}
+		var recoverable RecoverableError
+		if !errors.As(err, &recoverable) {
+			klog.Errorf("Failed to send %d series, dropping them: %v", len(batch), err)
+			return true
+		}
+		klog.V(2).Infof("Failed to send %d series, retrying in %s: %v", len(batch), backoff, err)
+
+		select {
+		case <-ctx.Done():
+			q.requeue(batch)
+			return false
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > q.config.MaxBackoff {
+			backoff = q.config.MaxBackoff
+		}
+	}
+}
+
+// requeue puts a batch back in front of the queue, whatever its capacity.
+func (q *Queue) requeue(batch []TimeSeries) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.pending = append(batch, q.pending...)
+	for i := range batch {
+		q.samples += len(batch[i].Samples)
+	}
+}
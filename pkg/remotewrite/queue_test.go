@@ -0,0 +1,289 @@
+package remotewrite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// receiver is a remote write endpoint recording the decoded requests, it answers with the given statuses in turn
+// and with 204 afterwards.
+type receiver struct {
+	t *testing.T
+
+	lock     sync.Mutex
+	statuses []int
+	requests []*WriteRequest
+}
+
+func newReceiver(t *testing.T, statuses ...int) (*receiver, *httptest.Server) {
+	r := &receiver{t: t, statuses: statuses}
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+	return r, server
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("Content-Encoding") != "snappy" || req.Header.Get("Content-Type") != "application/x-protobuf" {
+		r.t.Errorf("unexpected headers %v", req.Header)
+	}
+	compressed, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		r.t.Errorf("failed to read request: %v", err)
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		r.t.Errorf("failed to decompress request: %v", err)
+	}
+	decoded, err := decodeWriteRequest(data)
+	if err != nil {
+		r.t.Errorf("failed to decode request: %v", err)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.requests = append(r.requests, decoded)
+	status := http.StatusNoContent
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	if status/100 != 2 {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+	w.WriteHeader(status)
+}
+
+// received returns the requests received so far.
+func (r *receiver) received() []*WriteRequest {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]*WriteRequest(nil), r.requests...)
+}
+
+// waitFor waits until the receiver got n requests.
+func (r *receiver) waitFor(n int) []*WriteRequest {
+	r.t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if requests := r.received(); len(requests) >= n {
+			return requests
+		}
+	}
+	r.t.Fatalf("expected %d requests, got %d", n, len(r.received()))
+	return nil
+}
+
+// decodeWriteRequest decodes a request in the protobuf wire format, independently of Marshal.
+func decodeWriteRequest(b []byte) (*WriteRequest, error) {
+	req := &WriteRequest{}
+	err := consumeMessage(b, func(num protowire.Number, v []byte, _ uint64) error {
+		if num != writeRequestTimeseries {
+			return fmt.Errorf("unexpected field %d of WriteRequest", num)
+		}
+		var ts TimeSeries
+		err := consumeMessage(v, func(num protowire.Number, v []byte, _ uint64) error {
+			switch num {
+			case timeSeriesLabels:
+				var l Label
+				err := consumeMessage(v, func(num protowire.Number, v []byte, _ uint64) error {
+					if num == labelName {
+						l.Name = string(v)
+					} else {
+						l.Value = string(v)
+					}
+					return nil
+				})
+				ts.Labels = append(ts.Labels, l)
+				return err
+			case timeSeriesSamples:
+				var s Sample
+				err := consumeMessage(v, func(num protowire.Number, _ []byte, n uint64) error {
+					if num == sampleValue {
+						s.Value = math.Float64frombits(n)
+					} else {
+						s.Timestamp = int64(n)
+					}
+					return nil
+				})
+				ts.Samples = append(ts.Samples, s)
+				return err
+			}
+			return fmt.Errorf("unexpected field %d of TimeSeries", num)
+		})
+		req.Timeseries = append(req.Timeseries, ts)
+		return err
+	})
+	return req, err
+}
+
+// consumeMessage calls field with the bytes of the bytes fields and the number of the varint and fixed64 fields.
+func consumeMessage(b []byte, field func(num protowire.Number, v []byte, n uint64) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		var v []byte
+		var u uint64
+		switch typ {
+		case protowire.BytesType:
+			v, n = protowire.ConsumeBytes(b)
+		case protowire.VarintType:
+			u, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			u, n = protowire.ConsumeFixed64(b)
+		default:
+			return fmt.Errorf("unexpected wire type %d", typ)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		if err := field(num, v, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newSeries(name string, samples int) TimeSeries {
+	ts := TimeSeries{Labels: []Label{{Name: "__name__", Value: name}, {Name: "metric", Value: "cpu"}}}
+	for i := 0; i < samples; i++ {
+		ts.Samples = append(ts.Samples, Sample{Value: 0.25 * float64(i), Timestamp: 1637740800000 + int64(i)*60000})
+	}
+	return ts
+}
+
+func TestClientEncoding(t *testing.T) {
+	r, server := newReceiver(t)
+	req := &WriteRequest{Timeseries: []TimeSeries{newSeries("a", 3), newSeries("b", 1)}}
+	if err := NewClient(server.URL, nil).Store(context.TODO(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if received := r.received(); len(received) != 1 || !reflect.DeepEqual(received[0], req) {
+		t.Errorf("expected the request %+v, got %+v", req, received)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	cases := []struct {
+		status      int
+		recoverable bool
+	}{
+		{status: http.StatusInternalServerError, recoverable: true},
+		{status: http.StatusTooManyRequests, recoverable: true},
+		{status: http.StatusBadRequest},
+	}
+	for _, c := range cases {
+		_, server := newReceiver(t, c.status)
+		err := NewClient(server.URL, nil).Store(context.TODO(), &WriteRequest{Timeseries: []TimeSeries{newSeries("a", 1)}})
+		var recoverable RecoverableError
+		if err == nil || errors.As(err, &recoverable) != c.recoverable {
+			t.Errorf("expected a recoverable error %v for status %d, got %v", c.recoverable, c.status, err)
+		}
+	}
+}
+
+// runQueue runs a queue sending to the server until the test ends.
+func runQueue(t *testing.T, server *httptest.Server, config QueueConfig) *Queue {
+	q := NewQueue(NewClient(server.URL, nil), config)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		q.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return q
+}
+
+func TestQueueBatchesByMaxSamplesPerSend(t *testing.T) {
+	r, server := newReceiver(t)
+	q := runQueue(t, server, QueueConfig{MaxSamplesPerSend: 4, BatchSendDeadline: time.Hour})
+
+	// the full queue is sent at once, in requests of at most 4 samples.
+	q.Append(newSeries("a", 2), newSeries("b", 2), newSeries("c", 3))
+	requests := r.waitFor(2)
+	if len(requests[0].Timeseries) != 2 || requests[0].Timeseries[0].Labels[0].Value != "a" || requests[0].Timeseries[1].Labels[0].Value != "b" {
+		t.Errorf("expected a request of the series a and b, got %+v", requests[0])
+	}
+	// a series larger than a request is sent alone.
+	q.Append(newSeries("d", 5))
+	requests = r.waitFor(3)
+	if len(requests[1].Timeseries) != 1 || len(requests[2].Timeseries) != 1 || len(requests[2].Timeseries[0].Samples) != 5 {
+		t.Errorf("expected the series c and d in their own requests, got %+v", requests[1:])
+	}
+}
+
+func TestQueueSendsAfterBatchSendDeadline(t *testing.T) {
+	r, server := newReceiver(t)
+	q := runQueue(t, server, QueueConfig{MaxSamplesPerSend: 100, BatchSendDeadline: 10 * time.Millisecond})
+
+	q.Append(newSeries("a", 1))
+	if requests := r.waitFor(1); len(requests[0].Timeseries) != 1 {
+		t.Errorf("unexpected request %+v", requests[0])
+	}
+}
+
+func TestQueueRetriesWithBackoff(t *testing.T) {
+	r, server := newReceiver(t, http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusInternalServerError)
+	q := runQueue(t, server, QueueConfig{MaxSamplesPerSend: 1, BatchSendDeadline: time.Hour, MinBackoff: 10 * time.Millisecond, MaxBackoff: 20 * time.Millisecond})
+
+	start := time.Now()
+	q.Append(newSeries("a", 1))
+	requests := r.waitFor(4)
+	// the retries wait 10, 20 and 20 milliseconds.
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected the retries to back off for at least 50ms, took %s", elapsed)
+	}
+	for _, req := range requests {
+		if !reflect.DeepEqual(req, requests[0]) {
+			t.Errorf("expected the same request to be retried, got %+v and %+v", requests[0], req)
+		}
+	}
+}
+
+func TestQueueDropsUnrecoverableRequests(t *testing.T) {
+	r, server := newReceiver(t, http.StatusBadRequest)
+	q := runQueue(t, server, QueueConfig{MaxSamplesPerSend: 1, BatchSendDeadline: time.Hour})
+
+	q.Append(newSeries("a", 1))
+	r.waitFor(1)
+	q.Append(newSeries("b", 1))
+	requests := r.waitFor(2)
+	if got := requests[1].Timeseries[0].Labels[0].Value; got != "b" {
+		t.Errorf("expected the rejected request to be dropped, got series %s", got)
+	}
+}
+
+func TestQueueCapacity(t *testing.T) {
+	q := NewQueue(NewClient("http://localhost", nil), QueueConfig{Capacity: 3})
+	if !q.Append(newSeries("a", 2), newSeries("b", 1)) {
+		t.Fatalf("expected the samples to fit in the queue")
+	}
+	if q.Append(newSeries("c", 1)) {
+		t.Errorf("expected a series appended to a full queue to be dropped")
+	}
+	if q.Len() != 2 {
+		t.Errorf("expected 2 series in the queue, got %d", q.Len())
+	}
+	q.next()
+	if !q.Append(newSeries("c", 3)) {
+		t.Errorf("expected the samples to fit in the emptied queue")
+	}
+}
@@ -0,0 +1,216 @@
+package remotewrite
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/gocrane-io/api/pkg/exporter"
+	"github.com/gocrane-io/api/pkg/generated/informers/externalversions"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// ForecastLabel is the label set to ForecastValue on every pushed series, to tell them apart from the series of the
+// exporter, whose samples carry a horizon label instead.
+const (
+	ForecastLabel = "forecast"
+	ForecastValue = "true"
+)
+
+// Sink appends the forecast series of the predictions to a queue whenever their status is updated. A metric of a
+// prediction is pushed as a single series holding all its points, with the names and labels of the metrics of the
+// exporter, ForecastLabel instead of the horizon, and the values in the base unit of their metric, cores for cpu
+// and bytes for memory. Only the samples after the last sample appended of a series are appended, so that a
+// receiver never gets samples older than those it already has, the later forecasts of a timestamp already pushed
+// are not sent again.
+type Sink struct {
+	queue *Queue
+
+	lock sync.Mutex
+	// pushed are the predictions whose series were appended, keyed by kind and key.
+	pushed map[string]*pushedPrediction
+}
+
+// pushedPrediction is the last update of a prediction whose series were appended.
+type pushedPrediction struct {
+	updated time.Time
+	// latest is the timestamp of the last sample appended of every series of the update, keyed by its labels.
+	latest map[string]int64
+}
+
+// NewSink returns a sink watching the predictions with the informers, which must be started afterwards.
+func NewSink(informers externalversions.SharedInformerFactory, queue *Queue) *Sink {
+	s := &Sink{queue: queue, pushed: make(map[string]*pushedPrediction)}
+	predictions := informers.Prediction().V1alpha1()
+	predictions.NodePredictions().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    s.pushNodePrediction,
+		UpdateFunc: func(_, newObj interface{}) { s.pushNodePrediction(newObj) },
+		DeleteFunc: func(obj interface{}) { s.forget(exporter.KindNodePrediction, obj) },
+	})
+	predictions.PodGroupPredictions().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    s.pushPodGroupPrediction,
+		UpdateFunc: func(_, newObj interface{}) { s.pushPodGroupPrediction(newObj) },
+		DeleteFunc: func(obj interface{}) { s.forget(exporter.KindPodGroupPrediction, obj) },
+	})
+	return s
+}
+
+func (s *Sink) pushNodePrediction(obj interface{}) {
+	np, ok := obj.(*v1alpha1.NodePrediction)
+	if !ok || np.Status.LastUpdateTime == nil {
+		return
+	}
+	updated := np.Status.LastUpdateTime.Time
+	if !s.updated(exporter.KindNodePrediction, np.Name, updated) {
+		return
+	}
+	var series []TimeSeries
+	for _, metric := range sortedMetrics(np.Status.Consumed) {
+		series = appendSeries(series, exporter.ForecastMetric, metric, np.Status.Consumed[metric],
+			Label{Name: "kind", Value: exporter.KindNodePrediction},
+			Label{Name: "prediction", Value: np.Name})
+	}
+	s.append(exporter.KindNodePrediction, np.Name, updated, series)
+}
+
+func (s *Sink) pushPodGroupPrediction(obj interface{}) {
+	pgp, ok := obj.(*v1alpha1.PodGroupPrediction)
+	if !ok || pgp.Status.LastUpdateTime == nil {
+		return
+	}
+	key := pgp.Namespace + "/" + pgp.Name
+	updated := pgp.Status.LastUpdateTime.Time
+	if !s.updated(exporter.KindPodGroupPrediction, key, updated) {
+		return
+	}
+	var series []TimeSeries
+	for _, metric := range sortedMetrics(pgp.Status.Aggregation) {
+		series = appendSeries(series, exporter.ForecastMetric, metric, pgp.Status.Aggregation[metric],
+			Label{Name: "kind", Value: exporter.KindPodGroupPrediction},
+			Label{Name: "namespace", Value: pgp.Namespace},
+			Label{Name: "prediction", Value: pgp.Name})
+	}
+	containers := make([]string, 0, len(pgp.Status.Containers))
+	for container := range pgp.Status.Containers {
+		containers = append(containers, container)
+	}
+	sort.Strings(containers)
+	for _, container := range containers {
+		prediction := pgp.Status.Containers[container]
+		for _, metric := range sortedMetrics(prediction) {
+			series = appendSeries(series, exporter.ContainerForecastMetric, metric, prediction[metric],
+				Label{Name: "container", Value: container},
+				Label{Name: "namespace", Value: pgp.Namespace},
+				Label{Name: "prediction", Value: pgp.Name})
+		}
+	}
+	s.append(exporter.KindPodGroupPrediction, key, updated, series)
+}
+
+// updated returns whether the prediction was updated since its series were last appended.
+func (s *Sink) updated(kind, key string, updated time.Time) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	pushed := s.pushed[kind+"/"+key]
+	return pushed == nil || !pushed.updated.Equal(updated)
+}
+
+// append appends the samples of the series of a prediction that are after the last appended ones to the queue,
+// the prediction is pushed again on its next event if the queue is full.
+func (s *Sink) append(kind, key string, updated time.Time, series []TimeSeries) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var last map[string]int64
+	if pushed := s.pushed[kind+"/"+key]; pushed != nil {
+		last = pushed.latest
+	}
+
+	latest := make(map[string]int64, len(series))
+	var fresh []TimeSeries
+	for _, ts := range series {
+		id := seriesID(ts.Labels)
+		samples := ts.Samples
+		if t, ok := last[id]; ok {
+			samples = samples[sort.Search(len(samples), func(i int) bool { return samples[i].Timestamp > t }):]
+			latest[id] = t
+		}
+		if len(samples) == 0 {
+			continue
+		}
+		latest[id] = samples[len(samples)-1].Timestamp
+		fresh = append(fresh, TimeSeries{Labels: ts.Labels, Samples: samples})
+	}
+	if len(fresh) > 0 && !s.queue.Append(fresh...) {
+		klog.Errorf("Failed to push the series of %s %s: the queue is full", kind, key)
+		return
+	}
+	s.pushed[kind+"/"+key] = &pushedPrediction{updated: updated, latest: latest}
+}
+
+// seriesID returns a key identifying the series of the sorted labels.
+func seriesID(labels []Label) string {
+	var b strings.Builder
+	for _, l := range labels {
+		b.WriteString(l.Name)
+		b.WriteByte('=')
+		b.WriteString(l.Value)
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+func (s *Sink) forget(kind string, obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.pushed, kind+"/"+key)
+}
+
+// appendSeries appends the series of a metric forecast, holding all its points sorted by timestamp, the labels of
+// the series are sorted by name as the remote write protocol requires.
+func appendSeries(series []TimeSeries, name, metric string, ts v1alpha1.TimeSeries, labels ...Label) []TimeSeries {
+	resourceName := v1alpha1.ResourceName(metric)
+	points, err := helper.ParseTimeSeries(resourceName, ts)
+	if err != nil {
+		klog.Errorf("Failed to parse the forecast of metric %s: %v", metric, err)
+		return series
+	}
+	if len(points) == 0 {
+		return series
+	}
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Timestamp < points[j].Timestamp
+	})
+
+	labels = append(labels,
+		Label{Name: "__name__", Value: name},
+		Label{Name: ForecastLabel, Value: ForecastValue},
+		Label{Name: "metric", Value: metric})
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].Name < labels[j].Name
+	})
+	samples := make([]Sample, 0, len(points))
+	for _, point := range points {
+		value := helper.ToQuantity(resourceName, point.Value)
+		samples = append(samples, Sample{Value: value.AsApproximateFloat64(), Timestamp: point.Timestamp * 1000})
+	}
+	return append(series, TimeSeries{Labels: labels, Samples: samples})
+}
+
+// sortedMetrics returns the sorted metric names of a prediction.
+func sortedMetrics(p v1alpha1.Prediction) []string {
+	metrics := make([]string, 0, len(p))
+	for metric := range p {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+	return metrics
+}
@@ -0,0 +1,107 @@
+package remotewrite
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gocrane-io/api/pkg/exporter"
+	"github.com/gocrane-io/api/pkg/generated/clientset/versioned/fake"
+	"github.com/gocrane-io/api/pkg/generated/informers/externalversions"
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/predictiontest"
+)
+
+// newPodGroupPrediction returns a PodGroupPrediction updated at the time, whose cpu aggregation and container
+// predict 1500 milli cores every minute of the following 3 minutes.
+func newPodGroupPrediction(updated time.Time) *v1alpha1.PodGroupPrediction {
+	ts := predictiontest.Series(updated.Add(time.Minute), time.Minute, "1500", "1500", "1500")
+	pgp := predictiontest.NewPodGroupPrediction("default", "web")
+	pgp.Status = v1alpha1.PodGroupPredictionStatus{
+		LastUpdateTime: &metav1.Time{Time: updated},
+		Aggregation:    v1alpha1.Prediction{"cpu": ts},
+		Containers:     map[string]v1alpha1.Prediction{"default/web-0/web": {"cpu": ts}},
+	}
+	return pgp
+}
+
+func TestSinkPushesNewSamplesOfStableSeries(t *testing.T) {
+	queue := NewQueue(NewClient("http://localhost", nil), QueueConfig{})
+	sink := NewSink(externalversions.NewSharedInformerFactory(fake.NewSimpleClientset(), 0), queue)
+	updated := predictiontest.Now
+
+	sink.pushPodGroupPrediction(newPodGroupPrediction(updated))
+	first := queue.next()
+	if len(first) != 2 {
+		t.Fatalf("expected the series of the aggregation and the container, got %+v", first)
+	}
+	expected := []Label{
+		{Name: "__name__", Value: exporter.ForecastMetric},
+		{Name: ForecastLabel, Value: ForecastValue},
+		{Name: "kind", Value: exporter.KindPodGroupPrediction},
+		{Name: "metric", Value: "cpu"},
+		{Name: "namespace", Value: "default"},
+		{Name: "prediction", Value: "web"},
+	}
+	if !reflect.DeepEqual(first[0].Labels, expected) {
+		t.Errorf("expected labels %+v, got %+v", expected, first[0].Labels)
+	}
+	var samples []Sample
+	for i := 1; i <= 3; i++ {
+		samples = append(samples, Sample{Value: 1.5, Timestamp: updated.Add(time.Duration(i)*time.Minute).Unix() * 1000})
+	}
+	if !reflect.DeepEqual(first[0].Samples, samples) {
+		t.Errorf("expected the samples %+v, got %+v", samples, first[0].Samples)
+	}
+	expected = []Label{
+		{Name: "__name__", Value: exporter.ContainerForecastMetric},
+		{Name: "container", Value: "default/web-0/web"},
+		{Name: ForecastLabel, Value: ForecastValue},
+		{Name: "metric", Value: "cpu"},
+		{Name: "namespace", Value: "default"},
+		{Name: "prediction", Value: "web"},
+	}
+	if !reflect.DeepEqual(first[1].Labels, expected) {
+		t.Errorf("expected labels %+v, got %+v", expected, first[1].Labels)
+	}
+
+	// the same update is not pushed twice.
+	sink.pushPodGroupPrediction(newPodGroupPrediction(updated))
+	if queue.Len() != 0 {
+		t.Errorf("expected no series for the same update, got %d", queue.Len())
+	}
+
+	// the next update pushes the same series with only its last point, the others were already pushed.
+	sink.pushPodGroupPrediction(newPodGroupPrediction(updated.Add(time.Minute)))
+	second := queue.next()
+	if len(second) != 2 {
+		t.Fatalf("expected the series of the aggregation and the container, got %+v", second)
+	}
+	for i := range second {
+		if !reflect.DeepEqual(second[i].Labels, first[i].Labels) {
+			t.Errorf("expected the labels %+v of the first update, got %+v", first[i].Labels, second[i].Labels)
+		}
+		if s := second[i].Samples; len(s) != 1 || s[0].Timestamp != samples[2].Timestamp+time.Minute.Milliseconds() {
+			t.Errorf("expected the sample a minute after the last one of the first update, got %+v", s)
+		}
+	}
+}
+
+func TestSinkRetriesUpdateOnFullQueue(t *testing.T) {
+	queue := NewQueue(NewClient("http://localhost", nil), QueueConfig{Capacity: 3})
+	sink := NewSink(externalversions.NewSharedInformerFactory(fake.NewSimpleClientset(), 0), queue)
+	pgp := newPodGroupPrediction(predictiontest.Now)
+
+	sink.pushPodGroupPrediction(pgp)
+	if queue.Len() != 0 {
+		t.Fatalf("expected the series to be dropped by the full queue, got %d", queue.Len())
+	}
+	// the update is pushed again on the next event.
+	pgp.Status.Containers = nil
+	sink.pushPodGroupPrediction(pgp)
+	if series := queue.next(); len(series) != 1 || len(series[0].Samples) != 3 {
+		t.Errorf("expected the series of the aggregation with its 3 samples, got %+v", series)
+	}
+}
@@ -0,0 +1,55 @@
+package timeseries
+
+import (
+	"math"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// Evaluate compares the predicted series with the observed one and returns the accuracy of the prediction.
+// Every predicted sample is compared with the observed value at its timestamp, interpolated within gaps of at
+// most maxGap; the predicted samples outside of the observed series or within a larger gap are left out. A
+// maxGap of zero accepts any gap. ErrEmpty is returned when no sample can be compared.
+func Evaluate(predicted, observed Series, maxGap int64) (helper.Accuracy, error) {
+	var accuracy helper.Accuracy
+	var squares, percentages float64
+	var nonZero, bounded, covered int
+	for _, p := range predicted {
+		actual, err := observed.Interpolate(p.Timestamp, maxGap)
+		if err != nil {
+			continue
+		}
+		if accuracy.Samples == 0 {
+			accuracy.Start = p.Timestamp
+		}
+		accuracy.End = p.Timestamp
+		accuracy.Samples++
+
+		e := p.Value - actual
+		squares += e * e
+		if actual != 0 {
+			percentages += math.Abs(e / actual)
+			nonZero++
+		}
+		if lower, upper, ok := p.Bounds(); ok {
+			bounded++
+			if lower <= actual && actual <= upper {
+				covered++
+			}
+		}
+	}
+	if accuracy.Samples == 0 {
+		return helper.Accuracy{}, ErrEmpty
+	}
+
+	accuracy.RMSE = math.Sqrt(squares / float64(accuracy.Samples))
+	if nonZero > 0 {
+		mape := percentages / float64(nonZero)
+		accuracy.MAPE = &mape
+	}
+	if bounded > 0 {
+		coverage := float64(covered) / float64(bounded)
+		accuracy.Coverage = &coverage
+	}
+	return accuracy, nil
+}
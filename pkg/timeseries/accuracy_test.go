@@ -0,0 +1,69 @@
+package timeseries
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+func bounded(t int64, value, lower, upper float64) helper.Sample {
+	s := helper.Sample{Timestamp: t, Value: value}
+	s.SetBounds(lower, upper)
+	return s
+}
+
+func TestEvaluate(t *testing.T) {
+	observed := Series{{Timestamp: 0, Value: 100}, {Timestamp: 60, Value: 200}, {Timestamp: 120, Value: 0}, {Timestamp: 600, Value: 100}}
+	predicted := Series{
+		// before the observed series.
+		{Timestamp: -60, Value: 100},
+		bounded(0, 110, 90, 120),
+		// interpolated between 100 and 200.
+		bounded(30, 120, 160, 180),
+		// a zero actual value is left out of the MAPE.
+		{Timestamp: 120, Value: 30},
+		// within a gap longer than maxGap.
+		{Timestamp: 300, Value: 100},
+	}
+
+	accuracy, err := Evaluate(predicted, observed, 120)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if accuracy.Samples != 3 || accuracy.Start != 0 || accuracy.End != 120 {
+		t.Errorf("unexpected samples %d in [%d, %d]", accuracy.Samples, accuracy.Start, accuracy.End)
+	}
+	if rmse := math.Sqrt((10*10 + 30*30 + 30*30) / 3.0); math.Abs(accuracy.RMSE-rmse) > 1e-9 {
+		t.Errorf("expected rmse %g, got %g", rmse, accuracy.RMSE)
+	}
+	if mape := (0.1 + 0.2) / 2; accuracy.MAPE == nil || math.Abs(*accuracy.MAPE-mape) > 1e-9 {
+		t.Errorf("expected mape %g, got %v", mape, accuracy.MAPE)
+	}
+	if accuracy.Coverage == nil || *accuracy.Coverage != 0.5 {
+		t.Errorf("expected coverage 0.5, got %v", accuracy.Coverage)
+	}
+
+	accuracy, err = Evaluate(predicted, observed, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if accuracy.Samples != 4 {
+		t.Errorf("expected the gap to be interpolated without max gap, got %d samples", accuracy.Samples)
+	}
+}
+
+func TestEvaluateEmpty(t *testing.T) {
+	observed := Series{{Timestamp: 0, Value: 100}, {Timestamp: 60, Value: 100}}
+	if _, err := Evaluate(Series{{Timestamp: 120, Value: 100}}, observed, 0); !errors.Is(err, ErrEmpty) {
+		t.Errorf("expected ErrEmpty, got %v", err)
+	}
+	accuracy, err := Evaluate(Series{{Timestamp: 60, Value: 100}}, Series{{Timestamp: 60, Value: 0}}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if accuracy.MAPE != nil || accuracy.Coverage != nil {
+		t.Errorf("expected no mape nor coverage, got %+v", accuracy)
+	}
+}
@@ -0,0 +1,132 @@
+package timeseries
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// floorDiv rounds the timestamp down to a multiple of step, also for negative timestamps.
+func floorDiv(t, step int64) int64 {
+	q := t / step
+	if t%step != 0 && t < 0 {
+		q--
+	}
+	return q * step
+}
+
+// Resample buckets the series into steps aligned on multiples of step and aggregates every
+// bucket [t, t+step) into a single sample at t. Buckets without samples are left out, so gaps
+// of the input stay visible in the output; use Fill to fill them.
+func (s Series) Resample(step int64, aggregate Aggregator) (Series, error) {
+	if step <= 0 {
+		return nil, fmt.Errorf("step %d must be positive", step)
+	}
+	var out Series
+	for i := 0; i < len(s); {
+		bucket := floorDiv(s[i].Timestamp, step)
+		j := i
+		for j < len(s) && s[j].Timestamp < bucket+step {
+			j++
+		}
+		out = append(out, helper.Sample{Timestamp: bucket, Value: aggregate(s[i:j].Values())})
+		i = j
+	}
+	return out, nil
+}
+
+// Fill inserts a sample every step between consecutive samples that are more than step apart,
+// with a value linearly interpolated between them. Gaps larger than maxGap are left untouched,
+// a maxGap of zero fills all gaps.
+func (s Series) Fill(step, maxGap int64) (Series, error) {
+	if step <= 0 {
+		return nil, fmt.Errorf("step %d must be positive", step)
+	}
+	var out Series
+	for i, sample := range s {
+		if i > 0 {
+			prev := s[i-1]
+			if gap := sample.Timestamp - prev.Timestamp; gap > step && (maxGap == 0 || gap <= maxGap) {
+				for t := prev.Timestamp + step; t < sample.Timestamp; t += step {
+					out = append(out, helper.Sample{Timestamp: t, Value: lerp(prev, sample, t)})
+				}
+			}
+		}
+		out = append(out, sample)
+	}
+	return out, nil
+}
+
+func lerp(a, b helper.Sample, t int64) float64 {
+	if a.Timestamp == b.Timestamp {
+		return b.Value
+	}
+	return a.Value + (b.Value-a.Value)*float64(t-a.Timestamp)/float64(b.Timestamp-a.Timestamp)
+}
+
+// Interpolate returns the value at timestamp t, linearly interpolated between the samples
+// around it. ErrOutOfRange is returned if t is outside of the series and ErrGap if the
+// surrounding samples are more than maxGap apart. A maxGap of zero accepts any gap.
+func (s Series) Interpolate(t int64, maxGap int64) (float64, error) {
+	if len(s) == 0 {
+		return 0, ErrEmpty
+	}
+	i := sort.Search(len(s), func(i int) bool { return s[i].Timestamp >= t })
+	if i < len(s) && s[i].Timestamp == t {
+		return s[i].Value, nil
+	}
+	if i == 0 || i == len(s) {
+		return 0, ErrOutOfRange
+	}
+	prev, next := s[i-1], s[i]
+	if maxGap > 0 && next.Timestamp-prev.Timestamp > maxGap {
+		return 0, ErrGap
+	}
+	return lerp(prev, next, t), nil
+}
+
+// Align returns two series with the same timestamps: the union of the timestamps of a and b
+// within the time range covered by both. Values missing in one series are interpolated, and
+// timestamps falling into a gap larger than maxGap in either series are dropped.
+func Align(a, b Series, maxGap int64) (Series, Series) {
+	if len(a) == 0 || len(b) == 0 {
+		return nil, nil
+	}
+	start := a[0].Timestamp
+	if b[0].Timestamp > start {
+		start = b[0].Timestamp
+	}
+	end := a[len(a)-1].Timestamp
+	if b[len(b)-1].Timestamp < end {
+		end = b[len(b)-1].Timestamp
+	}
+	var alignedA, alignedB Series
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		var t int64
+		switch {
+		case j == len(b) || (i < len(a) && a[i].Timestamp < b[j].Timestamp):
+			t = a[i].Timestamp
+			i++
+		case i == len(a) || b[j].Timestamp < a[i].Timestamp:
+			t = b[j].Timestamp
+			j++
+		default:
+			t = a[i].Timestamp
+			i++
+			j++
+		}
+		if t < start || t > end {
+			continue
+		}
+		va, errA := a.Interpolate(t, maxGap)
+		vb, errB := b.Interpolate(t, maxGap)
+		if errA != nil || errB != nil {
+			continue
+		}
+		alignedA = append(alignedA, helper.Sample{Timestamp: t, Value: va})
+		alignedB = append(alignedB, helper.Sample{Timestamp: t, Value: vb})
+	}
+	return alignedA, alignedB
+}
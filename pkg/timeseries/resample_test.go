@@ -0,0 +1,134 @@
+package timeseries
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResample(t *testing.T) {
+	cases := []struct {
+		name      string
+		input     Series
+		step      int64
+		aggregate Aggregator
+		expected  Series
+	}{
+		{
+			name:      "buckets aligned on the step",
+			input:     series(0, 1, 30, 3, 60, 2, 119, 5),
+			step:      60,
+			aggregate: AggregateMax,
+			expected:  series(0, 3, 60, 5),
+		},
+		{
+			name:      "gaps are left out",
+			input:     series(0, 1, 30, 3, 300, 4),
+			step:      60,
+			aggregate: AggregateMean,
+			expected:  series(0, 2, 300, 4),
+		},
+		{
+			name:      "negative timestamps",
+			input:     series(-90, 1, -60, 2, -1, 3, 0, 4),
+			step:      60,
+			aggregate: AggregateLast,
+			expected:  series(-120, 1, -60, 3, 0, 4),
+		},
+		{
+			name:      "empty",
+			input:     nil,
+			step:      60,
+			aggregate: AggregateMax,
+			expected:  nil,
+		},
+	}
+	for _, c := range cases {
+		got, err := c.input.Resample(c.step, c.aggregate)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+		} else if !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, got)
+		}
+	}
+	for _, step := range []int64{0, -60} {
+		if _, err := series(0, 1).Resample(step, AggregateMax); err == nil {
+			t.Errorf("expected an error for the step %d", step)
+		}
+	}
+}
+
+func TestFill(t *testing.T) {
+	s := series(0, 0, 60, 6, 240, 12, 600, 0)
+	cases := []struct {
+		name     string
+		maxGap   int64
+		expected Series
+	}{
+		{name: "every gap", maxGap: 0, expected: series(0, 0, 60, 6, 120, 8, 180, 10, 240, 12, 300, 10, 360, 8, 420, 6, 480, 4, 540, 2, 600, 0)},
+		{name: "gaps up to maxGap", maxGap: 180, expected: series(0, 0, 60, 6, 120, 8, 180, 10, 240, 12, 600, 0)},
+		{name: "no gap small enough", maxGap: 60, expected: s},
+	}
+	for _, c := range cases {
+		got, err := s.Fill(60, c.maxGap)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+		} else if !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, got)
+		}
+	}
+	if got, _ := series(0, 1, 90, 4).Fill(60, 0); !reflect.DeepEqual(got, series(0, 1, 60, 3, 90, 4)) {
+		t.Errorf("expected a sample every step from the previous one, got %v", got)
+	}
+	if _, err := s.Fill(0, 0); err == nil {
+		t.Errorf("expected an error for the step 0")
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	s := series(0, 0, 60, 6, 300, 30)
+	cases := []struct {
+		name     string
+		series   Series
+		t        int64
+		maxGap   int64
+		expected float64
+		err      error
+	}{
+		{name: "sample", series: s, t: 60, maxGap: 60, expected: 6},
+		{name: "between samples", series: s, t: 30, maxGap: 60, expected: 3},
+		{name: "in a gap", series: s, t: 120, maxGap: 60, err: ErrGap},
+		{name: "any gap", series: s, t: 120, maxGap: 0, expected: 12},
+		{name: "before", series: s, t: -1, err: ErrOutOfRange},
+		{name: "after", series: s, t: 301, err: ErrOutOfRange},
+		{name: "empty", series: nil, t: 0, err: ErrEmpty},
+	}
+	for _, c := range cases {
+		got, err := c.series.Interpolate(c.t, c.maxGap)
+		if err != c.err {
+			t.Errorf("%s: expected the error %v, got %v", c.name, c.err, err)
+		} else if err == nil && got != c.expected {
+			t.Errorf("%s: expected %g, got %g", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestAlign(t *testing.T) {
+	a := series(0, 0, 60, 6, 120, 12, 480, 48)
+	b := series(30, 1, 90, 2, 150, 3)
+	alignedA, alignedB := Align(a, b, 120)
+	if expected := series(30, 3, 60, 6, 90, 9, 120, 12); !reflect.DeepEqual(alignedA, expected) {
+		t.Errorf("expected %v, got %v", expected, alignedA)
+	}
+	if expected := series(30, 1, 60, 1.5, 90, 2, 120, 2.5); !reflect.DeepEqual(alignedB, expected) {
+		t.Errorf("expected %v, got %v", expected, alignedB)
+	}
+
+	// 150 falls into the gap of a between 120 and 480.
+	alignedA, _ = Align(a, b, 0)
+	if n := len(alignedA); n != 5 {
+		t.Errorf("expected 5 samples without a max gap, got %v", alignedA)
+	}
+	if alignedA, alignedB := Align(nil, b, 0); alignedA != nil || alignedB != nil {
+		t.Errorf("expected no sample, got %v and %v", alignedA, alignedB)
+	}
+}
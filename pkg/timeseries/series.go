@@ -0,0 +1,100 @@
+// Package timeseries implements the analytics shared by the consumers of the prediction API:
+// statistics, windowed peaks, resampling, alignment and interpolation of TimeSeries, and the
+// evaluation of the accuracy of a prediction.
+package timeseries
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+var (
+	// ErrEmpty is returned when an operation needs at least one sample.
+	ErrEmpty = errors.New("time series is empty")
+	// ErrOutOfRange is returned when a timestamp is outside of the time range covered by a series.
+	ErrOutOfRange = errors.New("timestamp is out of the range of the time series")
+	// ErrGap is returned when a timestamp falls into a gap larger than the allowed one.
+	ErrGap = errors.New("timestamp falls into a gap of the time series")
+)
+
+// Series is a list of samples sorted by timestamp, with at most one sample per timestamp.
+// Use New or FromTimeSeries to build a Series from unordered data.
+type Series []helper.Sample
+
+// New sorts the samples by timestamp and removes duplicated timestamps, the last sample
+// of a timestamp wins. The input is not modified.
+func New(samples []helper.Sample) Series {
+	s := make(Series, len(samples))
+	copy(s, samples)
+	sort.SliceStable(s, func(i, j int) bool {
+		return s[i].Timestamp < s[j].Timestamp
+	})
+	out := s[:0]
+	for _, sample := range s {
+		if n := len(out); n > 0 && out[n-1].Timestamp == sample.Timestamp {
+			out[n-1] = sample
+			continue
+		}
+		out = append(out, sample)
+	}
+	return out
+}
+
+// FromTimeSeries parses a TimeSeries of the given resource into a Series. Vectors that cannot
+// be parsed are reported by a helper.ParseErrors error, the Series of the valid vectors is always returned.
+func FromTimeSeries(resourceName v1alpha1.ResourceName, ts v1alpha1.TimeSeries) (Series, error) {
+	samples, err := helper.ParseTimeSeries(resourceName, ts)
+	return New(samples), err
+}
+
+// TimeSeries converts the series back to a TimeSeries.
+func (s Series) TimeSeries() v1alpha1.TimeSeries {
+	return helper.FormatTimeSeries(helper.Samples(s))
+}
+
+// Values returns the values of the series.
+func (s Series) Values() []float64 {
+	return helper.Samples(s).Values()
+}
+
+// Start returns the timestamp of the first sample.
+func (s Series) Start() (int64, error) {
+	if len(s) == 0 {
+		return 0, ErrEmpty
+	}
+	return s[0].Timestamp, nil
+}
+
+// End returns the timestamp of the last sample.
+func (s Series) End() (int64, error) {
+	if len(s) == 0 {
+		return 0, ErrEmpty
+	}
+	return s[len(s)-1].Timestamp, nil
+}
+
+// Window returns the samples whose timestamps are in [start, end). The result shares
+// the underlying array with s.
+func (s Series) Window(start, end int64) Series {
+	i := sort.Search(len(s), func(i int) bool { return s[i].Timestamp >= start })
+	j := sort.Search(len(s), func(i int) bool { return s[i].Timestamp >= end })
+	if j < i {
+		j = i
+	}
+	return s[i:j]
+}
+
+// Gaps returns the [start, end) timestamp ranges where two consecutive samples are further
+// apart than maxInterval.
+func (s Series) Gaps(maxInterval int64) [][2]int64 {
+	var gaps [][2]int64
+	for i := 1; i < len(s); i++ {
+		if s[i].Timestamp-s[i-1].Timestamp > maxInterval {
+			gaps = append(gaps, [2]int64{s[i-1].Timestamp, s[i].Timestamp})
+		}
+	}
+	return gaps
+}
@@ -0,0 +1,102 @@
+package timeseries
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// series returns a series of the timestamp and value pairs.
+func series(pairs ...float64) Series {
+	s := make(Series, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		s = append(s, helper.Sample{Timestamp: int64(pairs[i]), Value: pairs[i+1]})
+	}
+	return s
+}
+
+func TestNewSortsAndDeduplicates(t *testing.T) {
+	input := []helper.Sample{{Timestamp: 120, Value: 3}, {Timestamp: 0, Value: 1}, {Timestamp: 60, Value: 2}, {Timestamp: 0, Value: 4}}
+	original := append([]helper.Sample(nil), input...)
+
+	got := New(input)
+	if expected := series(0, 4, 60, 2, 120, 3); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if !reflect.DeepEqual(input, original) {
+		t.Errorf("expected the input to be left unmodified, got %v", input)
+	}
+	if got := New(nil); len(got) != 0 {
+		t.Errorf("expected an empty series, got %v", got)
+	}
+}
+
+func TestFromTimeSeries(t *testing.T) {
+	ts := v1alpha1.TimeSeries{
+		{Timestamp: 60, Value: "2"},
+		{Timestamp: 0, Value: "500m"},
+		{Timestamp: 30, Value: "invalid"},
+	}
+	s, err := FromTimeSeries(v1alpha1.ResourceCPU, ts)
+	var parseErrors helper.ParseErrors
+	if !errors.As(err, &parseErrors) || len(parseErrors) != 1 || parseErrors[0].Index != 2 {
+		t.Errorf("expected the invalid vector to be reported, got %v", err)
+	}
+	if expected := series(0, 500, 60, 2); !reflect.DeepEqual(s, expected) {
+		t.Errorf("expected %v, got %v", expected, s)
+	}
+	if back := s.TimeSeries(); len(back) != 2 || back[0].Value != "500" || back[1].Timestamp != 60 {
+		t.Errorf("unexpected time series %v", back)
+	}
+}
+
+func TestStartEnd(t *testing.T) {
+	if _, err := Series(nil).Start(); err != ErrEmpty {
+		t.Errorf("expected ErrEmpty, got %v", err)
+	}
+	if _, err := Series(nil).End(); err != ErrEmpty {
+		t.Errorf("expected ErrEmpty, got %v", err)
+	}
+	s := series(0, 1, 60, 2)
+	if start, _ := s.Start(); start != 0 {
+		t.Errorf("expected start 0, got %d", start)
+	}
+	if end, _ := s.End(); end != 60 {
+		t.Errorf("expected end 60, got %d", end)
+	}
+}
+
+func TestWindowBounds(t *testing.T) {
+	s := series(0, 1, 60, 2, 120, 3, 180, 4)
+	cases := []struct {
+		name       string
+		start, end int64
+		expected   Series
+	}{
+		{name: "start included, end excluded", start: 60, end: 180, expected: series(60, 2, 120, 3)},
+		{name: "between samples", start: 1, end: 121, expected: series(60, 2, 120, 3)},
+		{name: "everything", start: -100, end: 1000, expected: s},
+		{name: "before", start: -100, end: 0, expected: Series{}},
+		{name: "after", start: 181, end: 1000, expected: Series{}},
+		{name: "reversed", start: 180, end: 60, expected: Series{}},
+	}
+	for _, c := range cases {
+		if got := s.Window(c.start, c.end); len(got) != len(c.expected) || (len(got) > 0 && !reflect.DeepEqual(got, c.expected)) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestGaps(t *testing.T) {
+	s := series(0, 1, 60, 2, 300, 3, 360, 4, 600, 5)
+	expected := [][2]int64{{60, 300}, {360, 600}}
+	if got := s.Gaps(60); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := s.Gaps(240); got != nil {
+		t.Errorf("expected no gap, got %v", got)
+	}
+}
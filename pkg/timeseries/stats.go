@@ -0,0 +1,140 @@
+package timeseries
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// Max returns the sample with the largest value, the earliest one on ties.
+func (s Series) Max() (helper.Sample, error) {
+	if len(s) == 0 {
+		return helper.Sample{}, ErrEmpty
+	}
+	max := s[0]
+	for _, sample := range s[1:] {
+		if sample.Value > max.Value {
+			max = sample
+		}
+	}
+	return max, nil
+}
+
+// Min returns the sample with the smallest value, the earliest one on ties.
+func (s Series) Min() (helper.Sample, error) {
+	if len(s) == 0 {
+		return helper.Sample{}, ErrEmpty
+	}
+	min := s[0]
+	for _, sample := range s[1:] {
+		if sample.Value < min.Value {
+			min = sample
+		}
+	}
+	return min, nil
+}
+
+// Sum returns the sum of all values.
+func (s Series) Sum() float64 {
+	var sum float64
+	for _, sample := range s {
+		sum += sample.Value
+	}
+	return sum
+}
+
+// Mean returns the arithmetic mean of the values.
+func (s Series) Mean() (float64, error) {
+	if len(s) == 0 {
+		return 0, ErrEmpty
+	}
+	return s.Sum() / float64(len(s)), nil
+}
+
+// StdDev returns the population standard deviation of the values.
+func (s Series) StdDev() (float64, error) {
+	mean, err := s.Mean()
+	if err != nil {
+		return 0, err
+	}
+	var sum float64
+	for _, sample := range s {
+		d := sample.Value - mean
+		sum += d * d
+	}
+	return math.Sqrt(sum / float64(len(s))), nil
+}
+
+// Percentile returns the p-th percentile (0 <= p <= 1) of the values, linearly interpolated
+// between the closest ranks.
+func (s Series) Percentile(p float64) (float64, error) {
+	if len(s) == 0 {
+		return 0, ErrEmpty
+	}
+	return Percentile(s.Values(), p)
+}
+
+// Percentile returns the p-th percentile (0 <= p <= 1) of values, linearly interpolated
+// between the closest ranks. values is not modified.
+func Percentile(values []float64, p float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, ErrEmpty
+	}
+	if p < 0 || p > 1 || math.IsNaN(p) {
+		return 0, fmt.Errorf("percentile %v is out of [0, 1]", p)
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower], nil
+	}
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower)), nil
+}
+
+// Aggregator reduces the non-empty values of a window to a single value.
+type Aggregator func(values []float64) float64
+
+var (
+	// AggregateMax keeps the largest value.
+	AggregateMax Aggregator = func(values []float64) float64 {
+		max := values[0]
+		for _, v := range values[1:] {
+			max = math.Max(max, v)
+		}
+		return max
+	}
+	// AggregateMin keeps the smallest value.
+	AggregateMin Aggregator = func(values []float64) float64 {
+		min := values[0]
+		for _, v := range values[1:] {
+			min = math.Min(min, v)
+		}
+		return min
+	}
+	// AggregateMean keeps the arithmetic mean.
+	AggregateMean Aggregator = func(values []float64) float64 {
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values))
+	}
+	// AggregateLast keeps the latest value.
+	AggregateLast Aggregator = func(values []float64) float64 {
+		return values[len(values)-1]
+	}
+)
+
+// AggregatePercentile keeps the p-th percentile, p must be in [0, 1].
+func AggregatePercentile(p float64) Aggregator {
+	return func(values []float64) float64 {
+		v, _ := Percentile(values, p)
+		return v
+	}
+}
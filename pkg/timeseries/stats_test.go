@@ -0,0 +1,74 @@
+package timeseries
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMaxMin(t *testing.T) {
+	s := series(0, 2, 60, 5, 120, 5, 180, 1, 240, 1)
+	if max, _ := s.Max(); max.Timestamp != 60 || max.Value != 5 {
+		t.Errorf("expected the earliest maximum at 60, got %v", max)
+	}
+	if min, _ := s.Min(); min.Timestamp != 180 || min.Value != 1 {
+		t.Errorf("expected the earliest minimum at 180, got %v", min)
+	}
+	if _, err := Series(nil).Max(); err != ErrEmpty {
+		t.Errorf("expected ErrEmpty, got %v", err)
+	}
+	if _, err := Series(nil).Min(); err != ErrEmpty {
+		t.Errorf("expected ErrEmpty, got %v", err)
+	}
+}
+
+func TestMoments(t *testing.T) {
+	s := series(0, 2, 60, 4, 120, 4, 180, 4, 240, 5, 300, 5, 360, 7, 420, 9)
+	if sum := s.Sum(); sum != 40 {
+		t.Errorf("expected the sum 40, got %g", sum)
+	}
+	if mean, _ := s.Mean(); mean != 5 {
+		t.Errorf("expected the mean 5, got %g", mean)
+	}
+	if stddev, _ := s.StdDev(); stddev != 2 {
+		t.Errorf("expected the population standard deviation 2, got %g", stddev)
+	}
+	if _, err := Series(nil).Mean(); err != ErrEmpty {
+		t.Errorf("expected ErrEmpty, got %v", err)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := []float64{4, 1, 3, 2, 5}
+	cases := []struct {
+		p        float64
+		expected float64
+		err      bool
+	}{
+		{p: 0, expected: 1},
+		{p: 0.5, expected: 3},
+		{p: 0.9, expected: 4.6},
+		{p: 1, expected: 5},
+		{p: -0.1, err: true},
+		{p: 1.1, err: true},
+	}
+	for _, c := range cases {
+		got, err := Percentile(values, c.p)
+		switch {
+		case c.err && err == nil:
+			t.Errorf("Percentile(%v): expected an error, got %g", c.p, got)
+		case !c.err && err != nil:
+			t.Errorf("Percentile(%v): unexpected error: %v", c.p, err)
+		case !c.err && math.Abs(got-c.expected) > 1e-9:
+			t.Errorf("Percentile(%v): expected %g, got %g", c.p, c.expected, got)
+		}
+	}
+	if _, err := Percentile(nil, 0.5); err != ErrEmpty {
+		t.Errorf("expected ErrEmpty, got %v", err)
+	}
+	if values[0] != 4 {
+		t.Errorf("expected the values to be left unsorted, got %v", values)
+	}
+	if got := AggregatePercentile(0.5)(values); got != 3 {
+		t.Errorf("expected the median 3, got %g", got)
+	}
+}
@@ -0,0 +1,40 @@
+package timeseries
+
+import (
+	"fmt"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// PeakIn returns the sample with the largest value whose timestamp is in [start, end).
+// This is the value reported by PredictionModeInstant, e.g. the maximum over the next hour.
+func (s Series) PeakIn(start, end int64) (helper.Sample, error) {
+	return s.Window(start, end).Max()
+}
+
+// PeakAfter returns the sample with the largest value in [from, from+length).
+func (s Series) PeakAfter(from, length int64) (helper.Sample, error) {
+	return s.PeakIn(from, from+length)
+}
+
+// Rolling applies the aggregator on the trailing window (t-window, t] of every sample, so that
+// the result has the same timestamps as s. window must be positive.
+func (s Series) Rolling(window int64, aggregate Aggregator) (Series, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("window %d must be positive", window)
+	}
+	out := make(Series, 0, len(s))
+	start := 0
+	for i, sample := range s {
+		for s[start].Timestamp <= sample.Timestamp-window {
+			start++
+		}
+		out = append(out, helper.Sample{Timestamp: sample.Timestamp, Value: aggregate(s[start : i+1].Values())})
+	}
+	return out, nil
+}
+
+// RollingMax is Rolling with AggregateMax.
+func (s Series) RollingMax(window int64) (Series, error) {
+	return s.Rolling(window, AggregateMax)
+}
@@ -0,0 +1,59 @@
+package timeseries
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPeakIn(t *testing.T) {
+	s := series(0, 1, 60, 5, 120, 9, 180, 2)
+	cases := []struct {
+		name       string
+		start, end int64
+		expected   int64
+		err        error
+	}{
+		{name: "end excluded", start: 0, end: 120, expected: 60},
+		{name: "start included", start: 120, end: 240, expected: 120},
+		{name: "empty window", start: 181, end: 240, err: ErrEmpty},
+	}
+	for _, c := range cases {
+		peak, err := s.PeakIn(c.start, c.end)
+		if err != c.err {
+			t.Errorf("%s: expected the error %v, got %v", c.name, c.err, err)
+		} else if err == nil && peak.Timestamp != c.expected {
+			t.Errorf("%s: expected the peak at %d, got %v", c.name, c.expected, peak)
+		}
+	}
+	if peak, _ := s.PeakAfter(60, 60); peak.Timestamp != 60 {
+		t.Errorf("expected the peak at 60, got %v", peak)
+	}
+}
+
+func TestRolling(t *testing.T) {
+	s := series(0, 4, 60, 1, 120, 2, 180, 3, 300, 0)
+	cases := []struct {
+		name      string
+		window    int64
+		aggregate Aggregator
+		expected  Series
+	}{
+		{name: "trailing max", window: 120, aggregate: AggregateMax, expected: series(0, 4, 60, 4, 120, 2, 180, 3, 300, 0)},
+		{name: "trailing mean", window: 121, aggregate: AggregateMean, expected: series(0, 4, 60, 2.5, 120, 7.0/3, 180, 2, 300, 1.5)},
+		{name: "single sample window", window: 1, aggregate: AggregateMax, expected: s},
+	}
+	for _, c := range cases {
+		got, err := s.Rolling(c.window, c.aggregate)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+		} else if !reflect.DeepEqual(got, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, got)
+		}
+	}
+	if got, _ := s.RollingMax(120); !reflect.DeepEqual(got, cases[0].expected) {
+		t.Errorf("expected %v, got %v", cases[0].expected, got)
+	}
+	if _, err := s.Rolling(0, AggregateMax); err == nil {
+		t.Errorf("expected an error for the window 0")
+	}
+}
@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+)
+
+// ConvertPath is the path of the conversion webhook of the prediction CRDs.
+const ConvertPath = "/convert"
+
+// serveConversion serves the ConversionReview requests of the API server, converting the prediction
+// objects between v1alpha1 and v1beta1.
+func serveConversion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	review := &apiextensionsv1.ConversionReview{}
+	if _, _, err := codecs.UniversalDeserializer().Decode(body, nil, review); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode conversion review: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "conversion review has no request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = convertObjects(review.Request)
+	review.Request = nil
+
+	resp, err := json.Marshal(review)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to encode conversion review: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(resp); err != nil {
+		klog.Errorf("Failed to write conversion response: %v", err)
+	}
+}
+
+func convertObjects(req *apiextensionsv1.ConversionRequest) *apiextensionsv1.ConversionResponse {
+	resp := &apiextensionsv1.ConversionResponse{UID: req.UID}
+	gv, err := schema.ParseGroupVersion(req.DesiredAPIVersion)
+	if err != nil {
+		resp.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+		return resp
+	}
+	for i := range req.Objects {
+		converted, err := convertObject(req.Objects[i].Raw, gv)
+		if err != nil {
+			resp.ConvertedObjects = nil
+			resp.Result = metav1.Status{Status: metav1.StatusFailure, Message: fmt.Sprintf("failed to convert object %d: %v", i, err)}
+			return resp
+		}
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Object: converted})
+	}
+	resp.Result = metav1.Status{Status: metav1.StatusSuccess}
+	return resp
+}
+
+func convertObject(raw []byte, gv schema.GroupVersion) (runtime.Object, error) {
+	obj, gvk, err := codecs.UniversalDeserializer().Decode(raw, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if gvk.GroupVersion() == gv {
+		return obj, nil
+	}
+	return scheme.ConvertToVersion(obj, gv)
+}
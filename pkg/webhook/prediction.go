@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	predictionv1alpha1 "github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/validation"
+)
+
+func mutateNodePrediction(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	np := &predictionv1alpha1.NodePrediction{}
+	if err := decode(req, np, nil); err != nil {
+		return denied(http.StatusBadRequest, err)
+	}
+	defaulted := np.DeepCopy()
+	scheme.Default(defaulted)
+	return patched(np.Spec, defaulted.Spec)
+}
+
+func validateNodePrediction(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	np, oldNp := &predictionv1alpha1.NodePrediction{}, &predictionv1alpha1.NodePrediction{}
+	if err := decode(req, np, oldNp); err != nil {
+		return denied(http.StatusBadRequest, err)
+	}
+	var errs field.ErrorList
+	if req.Operation == admissionv1.Update {
+		errs = validation.ValidateNodePredictionUpdate(np, oldNp)
+	} else {
+		errs = validation.ValidateNodePrediction(np)
+	}
+	if len(errs) > 0 {
+		return denied(http.StatusUnprocessableEntity, errs.ToAggregate())
+	}
+	return allowed()
+}
+
+func mutatePodGroupPrediction(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	pgp := &predictionv1alpha1.PodGroupPrediction{}
+	if err := decode(req, pgp, nil); err != nil {
+		return denied(http.StatusBadRequest, err)
+	}
+	defaulted := pgp.DeepCopy()
+	scheme.Default(defaulted)
+	return patched(pgp.Spec, defaulted.Spec)
+}
+
+func validatePodGroupPrediction(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	pgp, oldPgp := &predictionv1alpha1.PodGroupPrediction{}, &predictionv1alpha1.PodGroupPrediction{}
+	if err := decode(req, pgp, oldPgp); err != nil {
+		return denied(http.StatusBadRequest, err)
+	}
+	var errs field.ErrorList
+	if req.Operation == admissionv1.Update {
+		errs = validation.ValidatePodGroupPredictionUpdate(pgp, oldPgp)
+	} else {
+		errs = validation.ValidatePodGroupPrediction(pgp)
+	}
+	if len(errs) > 0 {
+		return denied(http.StatusUnprocessableEntity, errs.ToAggregate())
+	}
+	return allowed()
+}
@@ -0,0 +1,172 @@
+// Package webhook implements the admission and conversion webhooks of the prediction resources.
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/klog/v2"
+
+	predictionv1alpha1 "github.com/gocrane-io/api/prediction/v1alpha1"
+	predictionv1beta1 "github.com/gocrane-io/api/prediction/v1beta1"
+)
+
+// +kubebuilder:webhook:path=/mutate-prediction-crane-io-v1alpha1-nodeprediction,mutating=true,failurePolicy=fail,sideEffects=None,groups=prediction.crane.io,resources=nodepredictions,verbs=create;update,versions=v1alpha1,name=mnodeprediction.prediction.crane.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-prediction-crane-io-v1alpha1-nodeprediction,mutating=false,failurePolicy=fail,sideEffects=None,groups=prediction.crane.io,resources=nodepredictions,verbs=create;update,versions=v1alpha1,name=vnodeprediction.prediction.crane.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-prediction-crane-io-v1alpha1-podgroupprediction,mutating=true,failurePolicy=fail,sideEffects=None,groups=prediction.crane.io,resources=podgrouppredictions,verbs=create;update,versions=v1alpha1,name=mpodgroupprediction.prediction.crane.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-prediction-crane-io-v1alpha1-podgroupprediction,mutating=false,failurePolicy=fail,sideEffects=None,groups=prediction.crane.io,resources=podgrouppredictions,verbs=create;update,versions=v1alpha1,name=vpodgroupprediction.prediction.crane.io,admissionReviewVersions=v1
+
+// Paths of the admission webhooks.
+const (
+	MutateNodePredictionPath       = "/mutate-prediction-crane-io-v1alpha1-nodeprediction"
+	ValidateNodePredictionPath     = "/validate-prediction-crane-io-v1alpha1-nodeprediction"
+	MutatePodGroupPredictionPath   = "/mutate-prediction-crane-io-v1alpha1-podgroupprediction"
+	ValidatePodGroupPredictionPath = "/validate-prediction-crane-io-v1alpha1-podgroupprediction"
+)
+
+const maxRequestBodyBytes = 3 * 1024 * 1024
+
+var (
+	scheme = runtime.NewScheme()
+	codecs = serializer.NewCodecFactory(scheme)
+)
+
+func init() {
+	utilruntime.Must(admissionv1.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
+	utilruntime.Must(predictionv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(predictionv1beta1.AddToScheme(scheme))
+}
+
+// admitFunc handles an admission request and returns the response, the uid of the response is set by the caller.
+type admitFunc func(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse
+
+// NewHandler returns the http handler serving all the admission and conversion webhooks of the prediction resources.
+func NewHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(MutateNodePredictionPath, serveAdmission(mutateNodePrediction))
+	mux.Handle(ValidateNodePredictionPath, serveAdmission(validateNodePrediction))
+	mux.Handle(MutatePodGroupPredictionPath, serveAdmission(mutatePodGroupPrediction))
+	mux.Handle(ValidatePodGroupPredictionPath, serveAdmission(validatePodGroupPrediction))
+	mux.HandleFunc(ConvertPath, serveConversion)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return mux
+}
+
+func serveAdmission(admit admitFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, fmt.Sprintf("method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+		if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+			http.Error(w, fmt.Sprintf("content type %q is not supported, expect application/json", contentType), http.StatusUnsupportedMediaType)
+			return
+		}
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read request body: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		review := &admissionv1.AdmissionReview{}
+		if _, _, err := codecs.UniversalDeserializer().Decode(body, nil, review); err != nil {
+			http.Error(w, fmt.Sprintf("failed to decode admission review: %v", err), http.StatusBadRequest)
+			return
+		}
+		if review.Request == nil {
+			http.Error(w, "admission review has no request", http.StatusBadRequest)
+			return
+		}
+
+		response := admit(review.Request)
+		response.UID = review.Request.UID
+		review.Response = response
+		review.Request = nil
+
+		resp, err := json.Marshal(review)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to encode admission review: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(resp); err != nil {
+			klog.Errorf("Failed to write admission response: %v", err)
+		}
+	})
+}
+
+func allowed() *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+func denied(code int32, err error) *admissionv1.AdmissionResponse {
+	reason := metav1.StatusReasonInvalid
+	switch code {
+	case http.StatusBadRequest:
+		reason = metav1.StatusReasonBadRequest
+	case http.StatusInternalServerError:
+		reason = metav1.StatusReasonInternalError
+	}
+	return &admissionv1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    code,
+			Reason:  reason,
+			Message: err.Error(),
+		},
+	}
+}
+
+// patched returns a response replacing the spec of the object with the given one if it differs from the original.
+func patched(original, spec interface{}) *admissionv1.AdmissionResponse {
+	originalSpec, err := json.Marshal(original)
+	if err != nil {
+		return denied(http.StatusInternalServerError, err)
+	}
+	newSpec, err := json.Marshal(spec)
+	if err != nil {
+		return denied(http.StatusInternalServerError, err)
+	}
+	if string(originalSpec) == string(newSpec) {
+		return allowed()
+	}
+	patch, err := json.Marshal([]map[string]interface{}{{
+		"op":    "add",
+		"path":  "/spec",
+		"value": json.RawMessage(newSpec),
+	}})
+	if err != nil {
+		return denied(http.StatusInternalServerError, err)
+	}
+	patchType := admissionv1.PatchTypeJSONPatch
+	return &admissionv1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: &patchType,
+	}
+}
+
+// decode decodes the object and, for updates, the old object of the request unless oldObj is nil.
+func decode(req *admissionv1.AdmissionRequest, obj, oldObj runtime.Object) error {
+	if err := json.Unmarshal(req.Object.Raw, obj); err != nil {
+		return fmt.Errorf("failed to decode object: %v", err)
+	}
+	if req.Operation == admissionv1.Update && oldObj != nil {
+		if err := json.Unmarshal(req.OldObject.Raw, oldObj); err != nil {
+			return fmt.Errorf("failed to decode old object: %v", err)
+		}
+	}
+	return nil
+}
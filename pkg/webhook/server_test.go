@@ -0,0 +1,241 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	predictionv1alpha1 "github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/predictiontest"
+	predictionv1beta1 "github.com/gocrane-io/api/prediction/v1beta1"
+)
+
+// newPodGroupPrediction returns the stored fixture of the mode.
+func newPodGroupPrediction(mode predictionv1alpha1.PredictionMode) *predictionv1alpha1.PodGroupPrediction {
+	pgp := predictiontest.NewPodGroupPrediction("default", "web")
+	pgp.ResourceVersion = "1"
+	pgp.Spec.Mode = mode
+	return pgp
+}
+
+// newNodePrediction returns the stored fixture of the node.
+func newNodePrediction(nodeName string) *predictionv1alpha1.NodePrediction {
+	np := predictiontest.NewNodePrediction(nodeName)
+	np.ResourceVersion = "1"
+	return np
+}
+
+// defaulted returns a defaulted copy of the object, as admitted by the mutating webhook.
+func defaulted(obj runtime.Object) runtime.Object {
+	obj = obj.DeepCopyObject()
+	scheme.Default(obj)
+	return obj
+}
+
+func post(t *testing.T, server *httptest.Server, path string, body interface{}) *http.Response {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("failed to encode request: %v", err)
+	}
+	resp, err := http.Post(server.URL+path, "application/json", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to post to %s: %v", path, err)
+	}
+	return resp
+}
+
+func admit(t *testing.T, server *httptest.Server, path string, operation admissionv1.Operation, obj, oldObj runtime.Object) *admissionv1.AdmissionResponse {
+	t.Helper()
+	req := &admissionv1.AdmissionRequest{
+		UID:       types.UID("uid"),
+		Operation: operation,
+		Object:    runtime.RawExtension{Object: obj},
+	}
+	if oldObj != nil {
+		req.OldObject = runtime.RawExtension{Object: oldObj}
+	}
+	review := &admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: admissionv1.SchemeGroupVersion.String(), Kind: "AdmissionReview"},
+		Request:  req,
+	}
+
+	resp := post(t, server, path, review)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", resp.StatusCode)
+	}
+	review = &admissionv1.AdmissionReview{}
+	if err := json.NewDecoder(resp.Body).Decode(review); err != nil {
+		t.Fatalf("failed to decode admission review: %v", err)
+	}
+	if review.Response == nil || review.Response.UID != req.UID {
+		t.Fatalf("unexpected admission response %+v", review.Response)
+	}
+	return review.Response
+}
+
+func TestMutatePodGroupPrediction(t *testing.T) {
+	server := httptest.NewServer(NewHandler())
+	defer server.Close()
+
+	pgp := newPodGroupPrediction("")
+	pgp.Spec.MetricPredictionConfigs[0].DSP = &predictionv1alpha1.DspConfig{}
+	resp := admit(t, server, MutatePodGroupPredictionPath, admissionv1.Create, pgp, nil)
+	if !resp.Allowed || resp.PatchType == nil || *resp.PatchType != admissionv1.PatchTypeJSONPatch {
+		t.Fatalf("expected an allowed JSON patch, got %+v", resp)
+	}
+	var patch []struct {
+		Op    string                                    `json:"op"`
+		Path  string                                    `json:"path"`
+		Value predictionv1alpha1.PodGroupPredictionSpec `json:"value"`
+	}
+	if err := json.Unmarshal(resp.Patch, &patch); err != nil {
+		t.Fatalf("failed to decode patch: %v", err)
+	}
+	if len(patch) != 1 || patch[0].Op != "add" || patch[0].Path != "/spec" {
+		t.Fatalf("unexpected patch %s", resp.Patch)
+	}
+	spec := patch[0].Value
+	if spec.Mode != predictionv1alpha1.DefaultPredictionMode {
+		t.Errorf("expected mode %s, got %s", predictionv1alpha1.DefaultPredictionMode, spec.Mode)
+	}
+	if got := spec.MetricPredictionConfigs[0].DSP.Estimators.FFT.LowAmplitudeThreshold; got != predictionv1alpha1.DefaultCPULowAmplitudeThreshold {
+		t.Errorf("expected cpu low amplitude threshold %s, got %s", predictionv1alpha1.DefaultCPULowAmplitudeThreshold, got)
+	}
+
+	resp = admit(t, server, MutatePodGroupPredictionPath, admissionv1.Create, defaulted(pgp), nil)
+	if !resp.Allowed || resp.Patch != nil {
+		t.Errorf("expected a defaulted object to be allowed without patch, got %+v", resp)
+	}
+}
+
+func TestValidatePodGroupPrediction(t *testing.T) {
+	server := httptest.NewServer(NewHandler())
+	defer server.Close()
+
+	valid := defaulted(newPodGroupPrediction(predictionv1alpha1.PredictionModeInstant)).(*predictionv1alpha1.PodGroupPrediction)
+	noMetrics := valid.DeepCopy()
+	noMetrics.Spec.MetricPredictionConfigs = nil
+	rangeMode := defaulted(newPodGroupPrediction(predictionv1alpha1.PredictionModeRange)).(*predictionv1alpha1.PodGroupPrediction)
+	morePods := valid.DeepCopy()
+	morePods.Spec.Pods = append(morePods.Spec.Pods, "web-1")
+
+	cases := []struct {
+		name      string
+		operation admissionv1.Operation
+		obj       runtime.Object
+		oldObj    runtime.Object
+		allowed   bool
+	}{
+		{name: "valid create", operation: admissionv1.Create, obj: valid, allowed: true},
+		{name: "create without metric", operation: admissionv1.Create, obj: noMetrics},
+		{name: "valid update", operation: admissionv1.Update, obj: morePods, oldObj: valid, allowed: true},
+		{name: "update of the mode", operation: admissionv1.Update, obj: rangeMode, oldObj: valid},
+		{name: "update without metric", operation: admissionv1.Update, obj: noMetrics, oldObj: valid},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp := admit(t, server, ValidatePodGroupPredictionPath, c.operation, c.obj, c.oldObj)
+			if resp.Allowed != c.allowed {
+				t.Fatalf("expected allowed %v, got %+v", c.allowed, resp)
+			}
+			if !c.allowed && resp.Result.Code != http.StatusUnprocessableEntity {
+				t.Errorf("expected code %d, got %d", http.StatusUnprocessableEntity, resp.Result.Code)
+			}
+		})
+	}
+}
+
+func TestValidateNodePrediction(t *testing.T) {
+	server := httptest.NewServer(NewHandler())
+	defer server.Close()
+
+	valid := defaulted(newNodePrediction("node-1"))
+	if resp := admit(t, server, ValidateNodePredictionPath, admissionv1.Create, valid, nil); !resp.Allowed {
+		t.Errorf("expected a valid node prediction to be allowed, got %+v", resp)
+	}
+	moved := defaulted(newNodePrediction("node-2"))
+	moved.(*predictionv1alpha1.NodePrediction).Name = "node-1"
+	if resp := admit(t, server, ValidateNodePredictionPath, admissionv1.Update, moved, valid); resp.Allowed {
+		t.Errorf("expected an update of the node name to be denied")
+	}
+}
+
+func TestServeAdmissionRejectsInvalidRequests(t *testing.T) {
+	server := httptest.NewServer(NewHandler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + ValidatePodGroupPredictionPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected status %d for GET, got %d", http.StatusMethodNotAllowed, resp.StatusCode)
+	}
+
+	resp, err = http.Post(server.URL+ValidatePodGroupPredictionPath, "text/plain", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("expected status %d for text/plain, got %d", http.StatusUnsupportedMediaType, resp.StatusCode)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	server := httptest.NewServer(NewHandler())
+	defer server.Close()
+
+	pgp := defaulted(newPodGroupPrediction(predictionv1alpha1.PredictionModeInstant)).(*predictionv1alpha1.PodGroupPrediction)
+	pgp.Status.Aggregation = predictionv1alpha1.Prediction{"cpu": {{Value: "2500", Timestamp: 60}}}
+	pgp.Status.Containers = map[string]predictionv1alpha1.Prediction{
+		"default/web-0/nginx": {"cpu": {{Value: "2500", Timestamp: 60}}},
+	}
+	review := &apiextensionsv1.ConversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: apiextensionsv1.SchemeGroupVersion.String(), Kind: "ConversionReview"},
+		Request: &apiextensionsv1.ConversionRequest{
+			UID:               types.UID("uid"),
+			DesiredAPIVersion: predictionv1beta1.SchemeGroupVersion.String(),
+			Objects:           []runtime.RawExtension{{Object: pgp}},
+		},
+	}
+
+	resp := post(t, server, ConvertPath, review)
+	defer resp.Body.Close()
+	review = &apiextensionsv1.ConversionReview{}
+	if err := json.NewDecoder(resp.Body).Decode(review); err != nil {
+		t.Fatalf("failed to decode conversion review: %v", err)
+	}
+	if review.Response == nil || review.Response.Result.Status != metav1.StatusSuccess || len(review.Response.ConvertedObjects) != 1 {
+		t.Fatalf("unexpected conversion response %+v", review.Response)
+	}
+
+	converted := &predictionv1beta1.PodGroupPrediction{}
+	if err := json.Unmarshal(review.Response.ConvertedObjects[0].Raw, converted); err != nil {
+		t.Fatalf("failed to decode converted object: %v", err)
+	}
+	if converted.APIVersion != predictionv1beta1.SchemeGroupVersion.String() {
+		t.Errorf("expected api version %s, got %s", predictionv1beta1.SchemeGroupVersion, converted.APIVersion)
+	}
+	if got := converted.Status.Aggregation["cpu"][0].Value; got.MilliValue() != 2500 {
+		t.Errorf("expected 2500 milli cores, got %s", got.String())
+	}
+	if len(converted.Status.Containers) != 1 {
+		t.Fatalf("expected one container, got %+v", converted.Status.Containers)
+	}
+	if c := converted.Status.Containers[0]; c.Namespace != "default" || c.PodName != "web-0" || c.ContainerName != "nginx" {
+		t.Errorf("unexpected container key %s/%s/%s", c.Namespace, c.PodName, c.ContainerName)
+	}
+}
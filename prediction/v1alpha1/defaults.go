@@ -0,0 +1,256 @@
+package v1alpha1
+
+import (
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// DefaultPredictionMode is the mode of a prediction that does not specify one.
+	DefaultPredictionMode = PredictionModeInstant
+	// DefaultPredictionLength is the length of a PredictionModeRange prediction that does not specify one.
+	DefaultPredictionLength = 24 * time.Hour
+	// DefaultPeriod is the default step of the prediction time series.
+	DefaultPeriod = time.Minute
+
+	// DefaultSampleInterval is the default sampling interval of the history metrics.
+	DefaultSampleInterval = "1m"
+	// DefaultHistoryLength is the default length of the history metrics used by the DSP and custom algorithms.
+	DefaultHistoryLength = "72h"
+
+	// DefaultEnsembleMemberWeight is the default weight of a member of an ensemble.
+	DefaultEnsembleMemberWeight = 1
+
+	// DefaultMarginFraction is the default fraction added on top of the FFT estimation.
+	DefaultMarginFraction = "0.15"
+	// DefaultLowAmplitudeThreshold is the default amplitude under which spectrum items are dropped, for metrics
+	// other than cpu and memory.
+	DefaultLowAmplitudeThreshold = "1.0"
+	// DefaultCPULowAmplitudeThreshold is the default low amplitude threshold of cpu: 10 milli cores.
+	DefaultCPULowAmplitudeThreshold = "10"
+	// DefaultMemoryLowAmplitudeThreshold is the default low amplitude threshold of memory: 1MiB.
+	DefaultMemoryLowAmplitudeThreshold = "1048576"
+	// DefaultMinNumOfSpectrumItems is the default minimum number of spectrum items kept by the FFT estimator.
+	DefaultMinNumOfSpectrumItems = 3
+	// DefaultMaxNumOfSpectrumItems is the default maximum number of spectrum items kept by the FFT estimator.
+	DefaultMaxNumOfSpectrumItems = 100
+
+	// DefaultHoltWintersAlpha is the default smoothing factor of the level of the Holt-Winters estimator.
+	DefaultHoltWintersAlpha = "0.5"
+	// DefaultHoltWintersBeta is the default smoothing factor of the trend of the Holt-Winters estimator.
+	DefaultHoltWintersBeta = "0.1"
+	// DefaultHoltWintersGamma is the default smoothing factor of the seasonality of the Holt-Winters estimator.
+	DefaultHoltWintersGamma = "0.3"
+	// DefaultHoltWintersSeasonLength is the default season length of the Holt-Winters estimator: a day.
+	DefaultHoltWintersSeasonLength = "24h"
+
+	// DefaultMinSampleWeight is the default minimum weight of a sample in a decaying histogram.
+	DefaultMinSampleWeight = "1e-5"
+	// DefaultHistogramMaxValue is the default largest value that a histogram can distinguish, for metrics other
+	// than cpu and memory.
+	DefaultHistogramMaxValue = "10000"
+	// DefaultCPUHistogramMaxValue is the default largest cpu value of a histogram: 1000 cores.
+	DefaultCPUHistogramMaxValue = "1e6"
+	// DefaultMemoryHistogramMaxValue is the default largest memory value of a histogram: 1TB.
+	DefaultMemoryHistogramMaxValue = "1e12"
+	// DefaultHistogramEpsilon is the default weight under which a histogram bucket is considered empty.
+	DefaultHistogramEpsilon = "1e-10"
+	// DefaultHistogramHalfLife is the default half life of the samples of a decaying histogram.
+	DefaultHistogramHalfLife = "24h"
+	// DefaultHistogramBucketSize is the default bucket size of a linear histogram.
+	DefaultHistogramBucketSize = "10"
+	// DefaultCPUHistogramBucketSize is the default cpu bucket size of a linear histogram: 100 milli cores.
+	DefaultCPUHistogramBucketSize = "100"
+	// DefaultMemoryHistogramBucketSize is the default memory bucket size of a linear histogram: 1GB.
+	DefaultMemoryHistogramBucketSize = "1e9"
+	// DefaultHistogramFirstBucketSize is the default size of the first bucket of an exponential histogram.
+	DefaultHistogramFirstBucketSize = "1"
+	// DefaultCPUHistogramFirstBucketSize is the default cpu size of the first bucket of an exponential
+	// histogram: 10 milli cores.
+	DefaultCPUHistogramFirstBucketSize = "10"
+	// DefaultMemoryHistogramFirstBucketSize is the default memory size of the first bucket of an exponential
+	// histogram: 10MB.
+	DefaultMemoryHistogramFirstBucketSize = "1e7"
+	// DefaultHistogramBucketSizeGrowthRatio is the default growth ratio of the buckets of an exponential histogram.
+	DefaultHistogramBucketSizeGrowthRatio = "0.05"
+)
+
+// DefaultHighFrequencyThreshold is the default frequency, in Hz, above which spectrum items are dropped: once per hour.
+var DefaultHighFrequencyThreshold = strconv.FormatFloat(1.0/time.Hour.Seconds(), 'g', -1, 64)
+
+func init() {
+	localSchemeBuilder.Register(addDefaultingFuncs)
+}
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+func SetDefaults_NodePredictionResourceSpec(obj *NodePredictionResourceSpec) {
+	if obj.Mode == "" {
+		obj.Mode = DefaultPredictionMode
+	}
+	if obj.Period.Duration == 0 {
+		obj.Period = metav1.Duration{Duration: DefaultPeriod}
+	}
+}
+
+func SetDefaults_PodGroupPredictionSpec(obj *PodGroupPredictionSpec) {
+	if obj.Mode == "" {
+		obj.Mode = DefaultPredictionMode
+	}
+	if obj.Mode == PredictionModeRange && obj.PredictionLength.Duration == 0 {
+		obj.PredictionLength = metav1.Duration{Duration: DefaultPredictionLength}
+	}
+}
+
+// SetDefaults_AlgorithmProviderConfig sets the defaults that depend on the unit of the metric: cpu is in milli
+// cores and memory in bytes. The defaults of the other metrics are set by the defaulters of the algorithms.
+func SetDefaults_AlgorithmProviderConfig(obj *AlgorithmProviderConfig) {
+	resource := ResourceName(obj.MetricName)
+	if obj.Source != nil {
+		resource = ""
+		if obj.Source.Resource != nil {
+			resource = obj.Source.Resource.Name
+		}
+	}
+	if resource != ResourceCPU && resource != ResourceMemory {
+		return
+	}
+
+	setResourceDefaults(resource, obj.DSP, obj.Percentile)
+	if obj.Ensemble != nil {
+		for i := range obj.Ensemble.Members {
+			setResourceDefaults(resource, obj.Ensemble.Members[i].DSP, obj.Ensemble.Members[i].Percentile)
+		}
+	}
+	for i := range obj.Fallbacks {
+		setResourceDefaults(resource, obj.Fallbacks[i].DSP, obj.Fallbacks[i].Percentile)
+	}
+}
+
+func setResourceDefaults(resource ResourceName, dsp *DspConfig, percentile *PercentileConfig) {
+	lowAmplitudeThreshold, maxValue, bucketSize, firstBucketSize := DefaultCPULowAmplitudeThreshold,
+		DefaultCPUHistogramMaxValue, DefaultCPUHistogramBucketSize, DefaultCPUHistogramFirstBucketSize
+	if resource == ResourceMemory {
+		lowAmplitudeThreshold, maxValue, bucketSize, firstBucketSize = DefaultMemoryLowAmplitudeThreshold,
+			DefaultMemoryHistogramMaxValue, DefaultMemoryHistogramBucketSize, DefaultMemoryHistogramFirstBucketSize
+	}
+
+	if dsp != nil {
+		// the estimators are defaulted first, an unset estimator list is the FFT estimator.
+		SetDefaults_DspConfig(dsp)
+		if fft := dsp.Estimators.FFT; fft != nil && fft.LowAmplitudeThreshold == "" {
+			fft.LowAmplitudeThreshold = lowAmplitudeThreshold
+		}
+	}
+	if percentile != nil {
+		h := &percentile.Histogram
+		if h.MaxValue == "" {
+			h.MaxValue = maxValue
+		}
+		if h.BucketSize == "" {
+			h.BucketSize = bucketSize
+		}
+		if h.FirstBucketSize == "" {
+			h.FirstBucketSize = firstBucketSize
+		}
+	}
+}
+
+func SetDefaults_DspConfig(obj *DspConfig) {
+	if obj.SampleInterval == "" {
+		obj.SampleInterval = DefaultSampleInterval
+	}
+	if obj.HistoryLength == "" {
+		obj.HistoryLength = DefaultHistoryLength
+	}
+	if obj.Estimators == nil {
+		obj.Estimators = &EstimatorConfigs{}
+	}
+	if obj.Estimators.MaxValue == nil && obj.Estimators.FFT == nil &&
+		obj.Estimators.HoltWinters == nil && obj.Estimators.LinearRegression == nil {
+		obj.Estimators.FFT = &FFTEstimatorConfig{}
+	}
+}
+
+func SetDefaults_CustomAlgorithmConfig(obj *CustomAlgorithmConfig) {
+	if obj.SampleInterval == "" {
+		obj.SampleInterval = DefaultSampleInterval
+	}
+	if obj.HistoryLength == "" {
+		obj.HistoryLength = DefaultHistoryLength
+	}
+}
+
+func SetDefaults_EnsembleMember(obj *EnsembleMember) {
+	if obj.Weight == 0 {
+		obj.Weight = DefaultEnsembleMemberWeight
+	}
+}
+
+func SetDefaults_FFTEstimatorConfig(obj *FFTEstimatorConfig) {
+	if obj.MarginFraction == "" {
+		obj.MarginFraction = DefaultMarginFraction
+	}
+	if obj.LowAmplitudeThreshold == "" {
+		obj.LowAmplitudeThreshold = DefaultLowAmplitudeThreshold
+	}
+	if obj.HighFrequencyThreshold == "" {
+		obj.HighFrequencyThreshold = DefaultHighFrequencyThreshold
+	}
+	if obj.MinNumOfSpectrumItems == 0 {
+		obj.MinNumOfSpectrumItems = DefaultMinNumOfSpectrumItems
+	}
+	if obj.MaxNumOfSpectrumItems == 0 {
+		obj.MaxNumOfSpectrumItems = DefaultMaxNumOfSpectrumItems
+	}
+}
+
+func SetDefaults_HoltWintersEstimatorConfig(obj *HoltWintersEstimatorConfig) {
+	if obj.Alpha == "" {
+		obj.Alpha = DefaultHoltWintersAlpha
+	}
+	if obj.Beta == "" {
+		obj.Beta = DefaultHoltWintersBeta
+	}
+	if obj.Gamma == "" {
+		obj.Gamma = DefaultHoltWintersGamma
+	}
+	if obj.SeasonLength == "" {
+		obj.SeasonLength = DefaultHoltWintersSeasonLength
+	}
+}
+
+func SetDefaults_PercentileConfig(obj *PercentileConfig) {
+	if obj.SampleInterval == "" {
+		obj.SampleInterval = DefaultSampleInterval
+	}
+	if obj.MinSampleWeight == "" {
+		obj.MinSampleWeight = DefaultMinSampleWeight
+	}
+}
+
+func SetDefaults_HistogramConfig(obj *HistogramConfig) {
+	if obj.MaxValue == "" {
+		obj.MaxValue = DefaultHistogramMaxValue
+	}
+	if obj.Epsilon == "" {
+		obj.Epsilon = DefaultHistogramEpsilon
+	}
+	if obj.HalfLife == "" {
+		obj.HalfLife = DefaultHistogramHalfLife
+	}
+	if obj.BucketSize == "" {
+		obj.BucketSize = DefaultHistogramBucketSize
+	}
+	if obj.FirstBucketSize == "" {
+		obj.FirstBucketSize = DefaultHistogramFirstBucketSize
+	}
+	if obj.BucketSizeGrowthRatio == "" {
+		obj.BucketSizeGrowthRatio = DefaultHistogramBucketSizeGrowthRatio
+	}
+}
@@ -0,0 +1,179 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return scheme
+}
+
+func TestSetDefaultsAlgorithmProviderConfig(t *testing.T) {
+	cases := []struct {
+		name                  string
+		config                AlgorithmProviderConfig
+		lowAmplitudeThreshold string
+		maxValue              string
+		bucketSize            string
+		firstBucketSize       string
+	}{
+		{
+			name:                  "cpu",
+			config:                AlgorithmProviderConfig{MetricName: "cpu"},
+			lowAmplitudeThreshold: DefaultCPULowAmplitudeThreshold,
+			maxValue:              DefaultCPUHistogramMaxValue,
+			bucketSize:            DefaultCPUHistogramBucketSize,
+			firstBucketSize:       DefaultCPUHistogramFirstBucketSize,
+		},
+		{
+			name:                  "memory",
+			config:                AlgorithmProviderConfig{MetricName: "memory"},
+			lowAmplitudeThreshold: DefaultMemoryLowAmplitudeThreshold,
+			maxValue:              DefaultMemoryHistogramMaxValue,
+			bucketSize:            DefaultMemoryHistogramBucketSize,
+			firstBucketSize:       DefaultMemoryHistogramFirstBucketSize,
+		},
+		{
+			name:                  "generic",
+			config:                AlgorithmProviderConfig{MetricName: "requests"},
+			lowAmplitudeThreshold: DefaultLowAmplitudeThreshold,
+			maxValue:              DefaultHistogramMaxValue,
+			bucketSize:            DefaultHistogramBucketSize,
+			firstBucketSize:       DefaultHistogramFirstBucketSize,
+		},
+		{
+			name: "memory resource source",
+			config: AlgorithmProviderConfig{MetricName: "working-set", Source: &MetricSourceSpec{
+				Type: ResourceMetricSourceType, Resource: &ResourceMetricSource{Name: ResourceMemory},
+			}},
+			lowAmplitudeThreshold: DefaultMemoryLowAmplitudeThreshold,
+			maxValue:              DefaultMemoryHistogramMaxValue,
+			bucketSize:            DefaultMemoryHistogramBucketSize,
+			firstBucketSize:       DefaultMemoryHistogramFirstBucketSize,
+		},
+		{
+			name: "cpu prometheus source",
+			config: AlgorithmProviderConfig{MetricName: "cpu", Source: &MetricSourceSpec{
+				Type: PrometheusMetricSourceType, Prometheus: &PrometheusMetricSource{Query: "sum(rate(container_cpu_usage_seconds_total[5m]))"},
+			}},
+			lowAmplitudeThreshold: DefaultLowAmplitudeThreshold,
+			maxValue:              DefaultHistogramMaxValue,
+			bucketSize:            DefaultHistogramBucketSize,
+			firstBucketSize:       DefaultHistogramFirstBucketSize,
+		},
+	}
+	scheme := newScheme(t)
+	for _, c := range cases {
+		config := c.config
+		config.DSP = &DspConfig{}
+		config.Percentile = &PercentileConfig{}
+		config.Fallbacks = []AlgorithmConfig{{DSP: &DspConfig{}}, {Percentile: &PercentileConfig{}}}
+		ensemble := AlgorithmProviderConfig{
+			MetricName: c.config.MetricName,
+			Source:     c.config.Source,
+			Ensemble: &EnsembleConfig{Members: []EnsembleMember{
+				{AlgorithmConfig: AlgorithmConfig{DSP: &DspConfig{}}},
+				{AlgorithmConfig: AlgorithmConfig{Percentile: &PercentileConfig{}}, Weight: 3},
+			}},
+		}
+		pgp := &PodGroupPrediction{Spec: PodGroupPredictionSpec{MetricPredictionConfigs: []AlgorithmProviderConfig{config, ensemble}}}
+		scheme.Default(pgp)
+
+		config, ensemble = pgp.Spec.MetricPredictionConfigs[0], pgp.Spec.MetricPredictionConfigs[1]
+		dsps := []*DspConfig{config.DSP, config.Fallbacks[0].DSP, ensemble.Ensemble.Members[0].DSP}
+		for i, dsp := range dsps {
+			if dsp.SampleInterval != DefaultSampleInterval || dsp.HistoryLength != DefaultHistoryLength {
+				t.Errorf("%s: dsp %d: unexpected durations %s and %s", c.name, i, dsp.SampleInterval, dsp.HistoryLength)
+			}
+			fft := dsp.Estimators.FFT
+			if fft == nil {
+				t.Errorf("%s: dsp %d: expected the fft estimator by default", c.name, i)
+				continue
+			}
+			if fft.LowAmplitudeThreshold != c.lowAmplitudeThreshold {
+				t.Errorf("%s: dsp %d: expected the low amplitude threshold %s, got %s", c.name, i, c.lowAmplitudeThreshold, fft.LowAmplitudeThreshold)
+			}
+			if fft.MarginFraction != DefaultMarginFraction || fft.HighFrequencyThreshold != DefaultHighFrequencyThreshold ||
+				fft.MinNumOfSpectrumItems != DefaultMinNumOfSpectrumItems || fft.MaxNumOfSpectrumItems != DefaultMaxNumOfSpectrumItems {
+				t.Errorf("%s: dsp %d: unexpected fft defaults %+v", c.name, i, fft)
+			}
+		}
+		percentiles := []*PercentileConfig{config.Percentile, config.Fallbacks[1].Percentile, ensemble.Ensemble.Members[1].Percentile}
+		for i, percentile := range percentiles {
+			h := percentile.Histogram
+			if h.MaxValue != c.maxValue || h.BucketSize != c.bucketSize || h.FirstBucketSize != c.firstBucketSize {
+				t.Errorf("%s: percentile %d: expected the histogram %s, %s, %s, got %s, %s, %s", c.name, i,
+					c.maxValue, c.bucketSize, c.firstBucketSize, h.MaxValue, h.BucketSize, h.FirstBucketSize)
+			}
+			if h.Epsilon != DefaultHistogramEpsilon || h.HalfLife != DefaultHistogramHalfLife || h.BucketSizeGrowthRatio != DefaultHistogramBucketSizeGrowthRatio {
+				t.Errorf("%s: percentile %d: unexpected histogram defaults %+v", c.name, i, h)
+			}
+			if percentile.SampleInterval != DefaultSampleInterval || percentile.MinSampleWeight != DefaultMinSampleWeight {
+				t.Errorf("%s: percentile %d: unexpected defaults %+v", c.name, i, percentile)
+			}
+		}
+		if weights := [2]int32{ensemble.Ensemble.Members[0].Weight, ensemble.Ensemble.Members[1].Weight}; weights != [2]int32{DefaultEnsembleMemberWeight, 3} {
+			t.Errorf("%s: unexpected weights %v", c.name, weights)
+		}
+	}
+}
+
+func TestSetDefaultsKeepsValues(t *testing.T) {
+	np := &NodePrediction{Spec: NodePredictionResourceSpec{MetricPredictionConfigs: []AlgorithmProviderConfig{{
+		MetricName: "cpu",
+		DSP: &DspConfig{SampleInterval: "5m", Estimators: &EstimatorConfigs{
+			MaxValue: &MaxValueEstimatorConfig{},
+			FFT:      &FFTEstimatorConfig{LowAmplitudeThreshold: "50"},
+		}},
+		Percentile: &PercentileConfig{Histogram: HistogramConfig{MaxValue: "5000", BucketSize: "20"}},
+		Custom:     &CustomAlgorithmConfig{Name: "example.com/seasonal-naive", HistoryLength: "24h"},
+	}}}}
+	newScheme(t).Default(np)
+
+	if np.Spec.Mode != DefaultPredictionMode || np.Spec.Period.Duration != DefaultPeriod {
+		t.Errorf("unexpected mode %s and period %s", np.Spec.Mode, np.Spec.Period.Duration)
+	}
+	config := np.Spec.MetricPredictionConfigs[0]
+	if config.DSP.SampleInterval != "5m" || config.DSP.HistoryLength != DefaultHistoryLength {
+		t.Errorf("unexpected durations %s and %s", config.DSP.SampleInterval, config.DSP.HistoryLength)
+	}
+	if config.DSP.Estimators.FFT.LowAmplitudeThreshold != "50" {
+		t.Errorf("expected the low amplitude threshold to be kept, got %s", config.DSP.Estimators.FFT.LowAmplitudeThreshold)
+	}
+	if h := config.Percentile.Histogram; h.MaxValue != "5000" || h.BucketSize != "20" || h.FirstBucketSize != DefaultCPUHistogramFirstBucketSize {
+		t.Errorf("unexpected histogram %+v", h)
+	}
+	if config.Custom.SampleInterval != DefaultSampleInterval || config.Custom.HistoryLength != "24h" {
+		t.Errorf("unexpected custom durations %s and %s", config.Custom.SampleInterval, config.Custom.HistoryLength)
+	}
+}
+
+func TestSetDefaultsPodGroupPredictionSpec(t *testing.T) {
+	cases := []struct {
+		name             string
+		spec             PodGroupPredictionSpec
+		mode             PredictionMode
+		predictionLength time.Duration
+	}{
+		{name: "no mode", mode: DefaultPredictionMode},
+		{name: "range", spec: PodGroupPredictionSpec{Mode: PredictionModeRange}, mode: PredictionModeRange, predictionLength: DefaultPredictionLength},
+		{name: "range with length", spec: PodGroupPredictionSpec{Mode: PredictionModeRange, PredictionLength: metav1.Duration{Duration: time.Hour}}, mode: PredictionModeRange, predictionLength: time.Hour},
+	}
+	scheme := newScheme(t)
+	for _, c := range cases {
+		pgp := &PodGroupPrediction{Spec: c.spec}
+		scheme.Default(pgp)
+		if pgp.Spec.Mode != c.mode || pgp.Spec.PredictionLength.Duration != c.predictionLength {
+			t.Errorf("%s: expected %s for %s, got %s for %s", c.name, c.mode, c.predictionLength, pgp.Spec.Mode, pgp.Spec.PredictionLength.Duration)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package helper
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+)
+
+// Accuracy is the accuracy of the predictions of a metric over a window, as recorded by a MetricAccuracy.
+type Accuracy struct {
+	// MAPE is the mean absolute percentage error as a fraction, nil when every observed value is zero.
+	MAPE *float64
+	// RMSE is the root mean square error in the unit of the metric.
+	RMSE float64
+	// Coverage is the fraction of the observed values within the bounds of their prediction, nil when no
+	// prediction has bounds.
+	Coverage *float64
+	// Samples is the number of compared values.
+	Samples int
+	// Start and End are the unix timestamps of the first and the last compared values.
+	Start int64
+	End   int64
+}
+
+// AccuracyThreshold is the minimum accuracy a consumer requires from a prediction before acting on it, for
+// example before scaling a workload. The zero value of a field does not require anything.
+type AccuracyThreshold struct {
+	// MaxMAPE is the largest acceptable MAPE, a prediction without MAPE does not meet it.
+	MaxMAPE float64
+	// MaxRMSE is the largest acceptable RMSE, in the unit of the metric.
+	MaxRMSE float64
+	// MinCoverage is the smallest acceptable coverage, a prediction without coverage does not meet it.
+	MinCoverage float64
+	// MinSamples is the smallest number of compared values.
+	MinSamples int
+	// MaxAge is the largest acceptable age of the end of the evaluated window.
+	MaxAge time.Duration
+}
+
+// ParseAccuracy parses the accuracy of a metric whose RMSE is interpreted as the given resource.
+func ParseAccuracy(resourceName v1alpha1.ResourceName, a *v1alpha1.MetricAccuracy) (Accuracy, error) {
+	accuracy := Accuracy{Samples: int(a.Samples), Start: a.Window.Start.Unix(), End: a.Window.End.Unix()}
+	var err error
+	if accuracy.MAPE, err = parseOptionalValue("", a.MAPE); err != nil {
+		return Accuracy{}, fmt.Errorf("mape: %v", err)
+	}
+	if accuracy.RMSE, err = ParseValue(resourceName, a.RMSE); err != nil {
+		return Accuracy{}, fmt.Errorf("rmse: %v", err)
+	}
+	if accuracy.Coverage, err = parseOptionalValue("", a.Coverage); err != nil {
+		return Accuracy{}, fmt.Errorf("coverage: %v", err)
+	}
+	return accuracy, nil
+}
+
+// FormatAccuracy is the inverse of ParseAccuracy.
+func FormatAccuracy(metricName string, a Accuracy) v1alpha1.MetricAccuracy {
+	formatted := v1alpha1.MetricAccuracy{
+		MetricName: metricName,
+		RMSE:       FormatValue(a.RMSE),
+		Samples:    int32(a.Samples),
+		Window: v1alpha1.EvaluationWindow{
+			Start: metav1.Unix(a.Start, 0),
+			End:   metav1.Unix(a.End, 0),
+		},
+	}
+	if a.MAPE != nil {
+		formatted.MAPE = FormatValue(*a.MAPE)
+	}
+	if a.Coverage != nil {
+		formatted.Coverage = FormatValue(*a.Coverage)
+	}
+	return formatted
+}
+
+// MergeAccuracy returns the accuracy of the values compared by two evaluations, b following a. The RMSE is
+// exact, the MAPE and the coverage are the means of both weighted by their number of samples.
+func MergeAccuracy(a, b Accuracy) Accuracy {
+	switch {
+	case a.Samples == 0:
+		return b
+	case b.Samples == 0:
+		return a
+	}
+	na, nb := float64(a.Samples), float64(b.Samples)
+	merged := Accuracy{
+		MAPE:     mergeMean(a.MAPE, na, b.MAPE, nb),
+		RMSE:     math.Sqrt((a.RMSE*a.RMSE*na + b.RMSE*b.RMSE*nb) / (na + nb)),
+		Coverage: mergeMean(a.Coverage, na, b.Coverage, nb),
+		Samples:  a.Samples + b.Samples,
+		Start:    a.Start,
+		End:      b.End,
+	}
+	if b.Start < merged.Start {
+		merged.Start = b.Start
+	}
+	if a.End > merged.End {
+		merged.End = a.End
+	}
+	return merged
+}
+
+func mergeMean(a *float64, na float64, b *float64, nb float64) *float64 {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	}
+	mean := (*a*na + *b*nb) / (na + nb)
+	return &mean
+}
+
+// Trim returns the accuracy of the part of the window from start, assuming the compared values are spread
+// evenly over the window: the number of samples is reduced in proportion, the errors are unchanged.
+func (a Accuracy) Trim(start int64) Accuracy {
+	switch {
+	case a.Samples == 0 || start <= a.Start:
+		return a
+	case start > a.End:
+		return Accuracy{}
+	}
+	trimmed := a
+	trimmed.Samples = int(math.Round(float64(a.Samples) * float64(a.End-start) / float64(a.End-a.Start)))
+	if trimmed.Samples < 1 {
+		trimmed.Samples = 1
+	}
+	trimmed.Start = start
+	return trimmed
+}
+
+// GetAccuracy returns the accuracy of the metric, nil if it has not been evaluated.
+func GetAccuracy(accuracies []v1alpha1.MetricAccuracy, metricName string) *v1alpha1.MetricAccuracy {
+	for i := range accuracies {
+		if accuracies[i].MetricName == metricName {
+			return &accuracies[i]
+		}
+	}
+	return nil
+}
+
+// Check returns an error describing the first requirement of the threshold the accuracy does not meet, nil if
+// it meets all of them at the given time.
+func (t AccuracyThreshold) Check(a Accuracy, now time.Time) error {
+	switch {
+	case a.Samples < t.MinSamples:
+		return fmt.Errorf("%d samples were evaluated, at least %d are required", a.Samples, t.MinSamples)
+	case t.MaxMAPE > 0 && a.MAPE == nil:
+		return fmt.Errorf("mape is unknown, at most %s is required", FormatValue(t.MaxMAPE))
+	case t.MaxMAPE > 0 && *a.MAPE > t.MaxMAPE:
+		return fmt.Errorf("mape %s is above %s", FormatValue(*a.MAPE), FormatValue(t.MaxMAPE))
+	case t.MaxRMSE > 0 && a.RMSE > t.MaxRMSE:
+		return fmt.Errorf("rmse %s is above %s", FormatValue(a.RMSE), FormatValue(t.MaxRMSE))
+	case t.MinCoverage > 0 && a.Coverage == nil:
+		return fmt.Errorf("coverage is unknown, at least %s is required", FormatValue(t.MinCoverage))
+	case t.MinCoverage > 0 && *a.Coverage < t.MinCoverage:
+		return fmt.Errorf("coverage %s is below %s", FormatValue(*a.Coverage), FormatValue(t.MinCoverage))
+	case t.MaxAge > 0 && now.Sub(time.Unix(a.End, 0)) > t.MaxAge:
+		return fmt.Errorf("the accuracy was evaluated until %s, more than %s ago", time.Unix(a.End, 0).UTC().Format(time.RFC3339), t.MaxAge)
+	}
+	return nil
+}
+
+// CheckAccuracy parses the accuracy of the metric and checks it against the threshold, it returns an error if
+// the metric has not been evaluated.
+func CheckAccuracy(accuracies []v1alpha1.MetricAccuracy, metricName string, threshold AccuracyThreshold, now time.Time) error {
+	a := GetAccuracy(accuracies, metricName)
+	if a == nil {
+		return fmt.Errorf("the accuracy of metric %s has not been evaluated", metricName)
+	}
+	accuracy, err := ParseAccuracy(v1alpha1.ResourceName(metricName), a)
+	if err != nil {
+		return fmt.Errorf("invalid accuracy of metric %s: %v", metricName, err)
+	}
+	if err := threshold.Check(accuracy, now); err != nil {
+		return fmt.Errorf("metric %s: %v", metricName, err)
+	}
+	return nil
+}
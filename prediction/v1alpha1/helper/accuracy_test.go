@@ -0,0 +1,104 @@
+package helper
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+)
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func TestAccuracyThresholdCheck(t *testing.T) {
+	now := time.Unix(7200, 0)
+	accurate := Accuracy{MAPE: float(0.1), RMSE: 50, Coverage: float(0.9), Samples: 60, Start: 3600, End: 7140}
+	cases := []struct {
+		name      string
+		threshold AccuracyThreshold
+		accuracy  Accuracy
+		err       string
+	}{
+		{name: "no requirement", accuracy: Accuracy{}},
+		{name: "accurate", threshold: AccuracyThreshold{MaxMAPE: 0.2, MaxRMSE: 100, MinCoverage: 0.8, MinSamples: 10, MaxAge: time.Hour}, accuracy: accurate},
+		{name: "too few samples", threshold: AccuracyThreshold{MinSamples: 100}, accuracy: accurate, err: "60 samples"},
+		{name: "mape above", threshold: AccuracyThreshold{MaxMAPE: 0.05}, accuracy: accurate, err: "mape 0.1 is above 0.05"},
+		{name: "unknown mape", threshold: AccuracyThreshold{MaxMAPE: 0.2}, accuracy: Accuracy{RMSE: 50}, err: "mape is unknown"},
+		{name: "rmse above", threshold: AccuracyThreshold{MaxRMSE: 10}, accuracy: accurate, err: "rmse 50 is above 10"},
+		{name: "coverage below", threshold: AccuracyThreshold{MinCoverage: 0.95}, accuracy: accurate, err: "coverage 0.9 is below 0.95"},
+		{name: "unknown coverage", threshold: AccuracyThreshold{MinCoverage: 0.8}, accuracy: Accuracy{RMSE: 50}, err: "coverage is unknown"},
+		{name: "too old", threshold: AccuracyThreshold{MaxAge: time.Second}, accuracy: accurate, err: "more than 1s ago"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.threshold.Check(c.accuracy, now)
+			switch {
+			case c.err == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)):
+				t.Errorf("expected an error containing %q, got %v", c.err, err)
+			}
+		})
+	}
+}
+
+func TestCheckAccuracy(t *testing.T) {
+	now := time.Unix(7200, 0)
+	accuracies := []v1alpha1.MetricAccuracy{
+		FormatAccuracy("cpu", Accuracy{MAPE: float(0.1), RMSE: 50, Samples: 60, Start: 3600, End: 7140}),
+	}
+	threshold := AccuracyThreshold{MaxMAPE: 0.2, MinSamples: 10, MaxAge: time.Hour}
+	if err := CheckAccuracy(accuracies, "cpu", threshold, now); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := CheckAccuracy(accuracies, "memory", threshold, now); err == nil {
+		t.Errorf("expected an error for a metric that has not been evaluated")
+	}
+}
+
+func TestMergeAccuracy(t *testing.T) {
+	a := Accuracy{MAPE: float(0.1), RMSE: 3, Coverage: float(1), Samples: 1, Start: 0, End: 0}
+	b := Accuracy{MAPE: float(0.4), RMSE: 4, Samples: 3, Start: 60, End: 180}
+
+	merged := MergeAccuracy(a, b)
+	if merged.Samples != 4 || merged.Start != 0 || merged.End != 180 {
+		t.Errorf("unexpected samples %d in [%d, %d]", merged.Samples, merged.Start, merged.End)
+	}
+	if rmse := math.Sqrt((9 + 3*16) / 4.0); math.Abs(merged.RMSE-rmse) > 1e-9 {
+		t.Errorf("expected rmse %g, got %g", rmse, merged.RMSE)
+	}
+	if mape := (0.1 + 3*0.4) / 4; merged.MAPE == nil || math.Abs(*merged.MAPE-mape) > 1e-9 {
+		t.Errorf("expected mape %g, got %v", mape, merged.MAPE)
+	}
+	if merged.Coverage == nil || *merged.Coverage != 1 {
+		t.Errorf("expected the coverage of the only bounded evaluation, got %v", merged.Coverage)
+	}
+	if got := MergeAccuracy(Accuracy{}, b); got.Samples != b.Samples || got.RMSE != b.RMSE {
+		t.Errorf("expected the merge with an empty accuracy to be the other one, got %+v", got)
+	}
+}
+
+func TestAccuracyTrim(t *testing.T) {
+	a := Accuracy{RMSE: 5, Samples: 100, Start: 0, End: 1000}
+	cases := []struct {
+		start   int64
+		samples int
+	}{
+		{start: -10, samples: 100},
+		{start: 250, samples: 75},
+		{start: 1000, samples: 1},
+		{start: 1001, samples: 0},
+	}
+	for _, c := range cases {
+		trimmed := a.Trim(c.start)
+		if trimmed.Samples != c.samples {
+			t.Errorf("expected %d samples from %d, got %d", c.samples, c.start, trimmed.Samples)
+		}
+		if c.samples > 0 && c.start > a.Start && (trimmed.Start != c.start || trimmed.RMSE != a.RMSE) {
+			t.Errorf("unexpected accuracy trimmed from %d: %+v", c.start, trimmed)
+		}
+	}
+}
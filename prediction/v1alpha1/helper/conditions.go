@@ -0,0 +1,139 @@
+package helper
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+)
+
+// statusPriority lists the conditions deciding the status of a prediction, the first true one wins.
+var statusPriority = []struct {
+	condition v1alpha1.PredictionConditionType
+	status    v1alpha1.PredictionStatus
+}{
+	{v1alpha1.PredictionConditionFailed, v1alpha1.PredictionStatusFailed},
+	{v1alpha1.PredictionConditionFinished, v1alpha1.PredictionStatusFinished},
+	{v1alpha1.PredictionConditionStale, v1alpha1.PredictionStatusStale},
+	{v1alpha1.PredictionConditionPredicting, v1alpha1.PredictionStatusPredicting},
+	{v1alpha1.PredictionConditionCharging, v1alpha1.PredictionStatusCharging},
+}
+
+// GetCondition returns the condition of the given type, nil if there is none.
+func GetCondition(conditions []v1alpha1.PredictionCondition, conditionType v1alpha1.PredictionConditionType) *v1alpha1.PredictionCondition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsConditionTrue returns whether the condition of the given type is present and true.
+func IsConditionTrue(conditions []v1alpha1.PredictionCondition, conditionType v1alpha1.PredictionConditionType) bool {
+	condition := GetCondition(conditions, conditionType)
+	return condition != nil && condition.Status == v1.ConditionTrue
+}
+
+// SetCondition adds the condition or updates the existing one of the same type, and returns whether anything
+// but the probe time changed. The transition time is only bumped when the status of the condition changes,
+// it is set to now unless the new condition carries one. The probe time is set to now unless the new
+// condition carries one.
+func SetCondition(conditions *[]v1alpha1.PredictionCondition, newCondition v1alpha1.PredictionCondition) bool {
+	now := metav1.Now()
+	if newCondition.LastProbeTime.IsZero() {
+		newCondition.LastProbeTime = now
+	}
+
+	existing := GetCondition(*conditions, newCondition.Type)
+	if existing == nil {
+		if newCondition.LastTransitionTime.IsZero() {
+			newCondition.LastTransitionTime = now
+		}
+		*conditions = append(*conditions, newCondition)
+		return true
+	}
+
+	changed := false
+	if existing.Status != newCondition.Status {
+		existing.Status = newCondition.Status
+		existing.LastTransitionTime = newCondition.LastTransitionTime
+		if existing.LastTransitionTime.IsZero() {
+			existing.LastTransitionTime = now
+		}
+		changed = true
+	}
+	if existing.Reason != newCondition.Reason {
+		existing.Reason = newCondition.Reason
+		changed = true
+	}
+	if existing.Message != newCondition.Message {
+		existing.Message = newCondition.Message
+		changed = true
+	}
+	existing.LastProbeTime = newCondition.LastProbeTime
+	return changed
+}
+
+// RemoveCondition removes the condition of the given type and returns whether it was present.
+func RemoveCondition(conditions *[]v1alpha1.PredictionCondition, conditionType v1alpha1.PredictionConditionType) bool {
+	if GetCondition(*conditions, conditionType) == nil {
+		return false
+	}
+	kept := make([]v1alpha1.PredictionCondition, 0, len(*conditions)-1)
+	for _, condition := range *conditions {
+		if condition.Type != conditionType {
+			kept = append(kept, condition)
+		}
+	}
+	*conditions = kept
+	return true
+}
+
+// StatusFromConditions derives the status of a prediction from its conditions. Failed takes precedence over
+// Finished, Stale, Predicting and Charging in that order; without any true condition the prediction is NotStarted.
+func StatusFromConditions(conditions []v1alpha1.PredictionCondition) v1alpha1.PredictionStatus {
+	for _, p := range statusPriority {
+		if IsConditionTrue(conditions, p.condition) {
+			return p.status
+		}
+	}
+	return v1alpha1.PredictionStatusNotStarted
+}
+
+// SetPodGroupPredictionCondition sets the condition of a PodGroupPrediction and updates its status accordingly,
+// it returns whether the status changed.
+func SetPodGroupPredictionCondition(status *v1alpha1.PodGroupPredictionStatus, condition v1alpha1.PredictionCondition) bool {
+	changed := SetCondition(&status.Conditions, condition)
+	return syncStatus(&status.Status, status.Conditions) || changed
+}
+
+// RemovePodGroupPredictionCondition removes the condition of a PodGroupPrediction and updates its status
+// accordingly, it returns whether the status changed.
+func RemovePodGroupPredictionCondition(status *v1alpha1.PodGroupPredictionStatus, conditionType v1alpha1.PredictionConditionType) bool {
+	changed := RemoveCondition(&status.Conditions, conditionType)
+	return syncStatus(&status.Status, status.Conditions) || changed
+}
+
+// SetNodePredictionCondition sets the condition of a NodePrediction and updates its status accordingly,
+// it returns whether the status changed.
+func SetNodePredictionCondition(status *v1alpha1.NodePredictionResourceStatus, condition v1alpha1.PredictionCondition) bool {
+	changed := SetCondition(&status.Conditions, condition)
+	return syncStatus(&status.Status, status.Conditions) || changed
+}
+
+// RemoveNodePredictionCondition removes the condition of a NodePrediction and updates its status accordingly,
+// it returns whether the status changed.
+func RemoveNodePredictionCondition(status *v1alpha1.NodePredictionResourceStatus, conditionType v1alpha1.PredictionConditionType) bool {
+	changed := RemoveCondition(&status.Conditions, conditionType)
+	return syncStatus(&status.Status, status.Conditions) || changed
+}
+
+func syncStatus(status *v1alpha1.PredictionStatus, conditions []v1alpha1.PredictionCondition) bool {
+	derived := StatusFromConditions(conditions)
+	if *status == derived {
+		return false
+	}
+	*status = derived
+	return true
+}
@@ -0,0 +1,137 @@
+package helper
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+)
+
+func TestSetCondition(t *testing.T) {
+	transition := metav1.NewTime(time.Date(2021, 11, 24, 8, 0, 0, 0, time.UTC))
+	probe := metav1.NewTime(transition.Add(time.Minute))
+	conditions := []v1alpha1.PredictionCondition{{
+		Type:               v1alpha1.PredictionConditionPredicting,
+		Status:             v1.ConditionTrue,
+		Reason:             "Predicted",
+		LastTransitionTime: transition,
+		LastProbeTime:      transition,
+	}}
+
+	// the same status only bumps the probe time.
+	if SetCondition(&conditions, v1alpha1.PredictionCondition{Type: v1alpha1.PredictionConditionPredicting, Status: v1.ConditionTrue, Reason: "Predicted", LastProbeTime: probe}) {
+		t.Errorf("expected no change when only the probe time changes")
+	}
+	if c := conditions[0]; !c.LastTransitionTime.Equal(&transition) || !c.LastProbeTime.Equal(&probe) {
+		t.Errorf("expected the transition time %v and the probe time %v, got %v and %v", transition, probe, c.LastTransitionTime, c.LastProbeTime)
+	}
+
+	// a new reason is a change but not a transition.
+	if !SetCondition(&conditions, v1alpha1.PredictionCondition{Type: v1alpha1.PredictionConditionPredicting, Status: v1.ConditionTrue, Reason: "Refreshed"}) {
+		t.Errorf("expected a change of the reason")
+	}
+	if c := conditions[0]; c.Reason != "Refreshed" || !c.LastTransitionTime.Equal(&transition) {
+		t.Errorf("expected the reason Refreshed and the transition time %v, got %v and %v", transition, c.Reason, c.LastTransitionTime)
+	}
+
+	// a new status is a transition.
+	before := time.Now().Add(-time.Second)
+	if !SetCondition(&conditions, v1alpha1.PredictionCondition{Type: v1alpha1.PredictionConditionPredicting, Status: v1.ConditionFalse, Reason: "Refreshed"}) {
+		t.Errorf("expected a change of the status")
+	}
+	if c := conditions[0]; c.Status != v1.ConditionFalse || c.LastTransitionTime.Time.Before(before) {
+		t.Errorf("expected the status False with a new transition time, got %v at %v", c.Status, c.LastTransitionTime)
+	}
+
+	// a transition keeps the transition time of the new condition.
+	if !SetCondition(&conditions, v1alpha1.PredictionCondition{Type: v1alpha1.PredictionConditionPredicting, Status: v1.ConditionTrue, LastTransitionTime: transition}) {
+		t.Errorf("expected a change of the status")
+	}
+	if c := conditions[0]; !c.LastTransitionTime.Equal(&transition) {
+		t.Errorf("expected the transition time %v, got %v", transition, c.LastTransitionTime)
+	}
+
+	// a new type is appended.
+	if !SetCondition(&conditions, v1alpha1.PredictionCondition{Type: v1alpha1.PredictionConditionStale, Status: v1.ConditionTrue}) {
+		t.Errorf("expected a new condition")
+	}
+	if len(conditions) != 2 || conditions[1].LastTransitionTime.IsZero() || conditions[1].LastProbeTime.IsZero() {
+		t.Errorf("expected a new condition with its times set, got %v", conditions)
+	}
+}
+
+func TestStatusFromConditions(t *testing.T) {
+	condition := func(conditionType v1alpha1.PredictionConditionType, status v1.ConditionStatus) v1alpha1.PredictionCondition {
+		return v1alpha1.PredictionCondition{Type: conditionType, Status: status}
+	}
+	cases := []struct {
+		name       string
+		conditions []v1alpha1.PredictionCondition
+		expected   v1alpha1.PredictionStatus
+	}{
+		{
+			name:     "no condition",
+			expected: v1alpha1.PredictionStatusNotStarted,
+		},
+		{
+			name:       "no true condition",
+			conditions: []v1alpha1.PredictionCondition{condition(v1alpha1.PredictionConditionFailed, v1.ConditionFalse), condition(v1alpha1.PredictionConditionCharging, v1.ConditionUnknown)},
+			expected:   v1alpha1.PredictionStatusNotStarted,
+		},
+		{
+			name:       "charging",
+			conditions: []v1alpha1.PredictionCondition{condition(v1alpha1.PredictionConditionCharging, v1.ConditionTrue)},
+			expected:   v1alpha1.PredictionStatusCharging,
+		},
+		{
+			name:       "predicting over charging",
+			conditions: []v1alpha1.PredictionCondition{condition(v1alpha1.PredictionConditionCharging, v1.ConditionTrue), condition(v1alpha1.PredictionConditionPredicting, v1.ConditionTrue)},
+			expected:   v1alpha1.PredictionStatusPredicting,
+		},
+		{
+			name:       "stale over predicting",
+			conditions: []v1alpha1.PredictionCondition{condition(v1alpha1.PredictionConditionPredicting, v1.ConditionTrue), condition(v1alpha1.PredictionConditionStale, v1.ConditionTrue)},
+			expected:   v1alpha1.PredictionStatusStale,
+		},
+		{
+			name:       "finished over stale",
+			conditions: []v1alpha1.PredictionCondition{condition(v1alpha1.PredictionConditionStale, v1.ConditionTrue), condition(v1alpha1.PredictionConditionFinished, v1.ConditionTrue)},
+			expected:   v1alpha1.PredictionStatusFinished,
+		},
+		{
+			name: "failed over everything",
+			conditions: []v1alpha1.PredictionCondition{
+				condition(v1alpha1.PredictionConditionCharging, v1.ConditionTrue),
+				condition(v1alpha1.PredictionConditionPredicting, v1.ConditionTrue),
+				condition(v1alpha1.PredictionConditionStale, v1.ConditionTrue),
+				condition(v1alpha1.PredictionConditionFinished, v1.ConditionTrue),
+				condition(v1alpha1.PredictionConditionFailed, v1.ConditionTrue),
+			},
+			expected: v1alpha1.PredictionStatusFailed,
+		},
+	}
+	for _, c := range cases {
+		if got := StatusFromConditions(c.conditions); got != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestSetPodGroupPredictionCondition(t *testing.T) {
+	status := &v1alpha1.PodGroupPredictionStatus{}
+	if !SetPodGroupPredictionCondition(status, v1alpha1.PredictionCondition{Type: v1alpha1.PredictionConditionPredicting, Status: v1.ConditionTrue}) {
+		t.Errorf("expected a change")
+	}
+	if status.Status != v1alpha1.PredictionStatusPredicting {
+		t.Errorf("expected the status Predicting, got %s", status.Status)
+	}
+	if !RemovePodGroupPredictionCondition(status, v1alpha1.PredictionConditionPredicting) || status.Status != v1alpha1.PredictionStatusNotStarted {
+		t.Errorf("expected the status NotStarted, got %s", status.Status)
+	}
+	if RemovePodGroupPredictionCondition(status, v1alpha1.PredictionConditionPredicting) {
+		t.Errorf("expected no change when removing a missing condition")
+	}
+}
@@ -0,0 +1,67 @@
+package helper
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultQuantiles are the quantiles provided by the estimators that estimate the distribution of the value.
+var DefaultQuantiles = []float64{0.5, 0.9, 0.99}
+
+const (
+	// LowerBoundQuantile is the quantile of the lower bound of the confidence interval of a value.
+	LowerBoundQuantile = 0.05
+	// UpperBoundQuantile is the quantile of the upper bound of the confidence interval of a value, the interval
+	// holds 90% of the distribution.
+	UpperBoundQuantile = 0.95
+)
+
+// QuantileName returns the name of a quantile in (0, 1) in the Quantiles of a Vector, p followed by the
+// percentile, for example p50 or p99.9.
+func QuantileName(q float64) string {
+	// round away the error of the multiplication, 0.07*100 is 7.000000000000001.
+	percentile := math.Round(q*100*1e9) / 1e9
+	return "p" + strconv.FormatFloat(percentile, 'f', -1, 64)
+}
+
+// ParseQuantileName is the inverse of QuantileName, it rejects the percentiles 0 and 100, the minimum and the
+// maximum are not quantiles an estimator provides.
+func ParseQuantileName(name string) (float64, error) {
+	if !strings.HasPrefix(name, "p") {
+		return 0, fmt.Errorf("quantile %q must be p followed by a percentile", name)
+	}
+	percentile, err := strconv.ParseFloat(name[1:], 64)
+	if err != nil || percentile <= 0 || percentile >= 100 {
+		return 0, fmt.Errorf("quantile %q must be p followed by a percentile in (0, 100)", name)
+	}
+	return percentile / 100, nil
+}
+
+// Quantile returns the quantile q in [0, 1] of the value, if it is known.
+func (s Sample) Quantile(q float64) (float64, bool) {
+	value, ok := s.Quantiles[QuantileName(q)]
+	return value, ok
+}
+
+// SetQuantile sets the quantile q in [0, 1] of the value.
+func (s *Sample) SetQuantile(q, value float64) {
+	if s.Quantiles == nil {
+		s.Quantiles = make(map[string]float64)
+	}
+	s.Quantiles[QuantileName(q)] = value
+}
+
+// Bounds returns the confidence interval of the value, if it is known.
+func (s Sample) Bounds() (lower, upper float64, ok bool) {
+	if s.LowerBound == nil || s.UpperBound == nil {
+		return 0, 0, false
+	}
+	return *s.LowerBound, *s.UpperBound, true
+}
+
+// SetBounds sets the confidence interval of the value.
+func (s *Sample) SetBounds(lower, upper float64) {
+	s.LowerBound, s.UpperBound = &lower, &upper
+}
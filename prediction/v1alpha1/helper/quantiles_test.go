@@ -0,0 +1,40 @@
+package helper
+
+import "testing"
+
+func TestQuantileName(t *testing.T) {
+	cases := []struct {
+		name     string
+		quantile float64
+	}{
+		{name: "p50", quantile: 0.5},
+		{name: "p90", quantile: 0.9},
+		{name: "p99", quantile: 0.99},
+		{name: "p99.9", quantile: 0.999},
+		{name: "p7", quantile: 0.07},
+		{name: "p5", quantile: LowerBoundQuantile},
+		{name: "p95", quantile: UpperBoundQuantile},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if name := QuantileName(c.quantile); name != c.name {
+				t.Errorf("expected the name %s of %g, got %s", c.name, c.quantile, name)
+			}
+			q, err := ParseQuantileName(c.name)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if name := QuantileName(q); name != c.name {
+				t.Errorf("expected %s to round trip, got %s from %g", c.name, name, q)
+			}
+		})
+	}
+}
+
+func TestParseQuantileNameErrors(t *testing.T) {
+	for _, name := range []string{"", "p", "p0", "p100", "p101", "p-5", "x50", "50", "pabc", "P50"} {
+		if q, err := ParseQuantileName(name); err == nil {
+			t.Errorf("%q: expected an error, got %g", name, q)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+// Package helper provides typed access to the string encoded values of the prediction API and helpers to
+// manage the conditions of the prediction status.
+package helper
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+)
+
+// Sample is a parsed Vector.
+type Sample struct {
+	// Timestamp is the unix timestamp of the sample, in seconds.
+	Timestamp int64 `json:"timestamp"`
+	// Value is the value of the sample. For ResourceCPU it is in milli cores, for ResourceMemory it is in bytes.
+	Value float64 `json:"value"`
+	// LowerBound and UpperBound are the confidence interval of the value, nil when it is unknown.
+	LowerBound *float64 `json:"lowerBound,omitempty"`
+	UpperBound *float64 `json:"upperBound,omitempty"`
+	// Quantiles are the quantiles of the value keyed by QuantileName, nil when they are unknown.
+	Quantiles map[string]float64 `json:"quantiles,omitempty"`
+}
+
+// Samples is a parsed TimeSeries.
+type Samples []Sample
+
+// QuantitySample is a Vector whose values are expressed as resource.Quantity.
+type QuantitySample struct {
+	Timestamp  int64
+	Value      resource.Quantity
+	LowerBound *resource.Quantity
+	UpperBound *resource.Quantity
+	Quantiles  map[string]resource.Quantity
+}
+
+// ParseError describes a Vector of a TimeSeries that could not be parsed.
+type ParseError struct {
+	// Index is the position of the vector in the TimeSeries.
+	Index int
+	// Timestamp is the timestamp of the vector, zero if the vector is nil.
+	Timestamp int64
+	// Value is the raw value of the vector.
+	Value string
+	// Err is the underlying parse error.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("vector %d (timestamp %d, value %q): %v", e.Index, e.Timestamp, e.Value, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors is the list of vectors that failed to parse. It is returned as an error
+// together with the samples that were parsed successfully.
+type ParseErrors []*ParseError
+
+func (e ParseErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%d invalid vectors: [%s]", len(e), strings.Join(msgs, ", "))
+}
+
+// MetricParseError describes the vectors that failed to parse in a Prediction.
+type MetricParseError struct {
+	// Errors is the failed vectors keyed by metric name.
+	Errors map[string]ParseErrors
+}
+
+func (e *MetricParseError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, metric := range sortedKeys(e.Errors) {
+		msgs = append(msgs, fmt.Sprintf("metric %s: %v", metric, e.Errors[metric]))
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ParseValue parses the value of a vector. Plain numbers are returned as is. Quantity strings
+// such as "500m" or "1Gi" are accepted for ResourceCPU and ResourceMemory and converted to
+// milli cores and bytes respectively.
+func ParseValue(resourceName v1alpha1.ResourceName, value string) (float64, error) {
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return 0, fmt.Errorf("value %q is not a finite number", value)
+		}
+		return f, nil
+	}
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("value %q is neither a number nor a quantity", value)
+	}
+	return FromQuantity(resourceName, q), nil
+}
+
+// FormatValue formats a value for a Vector using the shortest representation that round trips.
+func FormatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// ParseTimeSeries parses all vectors of a TimeSeries whose values are interpreted as the given resource.
+// Vectors that cannot be parsed are skipped and reported in a ParseErrors error; the returned samples
+// always contain every vector that was parsed successfully, in their original order.
+func ParseTimeSeries(resourceName v1alpha1.ResourceName, ts v1alpha1.TimeSeries) (Samples, error) {
+	samples := make(Samples, 0, len(ts))
+	var errs ParseErrors
+	for i, v := range ts {
+		if v == nil {
+			errs = append(errs, &ParseError{Index: i, Err: fmt.Errorf("vector is nil")})
+			continue
+		}
+		sample, err := ParseVector(resourceName, v)
+		if err != nil {
+			errs = append(errs, &ParseError{Index: i, Timestamp: v.Timestamp, Value: v.Value, Err: err})
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	if len(errs) > 0 {
+		return samples, errs
+	}
+	return samples, nil
+}
+
+// ParseVector parses the value, the bounds and the quantiles of a vector whose values are interpreted as the
+// given resource.
+func ParseVector(resourceName v1alpha1.ResourceName, v *v1alpha1.Vector) (Sample, error) {
+	value, err := ParseValue(resourceName, v.Value)
+	if err != nil {
+		return Sample{}, err
+	}
+	sample := Sample{Timestamp: v.Timestamp, Value: value}
+	if sample.LowerBound, err = parseOptionalValue(resourceName, v.LowerBound); err != nil {
+		return Sample{}, fmt.Errorf("lowerBound: %v", err)
+	}
+	if sample.UpperBound, err = parseOptionalValue(resourceName, v.UpperBound); err != nil {
+		return Sample{}, fmt.Errorf("upperBound: %v", err)
+	}
+	if v.Quantiles != nil {
+		sample.Quantiles = make(map[string]float64, len(v.Quantiles))
+		for name, q := range v.Quantiles {
+			if _, err := ParseQuantileName(name); err != nil {
+				return Sample{}, err
+			}
+			if sample.Quantiles[name], err = ParseValue(resourceName, q); err != nil {
+				return Sample{}, fmt.Errorf("quantile %s: %v", name, err)
+			}
+		}
+	}
+	return sample, nil
+}
+
+func parseOptionalValue(resourceName v1alpha1.ResourceName, value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	f, err := ParseValue(resourceName, value)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// FormatVector is the inverse of ParseVector.
+func FormatVector(s Sample) *v1alpha1.Vector {
+	v := &v1alpha1.Vector{Value: FormatValue(s.Value), Timestamp: s.Timestamp}
+	if s.LowerBound != nil {
+		v.LowerBound = FormatValue(*s.LowerBound)
+	}
+	if s.UpperBound != nil {
+		v.UpperBound = FormatValue(*s.UpperBound)
+	}
+	if s.Quantiles != nil {
+		v.Quantiles = make(map[string]string, len(s.Quantiles))
+		for name, q := range s.Quantiles {
+			v.Quantiles[name] = FormatValue(q)
+		}
+	}
+	return v
+}
+
+// ParsePrediction parses every TimeSeries of a Prediction, using the metric name as the resource name.
+// Failed vectors are reported in a MetricParseError; successfully parsed samples are always returned.
+func ParsePrediction(p v1alpha1.Prediction) (map[string]Samples, error) {
+	result := make(map[string]Samples, len(p))
+	var failed map[string]ParseErrors
+	for metric, ts := range p {
+		samples, err := ParseTimeSeries(v1alpha1.ResourceName(metric), ts)
+		result[metric] = samples
+		if err != nil {
+			if failed == nil {
+				failed = map[string]ParseErrors{}
+			}
+			failed[metric] = err.(ParseErrors)
+		}
+	}
+	if failed != nil {
+		return result, &MetricParseError{Errors: failed}
+	}
+	return result, nil
+}
+
+// FormatTimeSeries converts samples back to a TimeSeries.
+func FormatTimeSeries(samples Samples) v1alpha1.TimeSeries {
+	ts := make(v1alpha1.TimeSeries, 0, len(samples))
+	for _, s := range samples {
+		ts = append(ts, FormatVector(s))
+	}
+	return ts
+}
+
+// FormatPrediction converts parsed samples keyed by metric name back to a Prediction.
+func FormatPrediction(samples map[string]Samples) v1alpha1.Prediction {
+	p := make(v1alpha1.Prediction, len(samples))
+	for metric, s := range samples {
+		p[metric] = FormatTimeSeries(s)
+	}
+	return p
+}
+
+// ToQuantity converts a value to a resource.Quantity according to the unit of the resource:
+// milli cores for ResourceCPU and bytes for ResourceMemory. Values of other resources are
+// treated as plain decimal numbers. The decimal representation of the value is kept down to
+// one nano of the unit of the quantity, smaller fractions are rounded up. Values that are not
+// finite are converted to zero.
+func ToQuantity(resourceName v1alpha1.ResourceName, value float64) resource.Quantity {
+	suffix, format := "", resource.DecimalSI
+	switch resourceName {
+	case v1alpha1.ResourceCPU:
+		suffix = "m"
+	case v1alpha1.ResourceMemory:
+		format = resource.BinarySI
+	}
+	q, err := resource.ParseQuantity(FormatValue(value) + suffix)
+	if err != nil {
+		return resource.Quantity{}
+	}
+	return *resource.NewDecimalQuantity(*q.AsDec(), format)
+}
+
+// FromQuantity is the inverse of ToQuantity.
+func FromQuantity(resourceName v1alpha1.ResourceName, q resource.Quantity) float64 {
+	// the exact decimal of the quantity scaled by the exponent of the unit avoids the rounding errors of
+	// MilliValue and AsApproximateFloat64
+	exponent := ""
+	if resourceName == v1alpha1.ResourceCPU {
+		exponent = "e3"
+	}
+	value, err := strconv.ParseFloat(q.AsDec().String()+exponent, 64)
+	if err != nil {
+		return q.AsApproximateFloat64()
+	}
+	return value
+}
+
+// ToQuantitySample converts the values of a sample of the given resource to quantities.
+func ToQuantitySample(resourceName v1alpha1.ResourceName, s Sample) QuantitySample {
+	q := QuantitySample{Timestamp: s.Timestamp, Value: ToQuantity(resourceName, s.Value)}
+	if s.LowerBound != nil {
+		bound := ToQuantity(resourceName, *s.LowerBound)
+		q.LowerBound = &bound
+	}
+	if s.UpperBound != nil {
+		bound := ToQuantity(resourceName, *s.UpperBound)
+		q.UpperBound = &bound
+	}
+	if s.Quantiles != nil {
+		q.Quantiles = make(map[string]resource.Quantity, len(s.Quantiles))
+		for name, value := range s.Quantiles {
+			q.Quantiles[name] = ToQuantity(resourceName, value)
+		}
+	}
+	return q
+}
+
+// FromQuantitySample is the inverse of ToQuantitySample.
+func FromQuantitySample(resourceName v1alpha1.ResourceName, q QuantitySample) Sample {
+	s := Sample{Timestamp: q.Timestamp, Value: FromQuantity(resourceName, q.Value)}
+	if q.LowerBound != nil {
+		bound := FromQuantity(resourceName, *q.LowerBound)
+		s.LowerBound = &bound
+	}
+	if q.UpperBound != nil {
+		bound := FromQuantity(resourceName, *q.UpperBound)
+		s.UpperBound = &bound
+	}
+	if q.Quantiles != nil {
+		s.Quantiles = make(map[string]float64, len(q.Quantiles))
+		for name, value := range q.Quantiles {
+			s.Quantiles[name] = FromQuantity(resourceName, value)
+		}
+	}
+	return s
+}
+
+// ParseQuantityTimeSeries parses a TimeSeries into quantity samples of the given resource.
+// Errors are reported the same way as ParseTimeSeries.
+func ParseQuantityTimeSeries(resourceName v1alpha1.ResourceName, ts v1alpha1.TimeSeries) ([]QuantitySample, error) {
+	samples, err := ParseTimeSeries(resourceName, ts)
+	result := make([]QuantitySample, 0, len(samples))
+	for _, s := range samples {
+		result = append(result, ToQuantitySample(resourceName, s))
+	}
+	return result, err
+}
+
+// FormatQuantityTimeSeries converts quantity samples of the given resource back to a TimeSeries.
+func FormatQuantityTimeSeries(resourceName v1alpha1.ResourceName, samples []QuantitySample) v1alpha1.TimeSeries {
+	ts := make(v1alpha1.TimeSeries, 0, len(samples))
+	for _, s := range samples {
+		ts = append(ts, FormatVector(FromQuantitySample(resourceName, s)))
+	}
+	return ts
+}
+
+// Values returns the values of the samples.
+func (s Samples) Values() []float64 {
+	values := make([]float64, 0, len(s))
+	for _, sample := range s {
+		values = append(values, sample.Value)
+	}
+	return values
+}
+
+func sortedKeys(m map[string]ParseErrors) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,127 @@
+package helper
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+)
+
+func TestParseValue(t *testing.T) {
+	cases := []struct {
+		name     string
+		resource v1alpha1.ResourceName
+		value    string
+		expected float64
+		err      bool
+	}{
+		{name: "plain number", resource: "requests", value: "1.5", expected: 1.5},
+		{name: "small number", resource: "requests", value: "0.0004", expected: 0.0004},
+		{name: "negative number", resource: "requests", value: "-3", expected: -3},
+		{name: "exponent", resource: "requests", value: "2e3", expected: 2000},
+		{name: "cpu number", resource: v1alpha1.ResourceCPU, value: "2500.4", expected: 2500.4},
+		{name: "cpu milli quantity", resource: v1alpha1.ResourceCPU, value: "500m", expected: 500},
+		{name: "cpu cores quantity", resource: v1alpha1.ResourceCPU, value: "2", expected: 2},
+		{name: "cpu micro quantity", resource: v1alpha1.ResourceCPU, value: "1500u", expected: 1.5},
+		{name: "memory quantity", resource: v1alpha1.ResourceMemory, value: "1Gi", expected: 1 << 30},
+		{name: "memory decimal quantity", resource: v1alpha1.ResourceMemory, value: "1.5k", expected: 1500},
+		{name: "plain quantity", resource: "requests", value: "250m", expected: 0.25},
+		{name: "not a number", resource: "requests", value: "abc", err: true},
+		{name: "empty", resource: "requests", value: "", err: true},
+		{name: "nan", resource: "requests", value: "NaN", err: true},
+		{name: "infinity", resource: "requests", value: "+Inf", err: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			value, err := ParseValue(c.resource, c.value)
+			switch {
+			case c.err && err == nil:
+				t.Errorf("expected an error, got %g", value)
+			case !c.err && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case !c.err && value != c.expected:
+				t.Errorf("expected %g, got %g", c.expected, value)
+			}
+		})
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	cases := []struct {
+		value    float64
+		expected string
+	}{
+		{value: 0, expected: "0"},
+		{value: 1.5, expected: "1.5"},
+		{value: -3, expected: "-3"},
+		{value: 0.0004, expected: "0.0004"},
+		{value: 2500.4, expected: "2500.4"},
+		{value: 1e21, expected: "1000000000000000000000"},
+		{value: 1.0 / 3, expected: "0.3333333333333333"},
+	}
+	for _, c := range cases {
+		if got := FormatValue(c.value); got != c.expected {
+			t.Errorf("FormatValue(%v): expected %q, got %q", c.value, c.expected, got)
+		}
+		if value, err := ParseValue("", FormatValue(c.value)); err != nil || value != c.value {
+			t.Errorf("FormatValue(%v) does not round trip: %g, %v", c.value, value, err)
+		}
+	}
+}
+
+func TestQuantityRoundTrip(t *testing.T) {
+	cases := []struct {
+		name     string
+		resource v1alpha1.ResourceName
+		value    float64
+		quantity string
+	}{
+		{name: "cpu millicores", resource: v1alpha1.ResourceCPU, value: 500, quantity: "500m"},
+		{name: "cpu cores", resource: v1alpha1.ResourceCPU, value: 2000, quantity: "2"},
+		{name: "cpu fractional millicores", resource: v1alpha1.ResourceCPU, value: 2500.4, quantity: "2500400u"},
+		{name: "cpu below one millicore", resource: v1alpha1.ResourceCPU, value: 0.0004, quantity: "400n"},
+		{name: "memory bytes", resource: v1alpha1.ResourceMemory, value: 1 << 30, quantity: "1Gi"},
+		{name: "memory odd bytes", resource: v1alpha1.ResourceMemory, value: 1536, quantity: "1536"},
+		{name: "plain number", resource: "requests", value: 12.5, quantity: "12500m"},
+		{name: "plain below one milli", resource: "requests", value: 0.0004, quantity: "400u"},
+		{name: "plain nanos", resource: "requests", value: 0.000000003, quantity: "3n"},
+		{name: "negative", resource: "requests", value: -0.25, quantity: "-250m"},
+		{name: "zero", resource: v1alpha1.ResourceCPU, value: 0, quantity: "0"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q := ToQuantity(c.resource, c.value)
+			if q.String() != c.quantity {
+				t.Errorf("expected quantity %s, got %s", c.quantity, q.String())
+			}
+			if got := FromQuantity(c.resource, q); got != c.value {
+				t.Errorf("expected %g back, got %g", c.value, got)
+			}
+			if got := FromQuantity(c.resource, resource.MustParse(c.quantity)); got != c.value {
+				t.Errorf("expected %g from the parsed quantity, got %g", c.value, got)
+			}
+		})
+	}
+}
+
+func TestToQuantityRoundsUpBelowOneNano(t *testing.T) {
+	if q := ToQuantity("requests", 0.0000000001); q.String() != "1n" {
+		t.Errorf("expected 1n, got %s", q.String())
+	}
+}
+
+func TestQuantitySampleRoundTrip(t *testing.T) {
+	lower, upper := 2400.25, 2600.75
+	sample := Sample{Timestamp: 60, Value: 2500.4, LowerBound: &lower, UpperBound: &upper, Quantiles: map[string]float64{"p50": 2500.4, "p99": 2999.9}}
+	q := ToQuantitySample(v1alpha1.ResourceCPU, sample)
+	got := FromQuantitySample(v1alpha1.ResourceCPU, q)
+	if got.Timestamp != sample.Timestamp || got.Value != sample.Value || *got.LowerBound != lower || *got.UpperBound != upper {
+		t.Errorf("expected %+v, got %+v", sample, got)
+	}
+	for name, value := range sample.Quantiles {
+		if got.Quantiles[name] != value {
+			t.Errorf("quantile %s: expected %g, got %g", name, value, got.Quantiles[name])
+		}
+	}
+}
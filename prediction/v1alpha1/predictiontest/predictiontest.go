@@ -0,0 +1,67 @@
+// Package predictiontest provides the prediction fixtures shared by the tests of the consumers of the prediction
+// API. The fixtures are valid objects that a test adapts to its case.
+package predictiontest
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+)
+
+// Now is the time the tests predict at, a multiple of the default sample interval.
+var Now = time.Unix(1637740800, 0)
+
+// DSP returns a dsp config that is valid without defaults, predicting with the max value estimator.
+func DSP() *v1alpha1.DspConfig {
+	return &v1alpha1.DspConfig{
+		SampleInterval: "1m",
+		HistoryLength:  "24h",
+		Estimators:     &v1alpha1.EstimatorConfigs{MaxValue: &v1alpha1.MaxValueEstimatorConfig{}},
+	}
+}
+
+// NewPodGroupPrediction returns a valid PodGroupPrediction, with its type and version, predicting the cpu of the
+// pod web-0 in instant mode with the DSP config.
+func NewPodGroupPrediction(namespace, name string) *v1alpha1.PodGroupPrediction {
+	return &v1alpha1.PodGroupPrediction{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "PodGroupPrediction"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: v1alpha1.PodGroupPredictionSpec{
+			Mode: v1alpha1.PredictionModeInstant,
+			Pods: []string{"web-0"},
+			MetricPredictionConfigs: []v1alpha1.AlgorithmProviderConfig{{
+				MetricName: string(v1alpha1.ResourceCPU),
+				DSP:        DSP(),
+			}},
+		},
+	}
+}
+
+// NewNodePrediction returns a valid NodePrediction, with its type and version, of the node of the same name
+// predicting its memory every minute in instant mode with the DSP config.
+func NewNodePrediction(name string) *v1alpha1.NodePrediction {
+	return &v1alpha1.NodePrediction{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "NodePrediction"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.NodePredictionResourceSpec{
+			NodeName: name,
+			Period:   metav1.Duration{Duration: time.Minute},
+			Mode:     v1alpha1.PredictionModeInstant,
+			MetricPredictionConfigs: []v1alpha1.AlgorithmProviderConfig{{
+				MetricName: string(v1alpha1.ResourceMemory),
+				DSP:        DSP(),
+			}},
+		},
+	}
+}
+
+// Series returns the series of the values every step from the start.
+func Series(start time.Time, step time.Duration, values ...string) v1alpha1.TimeSeries {
+	ts := make(v1alpha1.TimeSeries, 0, len(values))
+	for i, value := range values {
+		ts = append(ts, &v1alpha1.Vector{Value: value, Timestamp: start.Add(time.Duration(i) * step).Unix()})
+	}
+	return ts
+}
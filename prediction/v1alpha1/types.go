@@ -4,6 +4,7 @@ import (
 	autoscalingv2 "k8s.io/api/autoscaling/v2beta2"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // ResourceName represents the name of the resource.
@@ -17,6 +18,7 @@ const (
 )
 
 // PredictionMode represents the prediction time series mode.
+// +kubebuilder:validation:Enum=instant;range
 type PredictionMode string
 
 const (
@@ -27,9 +29,19 @@ const (
 )
 
 // +genclient
+// +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
-
-// NodePrediction is the node prediction resource. it is associated with a node.
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster,shortName=np,categories=crane
+// +kubebuilder:printcolumn:name="Node",type=string,JSONPath=".spec.nodeName",description="The node the prediction is associated with"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".spec.mode",description="The prediction time series mode"
+// +kubebuilder:printcolumn:name="Period",type=string,JSONPath=".spec.period",description="The prediction time series interval"
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=".status.status",description="The status of the prediction routine"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Last Update",type=date,JSONPath=".status.lastUpdateTime",description="The last time the prediction data was updated"
+
+// NodePrediction is the node prediction resource. it is associated with a node and is cluster scoped like the node.
 type NodePrediction struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -42,22 +54,53 @@ type NodePrediction struct {
 
 // NodePredictionResourceSpec
 type NodePredictionResourceSpec struct {
+	// NodeName is the name of the node the prediction is associated with.
+	// +kubebuilder:validation:MinLength=1
+	NodeName string `json:"nodeName"`
 	// Period is the prediction time series interval or step.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
 	Period metav1.Duration `json:"period"`
 	// Mode is the prediction time series mode
 	Mode PredictionMode `json:"mode"`
 	// MetricPredictionConfigs is the prediction configs of metric. each metric has its config for different prediction behaviors
+	// +kubebuilder:validation:MinItems=1
 	MetricPredictionConfigs []AlgorithmProviderConfig `json:"metricPredictionConfigs"`
 }
 
 // NodePredictionResourceStatus
 type NodePredictionResourceStatus struct {
+	// ObservedGeneration is the generation of the spec the status was computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions is the condition of NodePrediction
+	// +optional
+	Conditions []PredictionCondition `json:"conditions,omitempty"`
+	// Status is the phase of the prediction routine, it summarizes the conditions.
+	// +optional
+	Status PredictionStatus `json:"status,omitempty"`
+	// LastUpdateTime is the last time the prediction data was updated.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+	// Estimators are the estimators that produced the predicted series of the metrics.
+	// +optional
+	Estimators []MetricEstimator `json:"estimators,omitempty"`
+	// Accuracy is the accuracy of the past predictions of the metrics, evaluated against their observed values.
+	// +optional
+	Accuracy []MetricAccuracy `json:"accuracy,omitempty"`
 	// Consumed is the predicted resource usage in next resolution point based on past time series.
 	Consumed Prediction `json:"consumed"`
 }
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=pgp,categories=crane
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".spec.mode",description="The prediction time series mode"
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=".status.status",description="The status of the prediction routine"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Last Update",type=date,JSONPath=".status.lastUpdateTime",description="The last time the prediction data was updated"
 
 // PodGroupPrediction is a prediction on the resource consumed by a pod group.
 // In kubernetes context, a pod group often refers to a batch of pods that satisfy a label selector.
@@ -82,6 +125,10 @@ const (
 	PredictionStatusNotStarted PredictionStatus = "NotStarted"
 	// PredictionStatusFinished means the prediction has finished.
 	PredictionStatusFinished PredictionStatus = "Finished"
+	// PredictionStatusStale means the prediction data has not been updated in time and may be outdated.
+	PredictionStatusStale PredictionStatus = "Stale"
+	// PredictionStatusFailed means the prediction routine failed, the prediction data is not valid.
+	PredictionStatusFailed PredictionStatus = "Failed"
 )
 
 // PodGroupPredictionSpec is a description of a PodGroupPrediction.
@@ -94,6 +141,8 @@ type PodGroupPredictionSpec struct {
 	// +optional
 	End *metav1.Time `json:"end"`
 	// PredictionLength, for example, 24-hours means predicting time series in next 24 hours. This should be used only for PredictionModeRange.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
 	PredictionLength metav1.Duration `json:"predictionLength"`
 	// Prediction mode
 	Mode PredictionMode `json:"mode"`
@@ -107,40 +156,94 @@ type PodGroupPredictionSpec struct {
 	// +optional
 	LabelSelector metav1.LabelSelector `json:"labelSelector"`
 	// MetricPredictionConfigs is the prediction configs of metric. each metric has its config for different prediction behaviors
+	// +kubebuilder:validation:MinItems=1
 	MetricPredictionConfigs []AlgorithmProviderConfig `json:"metricPredictionConfigs"`
 }
 
 // PodGroupPredictionStatus
 type PodGroupPredictionStatus struct {
 	// Conditions is the condition of PodGroupPrediction
-	Conditions []PodGroupPredictionCondition `json:"conditions,omitempty"`
+	Conditions []PredictionCondition `json:"conditions,omitempty"`
 	// Status
 	Status PredictionStatus `json:"status,omitempty"`
+	// LastUpdateTime is the last time the prediction data was updated.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+	// Estimators are the estimators that produced the predicted series of the metrics.
+	// +optional
+	Estimators []MetricEstimator `json:"estimators,omitempty"`
+	// Accuracy is the accuracy of the past aggregated predictions of the metrics, evaluated against their
+	// observed values.
+	// +optional
+	Accuracy []MetricAccuracy `json:"accuracy,omitempty"`
 	// Aggregation is the aggregated prediction value of all pods.
 	Aggregation Prediction `json:"aggregation,omitempty"`
 	// Containers is all the containers in pod group. excludes pause container. key is the namesapce/podname/containername
 	Containers map[string]Prediction `json:"containers,omitempty"`
 }
 
-// PodGroupPredictionConditionType is a valid value for PodGroupPredictionCondition.Type
-type PodGroupPredictionConditionType string
+// MetricEstimator records the estimator that produced the predicted series of a metric.
+type MetricEstimator struct {
+	// MetricName is the name of the metric.
+	MetricName string `json:"metricName"`
+	// Estimator is the algorithm followed by the estimator it ran in parentheses, for example dsp(fft), or the
+	// weighted members of an ensemble, for example ensemble(dsp(fft):2,percentile:1). The different estimators
+	// of the containers of a pod group are sorted and separated by semicolons.
+	Estimator string `json:"estimator"`
+}
+
+// MetricAccuracy is the accuracy of the predictions of a metric accumulated over a rolling window, every
+// predicted value is compared once with its observed value.
+type MetricAccuracy struct {
+	// MetricName is the name of the metric.
+	MetricName string `json:"metricName"`
+	// MAPE is the mean absolute percentage error of the predicted values as a fraction, 0.1 is 10%. The observed
+	// values of zero are left out, it is unset when all are zero.
+	// +optional
+	MAPE string `json:"mape,omitempty"`
+	// RMSE is the root mean square error of the predicted values, in the unit of the metric.
+	RMSE string `json:"rmse"`
+	// Coverage is the fraction of the observed values within the bounds of their predicted vector, it is unset
+	// when the predicted vectors have no bounds.
+	// +optional
+	Coverage string `json:"coverage,omitempty"`
+	// Samples is the number of predicted values that were compared.
+	Samples int32 `json:"samples"`
+	// Window is the time range of the compared values.
+	Window EvaluationWindow `json:"window"`
+}
+
+// EvaluationWindow is the time range of an evaluation, both ends included.
+type EvaluationWindow struct {
+	// Start is the time of the first compared value.
+	Start metav1.Time `json:"start"`
+	// End is the time of the last compared value.
+	End metav1.Time `json:"end"`
+}
+
+// PredictionConditionType is a valid value for PredictionCondition.Type
+type PredictionConditionType string
 
-// These are valid conditions of PodGroupPrediction.
+// These are valid conditions of NodePrediction and PodGroupPrediction.
 const (
 	// PredictionConditionCharging means no valid prediction series is available, just wait to predict.
-	PredictionConditionCharging PodGroupPredictionConditionType = "Charging"
+	PredictionConditionCharging PredictionConditionType = "Charging"
 	// PredictionConditionPredicting means the prediction routine is ongoing and the prediction data is valid.
-	PredictionConditionPredicting PodGroupPredictionConditionType = "Predicting"
+	PredictionConditionPredicting PredictionConditionType = "Predicting"
 	// PredictionConditionNotStarted means the prediction routine has not started yet.
-	PredictionConditionNotStarted PodGroupPredictionConditionType = "NotStarted"
-	// PredictionStatusFinished means the prediction has finished, the prediction data will not be updated anymore.
-	PredictionConditionFinished PodGroupPredictionConditionType = "Finished"
+	PredictionConditionNotStarted PredictionConditionType = "NotStarted"
+	// PredictionConditionFinished means the prediction has finished, the prediction data will not be updated anymore.
+	PredictionConditionFinished PredictionConditionType = "Finished"
+	// PredictionConditionStale means the prediction data has not been updated in time and may be outdated.
+	PredictionConditionStale PredictionConditionType = "Stale"
+	// PredictionConditionFailed means the prediction routine failed, the reason and message tell why.
+	PredictionConditionFailed PredictionConditionType = "Failed"
 )
 
-// PodGroupPredictionCondition contains details for the current condition of this pod.
-type PodGroupPredictionCondition struct {
+// PredictionCondition contains details for the current condition of a prediction.
+type PredictionCondition struct {
 	// Type is the type of the condition.
-	Type PodGroupPredictionConditionType `json:"type,omitempty"`
+	Type PredictionConditionType `json:"type,omitempty"`
 	// Status is the status of the condition.
 	// Can be True, False, Unknown.
 	Status v1.ConditionStatus `json:"status,omitempty"`
@@ -158,6 +261,16 @@ type PodGroupPredictionCondition struct {
 	Message string `json:"message,omitempty"`
 }
 
+// PodGroupPredictionConditionType is kept for compatibility.
+//
+// Deprecated: use PredictionConditionType instead.
+type PodGroupPredictionConditionType = PredictionConditionType
+
+// PodGroupPredictionCondition is kept for compatibility.
+//
+// Deprecated: use PredictionCondition instead.
+type PodGroupPredictionCondition = PredictionCondition
+
 // Prediction define metrics prediction
 type Prediction map[string]TimeSeries
 
@@ -169,8 +282,21 @@ type Vector struct {
 	// CRD not support float64
 	Value     string `json:"value"`
 	Timestamp int64  `json:"timestamp"`
+	// LowerBound is the lower bound of the confidence interval of the value, when the estimator provides one.
+	// +optional
+	LowerBound string `json:"lowerBound,omitempty"`
+	// UpperBound is the upper bound of the confidence interval of the value, when the estimator provides one.
+	// +optional
+	UpperBound string `json:"upperBound,omitempty"`
+	// Quantiles are the estimated quantiles of the value keyed by name, for example p50, p90 and p99, when the
+	// estimator provides them.
+	// +optional
+	Quantiles Quantiles `json:"quantiles,omitempty"`
 }
 
+// Quantiles are estimated quantiles keyed by name, p followed by the percentile.
+type Quantiles map[string]string
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // NodePredictionList is a list of NodePrediction resources
@@ -192,17 +318,151 @@ type PodGroupPredictionList struct {
 }
 
 type AlgorithmProviderConfig struct {
+	// +kubebuilder:validation:MinLength=1
 	MetricName string `json:"metricName"`
 	// +optional
 	DSP *DspConfig `json:"dsp"`
 	// +optional
 	Percentile *PercentileConfig `json:"percentile"`
+	// Custom selects an algorithm registered by name in the prediction controller.
+	// +optional
+	Custom *CustomAlgorithmConfig `json:"custom"`
+	// Ensemble predicts the metric with the weighted mean of several algorithms, instead of dsp, percentile
+	// or custom.
+	// +optional
+	Ensemble *EnsembleConfig `json:"ensemble"`
+	// Fallbacks are the algorithms tried in order when the algorithm of the metric, or every member of its
+	// ensemble, cannot predict the history because it is too short or not periodic.
+	// +optional
+	Fallbacks []AlgorithmConfig `json:"fallbacks,omitempty"`
+	// Source is where the history of the metric is queried from. When unset, cpu and memory are the resource
+	// metrics of the target and any other metric name is an external metric without selector.
+	// +optional
+	Source *MetricSourceSpec `json:"source,omitempty"`
+}
+
+// MetricSourceType is the type of the source of a metric.
+// +kubebuilder:validation:Enum=Resource;Prometheus;External
+type MetricSourceType string
+
+const (
+	// ResourceMetricSourceType is a resource metric of the target, such as its cpu usage.
+	ResourceMetricSourceType MetricSourceType = "Resource"
+	// PrometheusMetricSourceType is a Prometheus query expression evaluated for the target.
+	PrometheusMetricSourceType MetricSourceType = "Prometheus"
+	// ExternalMetricSourceType is a metric not associated with the target, selected by name and labels.
+	ExternalMetricSourceType MetricSourceType = "External"
+)
+
+// MetricSourceSpec is the source of the history of a metric, the field matching its type must be specified
+// and the others must not.
+type MetricSourceSpec struct {
+	// Type is the type of the source.
+	Type MetricSourceType `json:"type"`
+	// Resource is the resource metric of the target, for the Resource type.
+	// +optional
+	Resource *ResourceMetricSource `json:"resource,omitempty"`
+	// Prometheus is the query of the metric, for the Prometheus type.
+	// +optional
+	Prometheus *PrometheusMetricSource `json:"prometheus,omitempty"`
+	// External is the external metric, for the External type.
+	// +optional
+	External *ExternalMetricSource `json:"external,omitempty"`
+}
+
+// ResourceMetricSource is a resource metric of the target of the prediction, a container of a pod group or a node.
+type ResourceMetricSource struct {
+	// Name is the name of the resource, cpu or memory.
+	Name ResourceName `json:"name"`
+}
+
+// PrometheusMetricSource is a Prometheus query expression. The ${namespace}, ${pod}, ${container} and ${node}
+// variables are replaced with the target of the prediction before the query is evaluated.
+type PrometheusMetricSource struct {
+	// Query is the query expression, for example
+	// sum(rate(container_cpu_usage_seconds_total{namespace="${namespace}",pod="${pod}"}[5m])).
+	// +kubebuilder:validation:MinLength=1
+	Query string `json:"query"`
+}
+
+// ExternalMetricSource is a metric that is not associated with the target of the prediction, for example the
+// length of a queue. The series of the metric matching the selector are summed.
+type ExternalMetricSource struct {
+	// MetricName is the name of the external metric.
+	// +kubebuilder:validation:MinLength=1
+	MetricName string `json:"metricName"`
+	// Selector selects the series of the metric by their labels, all of them when unset.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// AlgorithmConfig selects a single algorithm, exactly one of its fields must be specified.
+type AlgorithmConfig struct {
+	// +optional
+	DSP *DspConfig `json:"dsp"`
+	// +optional
+	Percentile *PercentileConfig `json:"percentile"`
+	// +optional
+	Custom *CustomAlgorithmConfig `json:"custom"`
+}
+
+// EnsembleConfig is the config of a weighted ensemble of algorithms.
+type EnsembleConfig struct {
+	// Members are the algorithms of the ensemble. The members that cannot predict the history are left out
+	// and the weights of the others are normalized.
+	// +kubebuilder:validation:MinItems=1
+	Members []EnsembleMember `json:"members"`
+}
+
+// EnsembleMember is an algorithm of an ensemble and its weight.
+type EnsembleMember struct {
+	AlgorithmConfig `json:",inline"`
+	// Weight is the weight of the member relative to the other members, 1 by default.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Weight int32 `json:"weight,omitempty"`
+}
+
+const (
+	// AlgorithmDSP is the name of the algorithm configured by AlgorithmProviderConfig.DSP.
+	AlgorithmDSP = "dsp"
+	// AlgorithmPercentile is the name of the algorithm configured by AlgorithmProviderConfig.Percentile.
+	AlgorithmPercentile = "percentile"
+	// AlgorithmEnsemble is the name of the weighted ensemble configured by AlgorithmProviderConfig.Ensemble.
+	AlgorithmEnsemble = "ensemble"
+)
+
+// IsReservedAlgorithmName returns whether the name only selects a built-in algorithm or the ensemble, a custom
+// algorithm cannot be registered or configured with it.
+func IsReservedAlgorithmName(name string) bool {
+	return name == AlgorithmDSP || name == AlgorithmPercentile || name == AlgorithmEnsemble
+}
+
+// CustomAlgorithmConfig is the config of an algorithm registered by name in the prediction controller.
+type CustomAlgorithmConfig struct {
+	// Name is the name the algorithm is registered with, it must not be a reserved algorithm name.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// SampleInterval is the sampling interval of metrics.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	SampleInterval string `json:"sampleInterval,omitempty"`
+	// HistoryLength describes how long back should be queried against provider to get historical metrics for prediction.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	HistoryLength string `json:"historyLength,omitempty"`
+	// Parameters are the parameters of the algorithm, their schema is defined by the algorithm.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Parameters *runtime.RawExtension `json:"parameters,omitempty"`
 }
 
 type DspConfig struct {
 	// SampleInterval is the sampling interval of metrics.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
 	SampleInterval string `json:"sampleInterval"`
 	// HistoryLength describes how long back should be queried against provider to get historical metrics for prediction.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
 	HistoryLength string `json:"historyLength"`
 	// Estimators
 	Estimators *EstimatorConfigs `json:"estimators"`
@@ -213,29 +473,90 @@ type EstimatorConfigs struct {
 	MaxValue *MaxValueEstimatorConfig `json:"maxValue"`
 	// +optional
 	FFT *FFTEstimatorConfig `json:"fft"`
+	// +optional
+	HoltWinters *HoltWintersEstimatorConfig `json:"holtWinters"`
+	// +optional
+	LinearRegression *LinearRegressionEstimatorConfig `json:"linearRegression"`
 }
 
 type MaxValueEstimatorConfig struct{}
 
+// FFTEstimatorConfig is the config of the FFT estimator, which extends the dominant frequencies of the history.
 type FFTEstimatorConfig struct {
-	MarginFraction         string `json:"marginFraction"`
-	LowAmplitudeThreshold  string `json:"lowAmplitudeThreshold"`
+	// MarginFraction is the fraction added on top of the estimation, which is multiplied by 1+MarginFraction.
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	MarginFraction string `json:"marginFraction"`
+	// LowAmplitudeThreshold is the amplitude, in the unit of the metric, under which the spectrum items beyond the
+	// MinNumOfSpectrumItems largest ones are dropped. The history is not periodic if no item reaches it.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`
+	LowAmplitudeThreshold string `json:"lowAmplitudeThreshold"`
+	// HighFrequencyThreshold is the frequency, in Hz, above which the spectrum items are dropped.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`
 	HighFrequencyThreshold string `json:"highFrequencyThreshold"`
-	MinNumOfSpectrumItems  int32  `json:"minNumOfSpectrumItems"`
-	MaxNumOfSpectrumItems  int32  `json:"maxNumOfSpectrumItems"`
+	// MinNumOfSpectrumItems is the number of spectrum items of largest amplitude that are kept whatever their amplitude.
+	// +kubebuilder:validation:Minimum=1
+	MinNumOfSpectrumItems int32 `json:"minNumOfSpectrumItems"`
+	// MaxNumOfSpectrumItems is the maximum number of spectrum items kept.
+	// +kubebuilder:validation:Minimum=1
+	MaxNumOfSpectrumItems int32 `json:"maxNumOfSpectrumItems"`
+}
+
+// HoltWintersEstimatorConfig is the config of the Holt-Winters estimator, which smooths the level, the trend and
+// the additive seasonality of the history and extends them.
+type HoltWintersEstimatorConfig struct {
+	// Alpha is the smoothing factor of the level, in [0, 1].
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	Alpha string `json:"alpha"`
+	// Beta is the smoothing factor of the trend, in [0, 1].
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	Beta string `json:"beta"`
+	// Gamma is the smoothing factor of the seasonality, in [0, 1].
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	Gamma string `json:"gamma"`
+	// SeasonLength is the length of a season, a multiple of the sample interval. The history must cover at least
+	// two seasons.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	SeasonLength string `json:"seasonLength"`
+}
+
+// LinearRegressionEstimatorConfig is the config of the linear regression estimator, which extends the least
+// squares line of the history.
+type LinearRegressionEstimatorConfig struct {
+	// Window is the length of the most recent history the line is fitted to, the whole history when unset.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	Window string `json:"window,omitempty"`
 }
 
+// PercentileConfig is the config of the percentile algorithm.
 type PercentileConfig struct {
-	SampleInterval  string          `json:"sampleInterval"`
-	Histogram       HistogramConfig `json:"histogram"`
-	MinSampleWeight string          `json:"minSampleWeight"`
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	SampleInterval string          `json:"sampleInterval"`
+	Histogram      HistogramConfig `json:"histogram"`
+	// MinSampleWeight is the weight a sample of a smaller weight is raised to.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`
+	MinSampleWeight string `json:"minSampleWeight"`
 }
 
+// HistogramConfig is the config of a decaying histogram.
 type HistogramConfig struct {
-	MaxValue              string `json:"maxValue"`
-	Epsilon               string `json:"epsilon"`
-	HalfLife              string `json:"halfLife"`
-	BucketSize            string `json:"bucketSize"`
-	FirstBucketSize       string `json:"firstBucketSize"`
+	// MaxValue is the largest value the histogram distinguishes, larger values fall into the last bucket.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`
+	MaxValue string `json:"maxValue"`
+	// Epsilon is the weight under which a bucket is considered empty.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`
+	Epsilon string `json:"epsilon"`
+	// HalfLife is the time after which the weight of a sample is halved.
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+	HalfLife string `json:"halfLife"`
+	// BucketSize is the size of the buckets when BucketSizeGrowthRatio is zero.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`
+	BucketSize string `json:"bucketSize"`
+	// FirstBucketSize is the size of the first bucket when BucketSizeGrowthRatio is positive.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`
+	FirstBucketSize string `json:"firstBucketSize"`
+	// BucketSizeGrowthRatio is the ratio by which the size of a bucket grows over the previous one, zero for
+	// buckets of a fixed size.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`
 	BucketSizeGrowthRatio string `json:"bucketSizeGrowthRatio"`
 }
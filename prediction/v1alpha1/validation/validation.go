@@ -0,0 +1,461 @@
+// Package validation validates prediction objects beyond what the CRD schema can express.
+package validation
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+)
+
+var (
+	supportedModes           = sets.NewString(v1alpha1.PredictionModeInstant, v1alpha1.PredictionModeRange)
+	supportedResourceMetrics = sets.NewString(string(v1alpha1.ResourceCPU), string(v1alpha1.ResourceMemory))
+)
+
+// ValidateNodePrediction validates a NodePrediction, which is cluster scoped.
+func ValidateNodePrediction(np *v1alpha1.NodePrediction) field.ErrorList {
+	allErrs := apivalidation.ValidateObjectMeta(&np.ObjectMeta, false, apivalidation.NameIsDNSSubdomain, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateNodePredictionResourceSpec(&np.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateNodePredictionUpdate validates an update of a NodePrediction. The node name and the mode are immutable.
+func ValidateNodePredictionUpdate(newNp, oldNp *v1alpha1.NodePrediction) field.ErrorList {
+	allErrs := apivalidation.ValidateObjectMetaUpdate(&newNp.ObjectMeta, &oldNp.ObjectMeta, field.NewPath("metadata"))
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, ValidateNodePredictionResourceSpec(&newNp.Spec, specPath)...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newNp.Spec.NodeName, oldNp.Spec.NodeName, specPath.Child("nodeName"))...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newNp.Spec.Mode, oldNp.Spec.Mode, specPath.Child("mode"))...)
+	return allErrs
+}
+
+// ValidateUpdate validates an update of a NodePrediction or a PodGroupPrediction, both objects must be of
+// the same kind.
+func ValidateUpdate(newObj, oldObj runtime.Object) field.ErrorList {
+	switch newObj := newObj.(type) {
+	case *v1alpha1.NodePrediction:
+		if oldObj, ok := oldObj.(*v1alpha1.NodePrediction); ok {
+			return ValidateNodePredictionUpdate(newObj, oldObj)
+		}
+	case *v1alpha1.PodGroupPrediction:
+		if oldObj, ok := oldObj.(*v1alpha1.PodGroupPrediction); ok {
+			return ValidatePodGroupPredictionUpdate(newObj, oldObj)
+		}
+	default:
+		return field.ErrorList{field.InternalError(nil, fmt.Errorf("unsupported object type %T", newObj))}
+	}
+	return field.ErrorList{field.InternalError(nil, fmt.Errorf("cannot update a %T with a %T", oldObj, newObj))}
+}
+
+// ValidatePodGroupPrediction validates a PodGroupPrediction.
+func ValidatePodGroupPrediction(pgp *v1alpha1.PodGroupPrediction) field.ErrorList {
+	allErrs := apivalidation.ValidateObjectMeta(&pgp.ObjectMeta, true, apivalidation.NameIsDNSSubdomain, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidatePodGroupPredictionSpec(&pgp.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidatePodGroupPredictionUpdate validates an update of a PodGroupPrediction. The mode and the
+// workload reference are immutable.
+func ValidatePodGroupPredictionUpdate(newPgp, oldPgp *v1alpha1.PodGroupPrediction) field.ErrorList {
+	allErrs := apivalidation.ValidateObjectMetaUpdate(&newPgp.ObjectMeta, &oldPgp.ObjectMeta, field.NewPath("metadata"))
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, ValidatePodGroupPredictionSpec(&newPgp.Spec, specPath)...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newPgp.Spec.Mode, oldPgp.Spec.Mode, specPath.Child("mode"))...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newPgp.Spec.WorkloadRef, oldPgp.Spec.WorkloadRef, specPath.Child("workloadRef"))...)
+	return allErrs
+}
+
+// ValidateNodePredictionResourceSpec validates the spec of a NodePrediction.
+func ValidateNodePredictionResourceSpec(spec *v1alpha1.NodePredictionResourceSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(spec.NodeName) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("nodeName"), ""))
+	} else {
+		for _, msg := range apivalidation.NameIsDNSSubdomain(spec.NodeName, false) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("nodeName"), spec.NodeName, msg))
+		}
+	}
+	allErrs = append(allErrs, validatePositiveDuration(spec.Period.Duration, fldPath.Child("period"))...)
+	allErrs = append(allErrs, validateMode(spec.Mode, fldPath.Child("mode"))...)
+	allErrs = append(allErrs, ValidateAlgorithmProviderConfigs(spec.MetricPredictionConfigs, fldPath.Child("metricPredictionConfigs"))...)
+	return allErrs
+}
+
+// ValidatePodGroupPredictionSpec validates the spec of a PodGroupPrediction.
+func ValidatePodGroupPredictionSpec(spec *v1alpha1.PodGroupPredictionSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := validateMode(spec.Mode, fldPath.Child("mode"))
+
+	if spec.Start != nil && spec.End != nil && !spec.End.After(spec.Start.Time) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("end"), spec.End.String(), "must be after start"))
+	}
+
+	switch {
+	case spec.PredictionLength.Duration < 0:
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("predictionLength"), spec.PredictionLength.String(), "must be greater than or equal to 0"))
+	case spec.Mode == v1alpha1.PredictionModeRange && spec.PredictionLength.Duration == 0:
+		allErrs = append(allErrs, field.Required(fldPath.Child("predictionLength"), "must be specified for mode "+v1alpha1.PredictionModeRange))
+	case spec.Mode == v1alpha1.PredictionModeInstant && spec.PredictionLength.Duration != 0:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("predictionLength"), "may only be specified for mode "+v1alpha1.PredictionModeRange))
+	}
+
+	if len(spec.Pods) == 0 && spec.WorkloadRef == nil && isEmptySelector(&spec.LabelSelector) {
+		allErrs = append(allErrs, field.Required(fldPath, "one of pods, workloadRef and labelSelector must be specified"))
+	}
+	if spec.WorkloadRef != nil {
+		refPath := fldPath.Child("workloadRef")
+		if spec.WorkloadRef.Kind == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("kind"), ""))
+		}
+		if spec.WorkloadRef.Name == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), ""))
+		}
+	}
+	pods := sets.NewString()
+	for i, pod := range spec.Pods {
+		if pods.Has(pod) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("pods").Index(i), pod))
+		}
+		pods.Insert(pod)
+	}
+	allErrs = append(allErrs, metavalidation.ValidateLabelSelector(&spec.LabelSelector, fldPath.Child("labelSelector"))...)
+	allErrs = append(allErrs, ValidateAlgorithmProviderConfigs(spec.MetricPredictionConfigs, fldPath.Child("metricPredictionConfigs"))...)
+	return allErrs
+}
+
+// ValidateAlgorithmProviderConfigs validates the metric prediction configs, metric names must be unique.
+func ValidateAlgorithmProviderConfigs(configs []v1alpha1.AlgorithmProviderConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(configs) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one metric prediction config is required"))
+	}
+	metrics := sets.NewString()
+	for i := range configs {
+		idxPath := fldPath.Index(i)
+		if metrics.Has(configs[i].MetricName) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("metricName"), configs[i].MetricName))
+		}
+		metrics.Insert(configs[i].MetricName)
+		allErrs = append(allErrs, ValidateAlgorithmProviderConfig(&configs[i], idxPath)...)
+	}
+	return allErrs
+}
+
+// ValidateAlgorithmProviderConfig validates a metric prediction config, exactly one algorithm or an ensemble
+// must be configured.
+func ValidateAlgorithmProviderConfig(config *v1alpha1.AlgorithmProviderConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if config.MetricName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("metricName"), ""))
+	}
+	algorithm := &v1alpha1.AlgorithmConfig{DSP: config.DSP, Percentile: config.Percentile, Custom: config.Custom}
+	if config.Ensemble == nil {
+		allErrs = append(allErrs, ValidateAlgorithmConfig(algorithm, fldPath)...)
+	} else {
+		if algorithm.DSP != nil || algorithm.Percentile != nil || algorithm.Custom != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "only one of dsp, percentile, custom and ensemble may be specified"))
+		}
+		allErrs = append(allErrs, ValidateEnsembleConfig(config.Ensemble, fldPath.Child("ensemble"))...)
+	}
+	for i := range config.Fallbacks {
+		allErrs = append(allErrs, ValidateAlgorithmConfig(&config.Fallbacks[i], fldPath.Child("fallbacks").Index(i))...)
+	}
+	if config.Source != nil {
+		allErrs = append(allErrs, ValidateMetricSourceSpec(config.Source, fldPath.Child("source"))...)
+	}
+	return allErrs
+}
+
+// ValidateMetricSourceSpec validates the source of a metric, the field of its type must be specified and the
+// others must not.
+func ValidateMetricSourceSpec(spec *v1alpha1.MetricSourceSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	sources := map[v1alpha1.MetricSourceType]bool{
+		v1alpha1.ResourceMetricSourceType:   spec.Resource != nil,
+		v1alpha1.PrometheusMetricSourceType: spec.Prometheus != nil,
+		v1alpha1.ExternalMetricSourceType:   spec.External != nil,
+	}
+	specified, ok := sources[spec.Type]
+	switch {
+	case spec.Type == "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("type"), ""))
+	case !ok:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), spec.Type,
+			[]string{string(v1alpha1.ResourceMetricSourceType), string(v1alpha1.PrometheusMetricSourceType), string(v1alpha1.ExternalMetricSourceType)}))
+	case !specified:
+		allErrs = append(allErrs, field.Required(fldPath, fmt.Sprintf("the source of type %s must be specified", spec.Type)))
+	}
+	for sourceType, specified := range sources {
+		if ok && specified && sourceType != spec.Type {
+			allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("only the source of type %s may be specified", spec.Type)))
+			break
+		}
+	}
+
+	if spec.Resource != nil && !supportedResourceMetrics.Has(string(spec.Resource.Name)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("resource", "name"), spec.Resource.Name, supportedResourceMetrics.List()))
+	}
+	if spec.Prometheus != nil && spec.Prometheus.Query == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("prometheus", "query"), ""))
+	}
+	if spec.External != nil {
+		if spec.External.MetricName == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("external", "metricName"), ""))
+		}
+		if spec.External.Selector != nil {
+			allErrs = append(allErrs, metavalidation.ValidateLabelSelector(spec.External.Selector, fldPath.Child("external", "selector"))...)
+		}
+	}
+	return allErrs
+}
+
+// ValidateAlgorithmConfig validates the config of a single algorithm, exactly one must be configured.
+func ValidateAlgorithmConfig(config *v1alpha1.AlgorithmConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	algorithms := 0
+	if config.DSP != nil {
+		algorithms++
+		allErrs = append(allErrs, ValidateDspConfig(config.DSP, fldPath.Child("dsp"))...)
+	}
+	if config.Percentile != nil {
+		algorithms++
+		allErrs = append(allErrs, ValidatePercentileConfig(config.Percentile, fldPath.Child("percentile"))...)
+	}
+	if config.Custom != nil {
+		algorithms++
+		allErrs = append(allErrs, ValidateCustomAlgorithmConfig(config.Custom, fldPath.Child("custom"))...)
+	}
+	switch {
+	case algorithms > 1:
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only one of dsp, percentile and custom may be specified"))
+	case algorithms == 0:
+		allErrs = append(allErrs, field.Required(fldPath, "one of dsp, percentile and custom must be specified"))
+	}
+	return allErrs
+}
+
+// ValidateEnsembleConfig validates the config of an ensemble, every member is a single algorithm.
+func ValidateEnsembleConfig(config *v1alpha1.EnsembleConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(config.Members) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("members"), "at least one member must be specified"))
+	}
+	for i := range config.Members {
+		memberPath := fldPath.Child("members").Index(i)
+		allErrs = append(allErrs, ValidateAlgorithmConfig(&config.Members[i].AlgorithmConfig, memberPath)...)
+		if config.Members[i].Weight < 1 {
+			allErrs = append(allErrs, field.Invalid(memberPath.Child("weight"), config.Members[i].Weight, "must be greater than or equal to 1"))
+		}
+	}
+	return allErrs
+}
+
+// ValidateCustomAlgorithmConfig validates the config of an algorithm registered by name. The parameters are
+// opaque to the API, they are validated by the algorithm when the prediction controller decodes them.
+func ValidateCustomAlgorithmConfig(config *v1alpha1.CustomAlgorithmConfig, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if config.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	} else if v1alpha1.IsReservedAlgorithmName(config.Name) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), config.Name, "is reserved for a built-in algorithm"))
+	} else {
+		for _, msg := range utilvalidation.IsQualifiedName(config.Name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), config.Name, msg))
+		}
+	}
+	// Both durations are optional, the defaults are set when the object is admitted.
+	var sampleInterval, historyLength time.Duration
+	if config.SampleInterval != "" {
+		var errs field.ErrorList
+		sampleInterval, errs = parsePositiveDuration(config.SampleInterval, fldPath.Child("sampleInterval"))
+		allErrs = append(allErrs, errs...)
+	}
+	if config.HistoryLength != "" {
+		var errs field.ErrorList
+		historyLength, errs = parsePositiveDuration(config.HistoryLength, fldPath.Child("historyLength"))
+		allErrs = append(allErrs, errs...)
+	}
+	if len(allErrs) == 0 && sampleInterval > 0 && historyLength > 0 && historyLength < sampleInterval {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("historyLength"), config.HistoryLength, "must not be shorter than sampleInterval"))
+	}
+	return allErrs
+}
+
+// ValidateDspConfig validates the config of the DSP algorithm.
+func ValidateDspConfig(config *v1alpha1.DspConfig, fldPath *field.Path) field.ErrorList {
+	sampleInterval, allErrs := parsePositiveDuration(config.SampleInterval, fldPath.Child("sampleInterval"))
+	historyLength, errs := parsePositiveDuration(config.HistoryLength, fldPath.Child("historyLength"))
+	allErrs = append(allErrs, errs...)
+	if len(allErrs) == 0 && historyLength < sampleInterval {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("historyLength"), config.HistoryLength, "must not be shorter than sampleInterval"))
+	}
+
+	estimatorsPath := fldPath.Child("estimators")
+	estimators := config.Estimators
+	if estimators == nil || (estimators.MaxValue == nil && estimators.FFT == nil && estimators.HoltWinters == nil && estimators.LinearRegression == nil) {
+		return append(allErrs, field.Required(estimatorsPath, "at least one estimator must be specified"))
+	}
+	if estimators.FFT != nil {
+		allErrs = append(allErrs, ValidateFFTEstimatorConfig(estimators.FFT, estimatorsPath.Child("fft"))...)
+	}
+	if estimators.HoltWinters != nil {
+		hwPath := estimatorsPath.Child("holtWinters")
+		hwErrs := ValidateHoltWintersEstimatorConfig(estimators.HoltWinters, hwPath)
+		allErrs = append(allErrs, hwErrs...)
+		if len(hwErrs) == 0 && sampleInterval > 0 && historyLength > 0 {
+			seasonLength, _ := time.ParseDuration(estimators.HoltWinters.SeasonLength)
+			if seasonLength%sampleInterval != 0 {
+				allErrs = append(allErrs, field.Invalid(hwPath.Child("seasonLength"), estimators.HoltWinters.SeasonLength, "must be a multiple of sampleInterval"))
+			}
+			if historyLength < 2*seasonLength {
+				allErrs = append(allErrs, field.Invalid(hwPath.Child("seasonLength"), estimators.HoltWinters.SeasonLength, "must not be longer than half of historyLength"))
+			}
+		}
+	}
+	if estimators.LinearRegression != nil {
+		allErrs = append(allErrs, ValidateLinearRegressionEstimatorConfig(estimators.LinearRegression, estimatorsPath.Child("linearRegression"))...)
+	}
+	return allErrs
+}
+
+// ValidateHoltWintersEstimatorConfig validates the config of the Holt-Winters estimator.
+func ValidateHoltWintersEstimatorConfig(config *v1alpha1.HoltWintersEstimatorConfig, fldPath *field.Path) field.ErrorList {
+	_, allErrs := parseFraction(config.Alpha, fldPath.Child("alpha"))
+	_, errs := parseFraction(config.Beta, fldPath.Child("beta"))
+	allErrs = append(allErrs, errs...)
+	_, errs = parseFraction(config.Gamma, fldPath.Child("gamma"))
+	allErrs = append(allErrs, errs...)
+	_, errs = parsePositiveDuration(config.SeasonLength, fldPath.Child("seasonLength"))
+	allErrs = append(allErrs, errs...)
+	return allErrs
+}
+
+// ValidateLinearRegressionEstimatorConfig validates the config of the linear regression estimator.
+func ValidateLinearRegressionEstimatorConfig(config *v1alpha1.LinearRegressionEstimatorConfig, fldPath *field.Path) field.ErrorList {
+	if config.Window == "" {
+		return nil
+	}
+	_, allErrs := parsePositiveDuration(config.Window, fldPath.Child("window"))
+	return allErrs
+}
+
+// ValidateFFTEstimatorConfig validates the config of the FFT estimator.
+func ValidateFFTEstimatorConfig(config *v1alpha1.FFTEstimatorConfig, fldPath *field.Path) field.ErrorList {
+	marginFraction, allErrs := parseNonNegativeFloat(config.MarginFraction, fldPath.Child("marginFraction"))
+	if len(allErrs) == 0 && marginFraction > 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("marginFraction"), config.MarginFraction, "must be less than or equal to 1"))
+	}
+	_, errs := parseNonNegativeFloat(config.LowAmplitudeThreshold, fldPath.Child("lowAmplitudeThreshold"))
+	allErrs = append(allErrs, errs...)
+	_, errs = parseNonNegativeFloat(config.HighFrequencyThreshold, fldPath.Child("highFrequencyThreshold"))
+	allErrs = append(allErrs, errs...)
+
+	if config.MinNumOfSpectrumItems < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minNumOfSpectrumItems"), config.MinNumOfSpectrumItems, "must be greater than or equal to 1"))
+	}
+	if config.MaxNumOfSpectrumItems < config.MinNumOfSpectrumItems {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxNumOfSpectrumItems"), config.MaxNumOfSpectrumItems, "must be greater than or equal to minNumOfSpectrumItems"))
+	}
+	return allErrs
+}
+
+// ValidatePercentileConfig validates the config of the percentile algorithm.
+func ValidatePercentileConfig(config *v1alpha1.PercentileConfig, fldPath *field.Path) field.ErrorList {
+	_, allErrs := parsePositiveDuration(config.SampleInterval, fldPath.Child("sampleInterval"))
+	_, errs := parseNonNegativeFloat(config.MinSampleWeight, fldPath.Child("minSampleWeight"))
+	allErrs = append(allErrs, errs...)
+	allErrs = append(allErrs, ValidateHistogramConfig(&config.Histogram, fldPath.Child("histogram"))...)
+	return allErrs
+}
+
+// ValidateHistogramConfig validates the config of a decaying histogram. BucketSize is used when
+// BucketSizeGrowthRatio is zero, FirstBucketSize otherwise.
+func ValidateHistogramConfig(config *v1alpha1.HistogramConfig, fldPath *field.Path) field.ErrorList {
+	_, allErrs := parsePositiveFloat(config.MaxValue, fldPath.Child("maxValue"))
+	_, errs := parsePositiveFloat(config.Epsilon, fldPath.Child("epsilon"))
+	allErrs = append(allErrs, errs...)
+	_, errs = parsePositiveDuration(config.HalfLife, fldPath.Child("halfLife"))
+	allErrs = append(allErrs, errs...)
+
+	ratio, errs := parseNonNegativeFloat(config.BucketSizeGrowthRatio, fldPath.Child("bucketSizeGrowthRatio"))
+	allErrs = append(allErrs, errs...)
+	if len(errs) == 0 {
+		if ratio == 0 {
+			_, errs = parsePositiveFloat(config.BucketSize, fldPath.Child("bucketSize"))
+		} else {
+			_, errs = parsePositiveFloat(config.FirstBucketSize, fldPath.Child("firstBucketSize"))
+		}
+		allErrs = append(allErrs, errs...)
+	}
+	return allErrs
+}
+
+func validateMode(mode v1alpha1.PredictionMode, fldPath *field.Path) field.ErrorList {
+	if mode == "" {
+		return field.ErrorList{field.Required(fldPath, "")}
+	}
+	if !supportedModes.Has(string(mode)) {
+		return field.ErrorList{field.NotSupported(fldPath, mode, supportedModes.List())}
+	}
+	return nil
+}
+
+func validatePositiveDuration(d time.Duration, fldPath *field.Path) field.ErrorList {
+	if d <= 0 {
+		return field.ErrorList{field.Invalid(fldPath, d.String(), "must be greater than 0")}
+	}
+	return nil
+}
+
+func parsePositiveDuration(value string, fldPath *field.Path) (time.Duration, field.ErrorList) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, field.ErrorList{field.Invalid(fldPath, value, err.Error())}
+	}
+	return d, validatePositiveDuration(d, fldPath)
+}
+
+func parseNonNegativeFloat(value string, fldPath *field.Path) (float64, field.ErrorList) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err == nil && (math.IsNaN(f) || math.IsInf(f, 0)) {
+		err = fmt.Errorf("%q is not a finite number", value)
+	}
+	if err != nil {
+		return 0, field.ErrorList{field.Invalid(fldPath, value, fmt.Sprintf("must be a number: %v", err))}
+	}
+	if f < 0 {
+		return 0, field.ErrorList{field.Invalid(fldPath, value, "must be greater than or equal to 0")}
+	}
+	return f, nil
+}
+
+func parseFraction(value string, fldPath *field.Path) (float64, field.ErrorList) {
+	f, errs := parseNonNegativeFloat(value, fldPath)
+	if len(errs) == 0 && f > 1 {
+		errs = append(errs, field.Invalid(fldPath, value, "must be less than or equal to 1"))
+	}
+	return f, errs
+}
+
+func parsePositiveFloat(value string, fldPath *field.Path) (float64, field.ErrorList) {
+	f, errs := parseNonNegativeFloat(value, fldPath)
+	if len(errs) == 0 && f == 0 {
+		errs = append(errs, field.Invalid(fldPath, value, "must be greater than 0"))
+	}
+	return f, errs
+}
+
+// isEmptySelector returns whether the selector has no requirement, such a selector selects every pod.
+func isEmptySelector(selector *metav1.LabelSelector) bool {
+	return len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0
+}
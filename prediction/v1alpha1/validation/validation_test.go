@@ -0,0 +1,321 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/predictiontest"
+)
+
+var start = metav1.NewTime(time.Date(2021, 11, 24, 8, 0, 0, 0, time.UTC))
+
+// newPodGroupPrediction returns the fixture predicting the workload web in range mode, and its memory with a
+// custom algorithm.
+func newPodGroupPrediction() *v1alpha1.PodGroupPrediction {
+	pgp := predictiontest.NewPodGroupPrediction("default", "web")
+	pgp.ResourceVersion = "1"
+	pgp.Spec.Mode, pgp.Spec.PredictionLength = v1alpha1.PredictionModeRange, metav1.Duration{Duration: time.Hour}
+	pgp.Spec.Pods = nil
+	pgp.Spec.WorkloadRef = &autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"}
+	pgp.Spec.MetricPredictionConfigs = append(pgp.Spec.MetricPredictionConfigs,
+		v1alpha1.AlgorithmProviderConfig{MetricName: "memory", Custom: &v1alpha1.CustomAlgorithmConfig{Name: "example.com/seasonal-naive"}})
+	return pgp
+}
+
+func newNodePrediction() *v1alpha1.NodePrediction {
+	np := predictiontest.NewNodePrediction("node-1")
+	np.ResourceVersion = "1"
+	return np
+}
+
+// expectErrors checks that the errors are exactly the expected ones, each given as its type and field.
+func expectErrors(t *testing.T, name string, errs field.ErrorList, expected ...field.Error) {
+	t.Helper()
+	if len(errs) != len(expected) {
+		t.Errorf("%s: expected %d errors, got %v", name, len(expected), errs)
+		return
+	}
+	for i := range expected {
+		if errs[i].Type != expected[i].Type || errs[i].Field != expected[i].Field {
+			t.Errorf("%s: expected the error %s on %s, got %v", name, expected[i].Type, expected[i].Field, errs[i])
+		}
+	}
+}
+
+func TestValidatePodGroupPrediction(t *testing.T) {
+	cases := []struct {
+		name     string
+		update   func(pgp *v1alpha1.PodGroupPrediction)
+		expected []field.Error
+	}{
+		{
+			name:   "valid",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {},
+		},
+		{
+			name: "end after start",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				end := metav1.NewTime(start.Add(time.Hour))
+				pgp.Spec.Start, pgp.Spec.End = &start, &end
+			},
+		},
+		{
+			name: "end equal to start",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.Start, pgp.Spec.End = &start, &start
+			},
+			expected: []field.Error{{Type: field.ErrorTypeInvalid, Field: "spec.end"}},
+		},
+		{
+			name: "end before start",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				end := metav1.NewTime(start.Add(-time.Hour))
+				pgp.Spec.Start, pgp.Spec.End = &start, &end
+			},
+			expected: []field.Error{{Type: field.ErrorTypeInvalid, Field: "spec.end"}},
+		},
+		{
+			name: "only an end",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.End = &start
+			},
+		},
+		{
+			name: "range without predictionLength",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.PredictionLength = metav1.Duration{}
+			},
+			expected: []field.Error{{Type: field.ErrorTypeRequired, Field: "spec.predictionLength"}},
+		},
+		{
+			name: "negative predictionLength",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.PredictionLength = metav1.Duration{Duration: -time.Hour}
+			},
+			expected: []field.Error{{Type: field.ErrorTypeInvalid, Field: "spec.predictionLength"}},
+		},
+		{
+			name: "instant without predictionLength",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.Mode, pgp.Spec.PredictionLength = v1alpha1.PredictionModeInstant, metav1.Duration{}
+			},
+		},
+		{
+			name: "instant with predictionLength",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.Mode = v1alpha1.PredictionModeInstant
+			},
+			expected: []field.Error{{Type: field.ErrorTypeForbidden, Field: "spec.predictionLength"}},
+		},
+		{
+			name: "unsupported mode",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.Mode = "forever"
+			},
+			expected: []field.Error{{Type: field.ErrorTypeNotSupported, Field: "spec.mode"}},
+		},
+		{
+			name: "no selection",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.WorkloadRef = nil
+			},
+			expected: []field.Error{{Type: field.ErrorTypeRequired, Field: "spec"}},
+		},
+		{
+			name: "duplicate pods",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.Pods = []string{"web-0", "web-1", "web-0"}
+			},
+			expected: []field.Error{{Type: field.ErrorTypeDuplicate, Field: "spec.pods[2]"}},
+		},
+		{
+			name: "no algorithm",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.MetricPredictionConfigs[0].DSP = nil
+			},
+			expected: []field.Error{{Type: field.ErrorTypeRequired, Field: "spec.metricPredictionConfigs[0]"}},
+		},
+		{
+			name: "two algorithms",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.MetricPredictionConfigs[1].DSP = predictiontest.DSP()
+			},
+			expected: []field.Error{{Type: field.ErrorTypeForbidden, Field: "spec.metricPredictionConfigs[1]"}},
+		},
+		{
+			name: "an algorithm and an ensemble",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.MetricPredictionConfigs[0].Ensemble = &v1alpha1.EnsembleConfig{Members: []v1alpha1.EnsembleMember{
+					{AlgorithmConfig: v1alpha1.AlgorithmConfig{DSP: predictiontest.DSP()}, Weight: 1},
+				}}
+			},
+			expected: []field.Error{{Type: field.ErrorTypeForbidden, Field: "spec.metricPredictionConfigs[0]"}},
+		},
+		{
+			name: "fallback with two algorithms",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.MetricPredictionConfigs[0].Fallbacks = []v1alpha1.AlgorithmConfig{
+					{DSP: predictiontest.DSP(), Custom: &v1alpha1.CustomAlgorithmConfig{Name: "example.com/naive"}},
+				}
+			},
+			expected: []field.Error{{Type: field.ErrorTypeForbidden, Field: "spec.metricPredictionConfigs[0].fallbacks[0]"}},
+		},
+		{
+			name: "duplicate metric names",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.MetricPredictionConfigs[1].MetricName = "cpu"
+			},
+			expected: []field.Error{{Type: field.ErrorTypeDuplicate, Field: "spec.metricPredictionConfigs[1].metricName"}},
+		},
+		{
+			name: "no metric",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.MetricPredictionConfigs = nil
+			},
+			expected: []field.Error{{Type: field.ErrorTypeRequired, Field: "spec.metricPredictionConfigs"}},
+		},
+	}
+	for _, name := range []string{v1alpha1.AlgorithmDSP, v1alpha1.AlgorithmPercentile, v1alpha1.AlgorithmEnsemble} {
+		name := name
+		cases = append(cases, struct {
+			name     string
+			update   func(pgp *v1alpha1.PodGroupPrediction)
+			expected []field.Error
+		}{
+			name: "reserved custom name " + name,
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.MetricPredictionConfigs[1].Custom.Name = name
+			},
+			expected: []field.Error{{Type: field.ErrorTypeInvalid, Field: "spec.metricPredictionConfigs[1].custom.name"}},
+		})
+	}
+	for _, c := range cases {
+		pgp := newPodGroupPrediction()
+		c.update(pgp)
+		expectErrors(t, c.name, ValidatePodGroupPrediction(pgp), c.expected...)
+	}
+}
+
+func TestValidateNodePrediction(t *testing.T) {
+	cases := []struct {
+		name     string
+		update   func(np *v1alpha1.NodePrediction)
+		expected []field.Error
+	}{
+		{
+			name:   "valid",
+			update: func(np *v1alpha1.NodePrediction) {},
+		},
+		{
+			name: "namespaced",
+			update: func(np *v1alpha1.NodePrediction) {
+				np.Namespace = "default"
+			},
+			expected: []field.Error{{Type: field.ErrorTypeForbidden, Field: "metadata.namespace"}},
+		},
+		{
+			name: "no node name",
+			update: func(np *v1alpha1.NodePrediction) {
+				np.Spec.NodeName = ""
+			},
+			expected: []field.Error{{Type: field.ErrorTypeRequired, Field: "spec.nodeName"}},
+		},
+		{
+			name: "no period",
+			update: func(np *v1alpha1.NodePrediction) {
+				np.Spec.Period = metav1.Duration{}
+			},
+			expected: []field.Error{{Type: field.ErrorTypeInvalid, Field: "spec.period"}},
+		},
+		{
+			name: "duplicate metric names",
+			update: func(np *v1alpha1.NodePrediction) {
+				np.Spec.MetricPredictionConfigs = append(np.Spec.MetricPredictionConfigs, v1alpha1.AlgorithmProviderConfig{MetricName: "memory", DSP: predictiontest.DSP()})
+			},
+			expected: []field.Error{{Type: field.ErrorTypeDuplicate, Field: "spec.metricPredictionConfigs[1].metricName"}},
+		},
+	}
+	for _, c := range cases {
+		np := newNodePrediction()
+		c.update(np)
+		expectErrors(t, c.name, ValidateNodePrediction(np), c.expected...)
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	pgpCases := []struct {
+		name     string
+		update   func(pgp *v1alpha1.PodGroupPrediction)
+		expected []field.Error
+	}{
+		{
+			name: "mutable predictionLength",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.PredictionLength = metav1.Duration{Duration: 2 * time.Hour}
+			},
+		},
+		{
+			name: "immutable mode",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.Mode, pgp.Spec.PredictionLength = v1alpha1.PredictionModeInstant, metav1.Duration{}
+			},
+			expected: []field.Error{{Type: field.ErrorTypeInvalid, Field: "spec.mode"}},
+		},
+		{
+			name: "immutable workloadRef",
+			update: func(pgp *v1alpha1.PodGroupPrediction) {
+				pgp.Spec.WorkloadRef = &autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "web"}
+			},
+			expected: []field.Error{{Type: field.ErrorTypeInvalid, Field: "spec.workloadRef"}},
+		},
+	}
+	for _, c := range pgpCases {
+		pgp := newPodGroupPrediction()
+		c.update(pgp)
+		expectErrors(t, c.name, ValidateUpdate(pgp, newPodGroupPrediction()), c.expected...)
+	}
+
+	npCases := []struct {
+		name     string
+		update   func(np *v1alpha1.NodePrediction)
+		expected []field.Error
+	}{
+		{
+			name: "mutable period",
+			update: func(np *v1alpha1.NodePrediction) {
+				np.Spec.Period = metav1.Duration{Duration: 5 * time.Minute}
+			},
+		},
+		{
+			name: "immutable mode",
+			update: func(np *v1alpha1.NodePrediction) {
+				np.Spec.Mode = v1alpha1.PredictionModeRange
+			},
+			expected: []field.Error{{Type: field.ErrorTypeInvalid, Field: "spec.mode"}},
+		},
+		{
+			name: "immutable nodeName",
+			update: func(np *v1alpha1.NodePrediction) {
+				np.Spec.NodeName = "node-2"
+			},
+			expected: []field.Error{{Type: field.ErrorTypeInvalid, Field: "spec.nodeName"}},
+		},
+	}
+	for _, c := range npCases {
+		np := newNodePrediction()
+		c.update(np)
+		expectErrors(t, c.name, ValidateUpdate(np, newNodePrediction()), c.expected...)
+	}
+
+	if errs := ValidateUpdate(newNodePrediction(), newPodGroupPrediction()); len(errs) != 1 || errs[0].Type != field.ErrorTypeInternal {
+		t.Errorf("expected an internal error for different kinds, got %v", errs)
+	}
+	if errs := ValidateUpdate(&v1alpha1.NodePredictionList{}, &v1alpha1.NodePredictionList{}); len(errs) != 1 || errs[0].Type != field.ErrorTypeInternal {
+		t.Errorf("expected an internal error for an unsupported kind, got %v", errs)
+	}
+}
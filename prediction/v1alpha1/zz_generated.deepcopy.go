@@ -6,9 +6,41 @@ package v1alpha1
 
 import (
 	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlgorithmConfig) DeepCopyInto(out *AlgorithmConfig) {
+	*out = *in
+	if in.DSP != nil {
+		in, out := &in.DSP, &out.DSP
+		*out = new(DspConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Percentile != nil {
+		in, out := &in.Percentile, &out.Percentile
+		*out = new(PercentileConfig)
+		**out = **in
+	}
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = new(CustomAlgorithmConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlgorithmConfig.
+func (in *AlgorithmConfig) DeepCopy() *AlgorithmConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AlgorithmConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlgorithmProviderConfig) DeepCopyInto(out *AlgorithmProviderConfig) {
 	*out = *in
@@ -22,6 +54,28 @@ func (in *AlgorithmProviderConfig) DeepCopyInto(out *AlgorithmProviderConfig) {
 		*out = new(PercentileConfig)
 		**out = **in
 	}
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = new(CustomAlgorithmConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ensemble != nil {
+		in, out := &in.Ensemble, &out.Ensemble
+		*out = new(EnsembleConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fallbacks != nil {
+		in, out := &in.Fallbacks, &out.Fallbacks
+		*out = make([]AlgorithmConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(MetricSourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -35,6 +89,27 @@ func (in *AlgorithmProviderConfig) DeepCopy() *AlgorithmProviderConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomAlgorithmConfig) DeepCopyInto(out *CustomAlgorithmConfig) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomAlgorithmConfig.
+func (in *CustomAlgorithmConfig) DeepCopy() *CustomAlgorithmConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomAlgorithmConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DspConfig) DeepCopyInto(out *DspConfig) {
 	*out = *in
@@ -56,6 +131,46 @@ func (in *DspConfig) DeepCopy() *DspConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnsembleConfig) DeepCopyInto(out *EnsembleConfig) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]EnsembleMember, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnsembleConfig.
+func (in *EnsembleConfig) DeepCopy() *EnsembleConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EnsembleConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnsembleMember) DeepCopyInto(out *EnsembleMember) {
+	*out = *in
+	in.AlgorithmConfig.DeepCopyInto(&out.AlgorithmConfig)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnsembleMember.
+func (in *EnsembleMember) DeepCopy() *EnsembleMember {
+	if in == nil {
+		return nil
+	}
+	out := new(EnsembleMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EstimatorConfigs) DeepCopyInto(out *EstimatorConfigs) {
 	*out = *in
@@ -69,6 +184,16 @@ func (in *EstimatorConfigs) DeepCopyInto(out *EstimatorConfigs) {
 		*out = new(FFTEstimatorConfig)
 		**out = **in
 	}
+	if in.HoltWinters != nil {
+		in, out := &in.HoltWinters, &out.HoltWinters
+		*out = new(HoltWintersEstimatorConfig)
+		**out = **in
+	}
+	if in.LinearRegression != nil {
+		in, out := &in.LinearRegression, &out.LinearRegression
+		*out = new(LinearRegressionEstimatorConfig)
+		**out = **in
+	}
 	return
 }
 
@@ -82,6 +207,45 @@ func (in *EstimatorConfigs) DeepCopy() *EstimatorConfigs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvaluationWindow) DeepCopyInto(out *EvaluationWindow) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationWindow.
+func (in *EvaluationWindow) DeepCopy() *EvaluationWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(EvaluationWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalMetricSource) DeepCopyInto(out *ExternalMetricSource) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalMetricSource.
+func (in *ExternalMetricSource) DeepCopy() *ExternalMetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalMetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FFTEstimatorConfig) DeepCopyInto(out *FFTEstimatorConfig) {
 	*out = *in
@@ -114,6 +278,38 @@ func (in *HistogramConfig) DeepCopy() *HistogramConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HoltWintersEstimatorConfig) DeepCopyInto(out *HoltWintersEstimatorConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HoltWintersEstimatorConfig.
+func (in *HoltWintersEstimatorConfig) DeepCopy() *HoltWintersEstimatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HoltWintersEstimatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LinearRegressionEstimatorConfig) DeepCopyInto(out *LinearRegressionEstimatorConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LinearRegressionEstimatorConfig.
+func (in *LinearRegressionEstimatorConfig) DeepCopy() *LinearRegressionEstimatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LinearRegressionEstimatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MaxValueEstimatorConfig) DeepCopyInto(out *MaxValueEstimatorConfig) {
 	*out = *in
@@ -130,6 +326,70 @@ func (in *MaxValueEstimatorConfig) DeepCopy() *MaxValueEstimatorConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricAccuracy) DeepCopyInto(out *MetricAccuracy) {
+	*out = *in
+	in.Window.DeepCopyInto(&out.Window)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricAccuracy.
+func (in *MetricAccuracy) DeepCopy() *MetricAccuracy {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricAccuracy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricEstimator) DeepCopyInto(out *MetricEstimator) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricEstimator.
+func (in *MetricEstimator) DeepCopy() *MetricEstimator {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricEstimator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricSourceSpec) DeepCopyInto(out *MetricSourceSpec) {
+	*out = *in
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(ResourceMetricSource)
+		**out = **in
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusMetricSource)
+		**out = **in
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ExternalMetricSource)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSourceSpec.
+func (in *MetricSourceSpec) DeepCopy() *MetricSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePrediction) DeepCopyInto(out *NodePrediction) {
 	*out = *in
@@ -218,6 +478,29 @@ func (in *NodePredictionResourceSpec) DeepCopy() *NodePredictionResourceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePredictionResourceStatus) DeepCopyInto(out *NodePredictionResourceStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]PredictionCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Estimators != nil {
+		in, out := &in.Estimators, &out.Estimators
+		*out = make([]MetricEstimator, len(*in))
+		copy(*out, *in)
+	}
+	if in.Accuracy != nil {
+		in, out := &in.Accuracy, &out.Accuracy
+		*out = make([]MetricAccuracy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Consumed != nil {
 		in, out := &in.Consumed, &out.Consumed
 		*out = make(Prediction, len(*in))
@@ -232,7 +515,7 @@ func (in *NodePredictionResourceStatus) DeepCopyInto(out *NodePredictionResource
 					if (*in)[i] != nil {
 						in, out := &(*in)[i], &(*out)[i]
 						*out = new(Vector)
-						**out = **in
+						(*in).DeepCopyInto(*out)
 					}
 				}
 			}
@@ -297,24 +580,6 @@ func (in *PodGroupPrediction) DeepCopyObject() runtime.Object {
 	return nil
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodGroupPredictionCondition) DeepCopyInto(out *PodGroupPredictionCondition) {
-	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodGroupPredictionCondition.
-func (in *PodGroupPredictionCondition) DeepCopy() *PodGroupPredictionCondition {
-	if in == nil {
-		return nil
-	}
-	out := new(PodGroupPredictionCondition)
-	in.DeepCopyInto(out)
-	return out
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodGroupPredictionList) DeepCopyInto(out *PodGroupPredictionList) {
 	*out = *in
@@ -396,7 +661,23 @@ func (in *PodGroupPredictionStatus) DeepCopyInto(out *PodGroupPredictionStatus)
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]PodGroupPredictionCondition, len(*in))
+		*out = make([]PredictionCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Estimators != nil {
+		in, out := &in.Estimators, &out.Estimators
+		*out = make([]MetricEstimator, len(*in))
+		copy(*out, *in)
+	}
+	if in.Accuracy != nil {
+		in, out := &in.Accuracy, &out.Accuracy
+		*out = make([]MetricAccuracy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -415,7 +696,7 @@ func (in *PodGroupPredictionStatus) DeepCopyInto(out *PodGroupPredictionStatus)
 					if (*in)[i] != nil {
 						in, out := &(*in)[i], &(*out)[i]
 						*out = new(Vector)
-						**out = **in
+						(*in).DeepCopyInto(*out)
 					}
 				}
 			}
@@ -443,7 +724,7 @@ func (in *PodGroupPredictionStatus) DeepCopyInto(out *PodGroupPredictionStatus)
 							if (*in)[i] != nil {
 								in, out := &(*in)[i], &(*out)[i]
 								*out = new(Vector)
-								**out = **in
+								(*in).DeepCopyInto(*out)
 							}
 						}
 					}
@@ -482,7 +763,7 @@ func (in Prediction) DeepCopyInto(out *Prediction) {
 					if (*in)[i] != nil {
 						in, out := &(*in)[i], &(*out)[i]
 						*out = new(Vector)
-						**out = **in
+						(*in).DeepCopyInto(*out)
 					}
 				}
 			}
@@ -502,6 +783,78 @@ func (in Prediction) DeepCopy() Prediction {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PredictionCondition) DeepCopyInto(out *PredictionCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PredictionCondition.
+func (in *PredictionCondition) DeepCopy() *PredictionCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PredictionCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusMetricSource) DeepCopyInto(out *PrometheusMetricSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusMetricSource.
+func (in *PrometheusMetricSource) DeepCopy() *PrometheusMetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusMetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Quantiles) DeepCopyInto(out *Quantiles) {
+	{
+		in := &in
+		*out = make(Quantiles, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Quantiles.
+func (in Quantiles) DeepCopy() Quantiles {
+	if in == nil {
+		return nil
+	}
+	out := new(Quantiles)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceMetricSource) DeepCopyInto(out *ResourceMetricSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMetricSource.
+func (in *ResourceMetricSource) DeepCopy() *ResourceMetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceMetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in TimeSeries) DeepCopyInto(out *TimeSeries) {
 	{
@@ -511,7 +864,7 @@ func (in TimeSeries) DeepCopyInto(out *TimeSeries) {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
 				*out = new(Vector)
-				**out = **in
+				(*in).DeepCopyInto(*out)
 			}
 		}
 		return
@@ -531,6 +884,13 @@ func (in TimeSeries) DeepCopy() TimeSeries {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Vector) DeepCopyInto(out *Vector) {
 	*out = *in
+	if in.Quantiles != nil {
+		in, out := &in.Quantiles, &out.Quantiles
+		*out = make(Quantiles, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -0,0 +1,480 @@
+package v1beta1
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/conversion"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+	"github.com/gocrane-io/api/prediction/v1alpha1/helper"
+)
+
+// The v1alpha1 numbers are plain decimal strings. The values of a metric are in the unit of the metric, milli cores
+// for cpu, and are converted to quantities of its base unit without rounding. A quantity keeps nine decimals of its
+// base unit, one nano core or one micro milli core for cpu, smaller fractions are rounded up.
+
+func convertStringToQuantity(in string, out *resource.Quantity) error {
+	if in == "" {
+		*out = resource.Quantity{}
+		return nil
+	}
+	if _, err := strconv.ParseFloat(in, 64); err != nil {
+		return fmt.Errorf("%q is not a number: %v", in, err)
+	}
+	q, err := resource.ParseQuantity(in)
+	if err != nil {
+		return err
+	}
+	*out = q
+	return nil
+}
+
+// convertQuantityToString formats a quantity as a plain decimal, a zero quantity without a format was never parsed
+// and comes back empty, so that an unset v1alpha1 number is still defaulted.
+func convertQuantityToString(in *resource.Quantity, out *string) {
+	if in.IsZero() && in.Format == "" {
+		*out = ""
+		return
+	}
+	// the exact decimal of the quantity avoids the rounding errors of AsApproximateFloat64, e.g. 10e-6
+	if value, err := strconv.ParseFloat(in.AsDec().String(), 64); err == nil {
+		*out = helper.FormatValue(value)
+		return
+	}
+	*out = helper.FormatValue(in.AsApproximateFloat64())
+}
+
+func convertStringToDuration(in string, out *metav1.Duration) error {
+	if in == "" {
+		*out = metav1.Duration{}
+		return nil
+	}
+	d, err := time.ParseDuration(in)
+	if err != nil {
+		return err
+	}
+	*out = metav1.Duration{Duration: d}
+	return nil
+}
+
+// convertDurationToString formats a duration the way it is usually written, without its zero trailing units, e.g.
+// 1m rather than 1m0s, so that a v1alpha1 duration comes back as written. A zero duration is an unset one, it
+// comes back empty rather than 0s.
+func convertDurationToString(in *metav1.Duration, out *string) {
+	if in.Duration == 0 {
+		*out = ""
+		return
+	}
+	formatted := in.Duration.String()
+	if strings.HasSuffix(formatted, "m0s") {
+		formatted = strings.TrimSuffix(formatted, "0s")
+	}
+	if strings.HasSuffix(formatted, "h0m") {
+		formatted = strings.TrimSuffix(formatted, "0m")
+	}
+	*out = formatted
+}
+
+func Convert_v1alpha1_DspConfig_To_v1beta1_DspConfig(in *v1alpha1.DspConfig, out *DspConfig, s conversion.Scope) error {
+	if err := autoConvert_v1alpha1_DspConfig_To_v1beta1_DspConfig(in, out, s); err != nil {
+		return err
+	}
+	if err := convertStringToDuration(in.SampleInterval, &out.SampleInterval); err != nil {
+		return fmt.Errorf("sampleInterval: %v", err)
+	}
+	if err := convertStringToDuration(in.HistoryLength, &out.HistoryLength); err != nil {
+		return fmt.Errorf("historyLength: %v", err)
+	}
+	return nil
+}
+
+func Convert_v1beta1_DspConfig_To_v1alpha1_DspConfig(in *DspConfig, out *v1alpha1.DspConfig, s conversion.Scope) error {
+	if err := autoConvert_v1beta1_DspConfig_To_v1alpha1_DspConfig(in, out, s); err != nil {
+		return err
+	}
+	convertDurationToString(&in.SampleInterval, &out.SampleInterval)
+	convertDurationToString(&in.HistoryLength, &out.HistoryLength)
+	return nil
+}
+
+func Convert_v1alpha1_CustomAlgorithmConfig_To_v1beta1_CustomAlgorithmConfig(in *v1alpha1.CustomAlgorithmConfig, out *CustomAlgorithmConfig, s conversion.Scope) error {
+	if err := autoConvert_v1alpha1_CustomAlgorithmConfig_To_v1beta1_CustomAlgorithmConfig(in, out, s); err != nil {
+		return err
+	}
+	if err := convertStringToDuration(in.SampleInterval, &out.SampleInterval); err != nil {
+		return fmt.Errorf("sampleInterval: %v", err)
+	}
+	if err := convertStringToDuration(in.HistoryLength, &out.HistoryLength); err != nil {
+		return fmt.Errorf("historyLength: %v", err)
+	}
+	return nil
+}
+
+func Convert_v1beta1_CustomAlgorithmConfig_To_v1alpha1_CustomAlgorithmConfig(in *CustomAlgorithmConfig, out *v1alpha1.CustomAlgorithmConfig, s conversion.Scope) error {
+	if err := autoConvert_v1beta1_CustomAlgorithmConfig_To_v1alpha1_CustomAlgorithmConfig(in, out, s); err != nil {
+		return err
+	}
+	convertDurationToString(&in.SampleInterval, &out.SampleInterval)
+	convertDurationToString(&in.HistoryLength, &out.HistoryLength)
+	return nil
+}
+
+func Convert_v1alpha1_FFTEstimatorConfig_To_v1beta1_FFTEstimatorConfig(in *v1alpha1.FFTEstimatorConfig, out *FFTEstimatorConfig, s conversion.Scope) error {
+	if err := autoConvert_v1alpha1_FFTEstimatorConfig_To_v1beta1_FFTEstimatorConfig(in, out, s); err != nil {
+		return err
+	}
+	if err := convertStringToQuantity(in.MarginFraction, &out.MarginFraction); err != nil {
+		return fmt.Errorf("marginFraction: %v", err)
+	}
+	if err := convertStringToQuantity(in.LowAmplitudeThreshold, &out.LowAmplitudeThreshold); err != nil {
+		return fmt.Errorf("lowAmplitudeThreshold: %v", err)
+	}
+	if err := convertStringToQuantity(in.HighFrequencyThreshold, &out.HighFrequencyThreshold); err != nil {
+		return fmt.Errorf("highFrequencyThreshold: %v", err)
+	}
+	return nil
+}
+
+func Convert_v1beta1_FFTEstimatorConfig_To_v1alpha1_FFTEstimatorConfig(in *FFTEstimatorConfig, out *v1alpha1.FFTEstimatorConfig, s conversion.Scope) error {
+	if err := autoConvert_v1beta1_FFTEstimatorConfig_To_v1alpha1_FFTEstimatorConfig(in, out, s); err != nil {
+		return err
+	}
+	convertQuantityToString(&in.MarginFraction, &out.MarginFraction)
+	convertQuantityToString(&in.LowAmplitudeThreshold, &out.LowAmplitudeThreshold)
+	convertQuantityToString(&in.HighFrequencyThreshold, &out.HighFrequencyThreshold)
+	return nil
+}
+
+func Convert_v1alpha1_HoltWintersEstimatorConfig_To_v1beta1_HoltWintersEstimatorConfig(in *v1alpha1.HoltWintersEstimatorConfig, out *HoltWintersEstimatorConfig, s conversion.Scope) error {
+	if err := autoConvert_v1alpha1_HoltWintersEstimatorConfig_To_v1beta1_HoltWintersEstimatorConfig(in, out, s); err != nil {
+		return err
+	}
+	if err := convertStringToQuantity(in.Alpha, &out.Alpha); err != nil {
+		return fmt.Errorf("alpha: %v", err)
+	}
+	if err := convertStringToQuantity(in.Beta, &out.Beta); err != nil {
+		return fmt.Errorf("beta: %v", err)
+	}
+	if err := convertStringToQuantity(in.Gamma, &out.Gamma); err != nil {
+		return fmt.Errorf("gamma: %v", err)
+	}
+	if err := convertStringToDuration(in.SeasonLength, &out.SeasonLength); err != nil {
+		return fmt.Errorf("seasonLength: %v", err)
+	}
+	return nil
+}
+
+func Convert_v1beta1_HoltWintersEstimatorConfig_To_v1alpha1_HoltWintersEstimatorConfig(in *HoltWintersEstimatorConfig, out *v1alpha1.HoltWintersEstimatorConfig, s conversion.Scope) error {
+	if err := autoConvert_v1beta1_HoltWintersEstimatorConfig_To_v1alpha1_HoltWintersEstimatorConfig(in, out, s); err != nil {
+		return err
+	}
+	convertQuantityToString(&in.Alpha, &out.Alpha)
+	convertQuantityToString(&in.Beta, &out.Beta)
+	convertQuantityToString(&in.Gamma, &out.Gamma)
+	convertDurationToString(&in.SeasonLength, &out.SeasonLength)
+	return nil
+}
+
+func Convert_v1alpha1_LinearRegressionEstimatorConfig_To_v1beta1_LinearRegressionEstimatorConfig(in *v1alpha1.LinearRegressionEstimatorConfig, out *LinearRegressionEstimatorConfig, s conversion.Scope) error {
+	if err := autoConvert_v1alpha1_LinearRegressionEstimatorConfig_To_v1beta1_LinearRegressionEstimatorConfig(in, out, s); err != nil {
+		return err
+	}
+	if err := convertStringToDuration(in.Window, &out.Window); err != nil {
+		return fmt.Errorf("window: %v", err)
+	}
+	return nil
+}
+
+func Convert_v1beta1_LinearRegressionEstimatorConfig_To_v1alpha1_LinearRegressionEstimatorConfig(in *LinearRegressionEstimatorConfig, out *v1alpha1.LinearRegressionEstimatorConfig, s conversion.Scope) error {
+	if err := autoConvert_v1beta1_LinearRegressionEstimatorConfig_To_v1alpha1_LinearRegressionEstimatorConfig(in, out, s); err != nil {
+		return err
+	}
+	convertDurationToString(&in.Window, &out.Window)
+	return nil
+}
+
+func Convert_v1alpha1_PercentileConfig_To_v1beta1_PercentileConfig(in *v1alpha1.PercentileConfig, out *PercentileConfig, s conversion.Scope) error {
+	if err := autoConvert_v1alpha1_PercentileConfig_To_v1beta1_PercentileConfig(in, out, s); err != nil {
+		return err
+	}
+	if err := convertStringToDuration(in.SampleInterval, &out.SampleInterval); err != nil {
+		return fmt.Errorf("sampleInterval: %v", err)
+	}
+	if err := convertStringToQuantity(in.MinSampleWeight, &out.MinSampleWeight); err != nil {
+		return fmt.Errorf("minSampleWeight: %v", err)
+	}
+	return nil
+}
+
+func Convert_v1beta1_PercentileConfig_To_v1alpha1_PercentileConfig(in *PercentileConfig, out *v1alpha1.PercentileConfig, s conversion.Scope) error {
+	if err := autoConvert_v1beta1_PercentileConfig_To_v1alpha1_PercentileConfig(in, out, s); err != nil {
+		return err
+	}
+	convertDurationToString(&in.SampleInterval, &out.SampleInterval)
+	convertQuantityToString(&in.MinSampleWeight, &out.MinSampleWeight)
+	return nil
+}
+
+func Convert_v1alpha1_HistogramConfig_To_v1beta1_HistogramConfig(in *v1alpha1.HistogramConfig, out *HistogramConfig, s conversion.Scope) error {
+	if err := autoConvert_v1alpha1_HistogramConfig_To_v1beta1_HistogramConfig(in, out, s); err != nil {
+		return err
+	}
+	quantities := []struct {
+		name string
+		in   string
+		out  *resource.Quantity
+	}{
+		{"maxValue", in.MaxValue, &out.MaxValue},
+		{"bucketSize", in.BucketSize, &out.BucketSize},
+		{"firstBucketSize", in.FirstBucketSize, &out.FirstBucketSize},
+		{"bucketSizeGrowthRatio", in.BucketSizeGrowthRatio, &out.BucketSizeGrowthRatio},
+	}
+	for _, q := range quantities {
+		if err := convertStringToQuantity(q.in, q.out); err != nil {
+			return fmt.Errorf("%s: %v", q.name, err)
+		}
+	}
+	if err := convertStringToDuration(in.HalfLife, &out.HalfLife); err != nil {
+		return fmt.Errorf("halfLife: %v", err)
+	}
+	return nil
+}
+
+func Convert_v1beta1_HistogramConfig_To_v1alpha1_HistogramConfig(in *HistogramConfig, out *v1alpha1.HistogramConfig, s conversion.Scope) error {
+	if err := autoConvert_v1beta1_HistogramConfig_To_v1alpha1_HistogramConfig(in, out, s); err != nil {
+		return err
+	}
+	convertQuantityToString(&in.MaxValue, &out.MaxValue)
+	convertDurationToString(&in.HalfLife, &out.HalfLife)
+	convertQuantityToString(&in.BucketSize, &out.BucketSize)
+	convertQuantityToString(&in.FirstBucketSize, &out.FirstBucketSize)
+	convertQuantityToString(&in.BucketSizeGrowthRatio, &out.BucketSizeGrowthRatio)
+	return nil
+}
+
+// Convert_v1alpha1_Vector_To_v1beta1_Vector converts a vector whose metric is unknown, the value is treated as a
+// plain number. Vectors of a Prediction are converted according to the unit of their metric instead.
+func Convert_v1alpha1_Vector_To_v1beta1_Vector(in *v1alpha1.Vector, out *Vector, s conversion.Scope) error {
+	if err := autoConvert_v1alpha1_Vector_To_v1beta1_Vector(in, out, s); err != nil {
+		return err
+	}
+	return convertVectorToV1beta1("", in, out)
+}
+
+// Convert_v1beta1_Vector_To_v1alpha1_Vector is the inverse of Convert_v1alpha1_Vector_To_v1beta1_Vector.
+func Convert_v1beta1_Vector_To_v1alpha1_Vector(in *Vector, out *v1alpha1.Vector, s conversion.Scope) error {
+	if err := autoConvert_v1beta1_Vector_To_v1alpha1_Vector(in, out, s); err != nil {
+		return err
+	}
+	convertVectorToV1alpha1("", in, out)
+	return nil
+}
+
+// Convert_v1alpha1_Quantiles_To_v1beta1_Quantiles converts quantiles whose metric is unknown, the values are
+// treated as plain numbers. Quantiles of a Vector of a Prediction are converted according to the unit of their
+// metric instead.
+func Convert_v1alpha1_Quantiles_To_v1beta1_Quantiles(in *v1alpha1.Quantiles, out *Quantiles, s conversion.Scope) error {
+	if *in == nil {
+		*out = nil
+		return nil
+	}
+	*out = make(Quantiles, len(*in))
+	for name, q := range *in {
+		value, err := helper.ParseValue("", q)
+		if err != nil {
+			return fmt.Errorf("quantile %s: %v", name, err)
+		}
+		(*out)[name] = helper.ToQuantity("", value)
+	}
+	return nil
+}
+
+// Convert_v1beta1_Quantiles_To_v1alpha1_Quantiles is the inverse of Convert_v1alpha1_Quantiles_To_v1beta1_Quantiles.
+func Convert_v1beta1_Quantiles_To_v1alpha1_Quantiles(in *Quantiles, out *v1alpha1.Quantiles, s conversion.Scope) error {
+	if *in == nil {
+		*out = nil
+		return nil
+	}
+	*out = make(v1alpha1.Quantiles, len(*in))
+	for name, q := range *in {
+		(*out)[name] = helper.FormatValue(helper.FromQuantity("", q))
+	}
+	return nil
+}
+
+// convertVectorToV1beta1 converts the value, the bounds and the quantiles of a vector into quantities according
+// to the unit of the resource.
+func convertVectorToV1beta1(resourceName v1alpha1.ResourceName, in *v1alpha1.Vector, out *Vector) error {
+	sample, err := helper.ParseVector(resourceName, in)
+	if err != nil {
+		return err
+	}
+	q := helper.ToQuantitySample(resourceName, sample)
+	*out = Vector{Value: q.Value, Timestamp: q.Timestamp, LowerBound: q.LowerBound, UpperBound: q.UpperBound, Quantiles: q.Quantiles}
+	return nil
+}
+
+// convertVectorToV1alpha1 is the inverse of convertVectorToV1beta1.
+func convertVectorToV1alpha1(resourceName v1alpha1.ResourceName, in *Vector, out *v1alpha1.Vector) {
+	q := helper.QuantitySample{Value: in.Value, Timestamp: in.Timestamp, LowerBound: in.LowerBound, UpperBound: in.UpperBound, Quantiles: in.Quantiles}
+	*out = *helper.FormatVector(helper.FromQuantitySample(resourceName, q))
+}
+
+// Convert_v1alpha1_Prediction_To_v1beta1_Prediction converts the values of every time series into quantities
+// according to the unit of the metric, e.g. a cpu value of 500 milli cores becomes 500m.
+func Convert_v1alpha1_Prediction_To_v1beta1_Prediction(in *v1alpha1.Prediction, out *Prediction, s conversion.Scope) error {
+	if *in == nil {
+		*out = nil
+		return nil
+	}
+	*out = make(Prediction, len(*in))
+	for metric, ts := range *in {
+		samples, err := helper.ParseQuantityTimeSeries(v1alpha1.ResourceName(metric), ts)
+		if err != nil {
+			return fmt.Errorf("metric %s: %v", metric, err)
+		}
+		var series TimeSeries
+		if ts != nil {
+			series = make(TimeSeries, 0, len(samples))
+		}
+		for _, q := range samples {
+			series = append(series, Vector{Value: q.Value, Timestamp: q.Timestamp, LowerBound: q.LowerBound, UpperBound: q.UpperBound, Quantiles: q.Quantiles})
+		}
+		(*out)[metric] = series
+	}
+	return nil
+}
+
+// Convert_v1beta1_Prediction_To_v1alpha1_Prediction is the inverse of Convert_v1alpha1_Prediction_To_v1beta1_Prediction.
+func Convert_v1beta1_Prediction_To_v1alpha1_Prediction(in *Prediction, out *v1alpha1.Prediction, s conversion.Scope) error {
+	if *in == nil {
+		*out = nil
+		return nil
+	}
+	*out = make(v1alpha1.Prediction, len(*in))
+	for metric, ts := range *in {
+		var series v1alpha1.TimeSeries
+		if ts != nil {
+			series = make(v1alpha1.TimeSeries, 0, len(ts))
+		}
+		for i := range ts {
+			vector := &v1alpha1.Vector{}
+			convertVectorToV1alpha1(v1alpha1.ResourceName(metric), &ts[i], vector)
+			series = append(series, vector)
+		}
+		(*out)[metric] = series
+	}
+	return nil
+}
+
+// Convert_v1alpha1_MetricAccuracy_To_v1beta1_MetricAccuracy converts the rmse into a quantity according to the
+// unit of the metric, the mape and the coverage are plain numbers.
+func Convert_v1alpha1_MetricAccuracy_To_v1beta1_MetricAccuracy(in *v1alpha1.MetricAccuracy, out *MetricAccuracy, s conversion.Scope) error {
+	if err := autoConvert_v1alpha1_MetricAccuracy_To_v1beta1_MetricAccuracy(in, out, s); err != nil {
+		return err
+	}
+	resourceName := v1alpha1.ResourceName(in.MetricName)
+	rmse, err := helper.ParseValue(resourceName, in.RMSE)
+	if err != nil {
+		return fmt.Errorf("rmse of metric %s: %v", in.MetricName, err)
+	}
+	out.RMSE = helper.ToQuantity(resourceName, rmse)
+	out.MAPE, out.Coverage = nil, nil
+	if in.MAPE != "" {
+		out.MAPE = &resource.Quantity{}
+		if err := convertStringToQuantity(in.MAPE, out.MAPE); err != nil {
+			return fmt.Errorf("mape of metric %s: %v", in.MetricName, err)
+		}
+	}
+	if in.Coverage != "" {
+		out.Coverage = &resource.Quantity{}
+		if err := convertStringToQuantity(in.Coverage, out.Coverage); err != nil {
+			return fmt.Errorf("coverage of metric %s: %v", in.MetricName, err)
+		}
+	}
+	return nil
+}
+
+// Convert_v1beta1_MetricAccuracy_To_v1alpha1_MetricAccuracy is the inverse of
+// Convert_v1alpha1_MetricAccuracy_To_v1beta1_MetricAccuracy.
+func Convert_v1beta1_MetricAccuracy_To_v1alpha1_MetricAccuracy(in *MetricAccuracy, out *v1alpha1.MetricAccuracy, s conversion.Scope) error {
+	if err := autoConvert_v1beta1_MetricAccuracy_To_v1alpha1_MetricAccuracy(in, out, s); err != nil {
+		return err
+	}
+	out.RMSE = helper.FormatValue(helper.FromQuantity(v1alpha1.ResourceName(in.MetricName), in.RMSE))
+	out.MAPE, out.Coverage = "", ""
+	if in.MAPE != nil {
+		convertQuantityToString(in.MAPE, &out.MAPE)
+	}
+	if in.Coverage != nil {
+		convertQuantityToString(in.Coverage, &out.Coverage)
+	}
+	return nil
+}
+
+// Convert_v1alpha1_PodGroupPredictionStatus_To_v1beta1_PodGroupPredictionStatus splits the namespace/podname/containername
+// keys of the containers. A key that does not have three parts is kept as the container name.
+func Convert_v1alpha1_PodGroupPredictionStatus_To_v1beta1_PodGroupPredictionStatus(in *v1alpha1.PodGroupPredictionStatus, out *PodGroupPredictionStatus, s conversion.Scope) error {
+	if err := autoConvert_v1alpha1_PodGroupPredictionStatus_To_v1beta1_PodGroupPredictionStatus(in, out, s); err != nil {
+		return err
+	}
+	if in.Containers == nil {
+		out.Containers = nil
+		return nil
+	}
+	keys := make([]string, 0, len(in.Containers))
+	for key := range in.Containers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	out.Containers = make([]ContainerPrediction, 0, len(keys))
+	for _, key := range keys {
+		container := ContainerPrediction{ContainerName: key}
+		if parts := strings.Split(key, "/"); len(parts) == 3 {
+			container.Namespace, container.PodName, container.ContainerName = parts[0], parts[1], parts[2]
+		}
+		prediction := in.Containers[key]
+		if err := Convert_v1alpha1_Prediction_To_v1beta1_Prediction(&prediction, &container.Prediction, s); err != nil {
+			return fmt.Errorf("container %s: %v", key, err)
+		}
+		out.Containers = append(out.Containers, container)
+	}
+	return nil
+}
+
+// Convert_v1beta1_PodGroupPredictionStatus_To_v1alpha1_PodGroupPredictionStatus is the inverse of
+// Convert_v1alpha1_PodGroupPredictionStatus_To_v1beta1_PodGroupPredictionStatus.
+func Convert_v1beta1_PodGroupPredictionStatus_To_v1alpha1_PodGroupPredictionStatus(in *PodGroupPredictionStatus, out *v1alpha1.PodGroupPredictionStatus, s conversion.Scope) error {
+	if err := autoConvert_v1beta1_PodGroupPredictionStatus_To_v1alpha1_PodGroupPredictionStatus(in, out, s); err != nil {
+		return err
+	}
+	if in.Containers == nil {
+		out.Containers = nil
+		return nil
+	}
+	out.Containers = make(map[string]v1alpha1.Prediction, len(in.Containers))
+	for i := range in.Containers {
+		container := &in.Containers[i]
+		key := container.Key()
+		var prediction v1alpha1.Prediction
+		if err := Convert_v1beta1_Prediction_To_v1alpha1_Prediction(&container.Prediction, &prediction, s); err != nil {
+			return fmt.Errorf("container %s: %v", key, err)
+		}
+		out.Containers[key] = prediction
+	}
+	return nil
+}
+
+// Key returns the namespace/podname/containername key of the container, as used by v1alpha1.
+func (c *ContainerPrediction) Key() string {
+	if c.Namespace == "" && c.PodName == "" {
+		return c.ContainerName
+	}
+	return c.Namespace + "/" + c.PodName + "/" + c.ContainerName
+}
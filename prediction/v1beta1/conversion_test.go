@@ -0,0 +1,147 @@
+package v1beta1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gocrane-io/api/prediction/v1alpha1"
+)
+
+func TestPredictionConversionRoundTrip(t *testing.T) {
+	in := v1alpha1.Prediction{
+		"cpu": {
+			{Timestamp: 60, Value: "2500.4", LowerBound: "2400.25", UpperBound: "2600.75", Quantiles: v1alpha1.Quantiles{"p50": "2500.4", "p99": "0.0004"}},
+			{Timestamp: 120, Value: "0.0004"},
+			{Timestamp: 180, Value: "1000"},
+		},
+		"memory": {
+			{Timestamp: 60, Value: "1073741824"},
+			{Timestamp: 120, Value: "1536.5"},
+		},
+		"requests": {
+			{Timestamp: 60, Value: "0.0004"},
+			{Timestamp: 120, Value: "0.000000003"},
+			{Timestamp: 180, Value: "12.5"},
+		},
+	}
+	expected := map[string][]string{
+		"cpu":      {"2500400u", "400n", "1"},
+		"memory":   {"1Gi", "1536500m"},
+		"requests": {"400u", "3n", "12500m"},
+	}
+
+	var converted Prediction
+	if err := Convert_v1alpha1_Prediction_To_v1beta1_Prediction(&in, &converted, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for metric, values := range expected {
+		for i, value := range values {
+			if got := converted[metric][i].Value.String(); got != value {
+				t.Errorf("%s[%d]: expected %s, got %s", metric, i, value, got)
+			}
+		}
+	}
+	if got := converted["cpu"][0].Quantiles["p99"]; got.String() != "400n" {
+		t.Errorf("expected the p99 quantile 400n, got %s", got.String())
+	}
+
+	var back v1alpha1.Prediction
+	if err := Convert_v1beta1_Prediction_To_v1alpha1_Prediction(&converted, &back, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(in, back) {
+		t.Errorf("the prediction does not round trip, expected %v, got %v", in, back)
+	}
+}
+
+func TestSpecConversionRoundTrip(t *testing.T) {
+	in := v1alpha1.PodGroupPredictionSpec{
+		Mode: v1alpha1.PredictionModeRange,
+		Pods: []string{"web-0"},
+		MetricPredictionConfigs: []v1alpha1.AlgorithmProviderConfig{
+			{
+				MetricName: "cpu",
+				DSP: &v1alpha1.DspConfig{
+					SampleInterval: "1m",
+					Estimators: &v1alpha1.EstimatorConfigs{
+						HoltWinters:      &v1alpha1.HoltWintersEstimatorConfig{Alpha: "0.5", Beta: "0", SeasonLength: "24h"},
+						LinearRegression: &v1alpha1.LinearRegressionEstimatorConfig{},
+					},
+				},
+			},
+			{
+				MetricName: "memory",
+				Percentile: &v1alpha1.PercentileConfig{
+					SampleInterval: "1m30s",
+					Histogram:      v1alpha1.HistogramConfig{MaxValue: "10000", Epsilon: "1e-10", HalfLife: "1h30m"},
+				},
+			},
+			{
+				MetricName: "requests",
+				Custom:     &v1alpha1.CustomAlgorithmConfig{Name: "example.com/constant", HistoryLength: "168h"},
+			},
+		},
+	}
+
+	var converted PodGroupPredictionSpec
+	if err := Convert_v1alpha1_PodGroupPredictionSpec_To_v1beta1_PodGroupPredictionSpec(&in, &converted, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := converted.MetricPredictionConfigs[0].DSP.SampleInterval.Duration; got != time.Minute {
+		t.Errorf("expected the sample interval 1m, got %s", got)
+	}
+	if got := converted.MetricPredictionConfigs[0].DSP.HistoryLength.Duration; got != 0 {
+		t.Errorf("expected no history length, got %s", got)
+	}
+
+	var back v1alpha1.PodGroupPredictionSpec
+	if err := Convert_v1beta1_PodGroupPredictionSpec_To_v1alpha1_PodGroupPredictionSpec(&converted, &back, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(in, back) {
+		t.Errorf("the spec does not round trip, expected %+v, got %+v", in, back)
+	}
+}
+
+func TestConvertDurationToString(t *testing.T) {
+	cases := map[time.Duration]string{
+		0:                              "",
+		30 * time.Second:               "30s",
+		time.Minute:                    "1m",
+		90 * time.Second:               "1m30s",
+		24 * time.Hour:                 "24h",
+		time.Hour + 30*time.Minute:     "1h30m",
+		time.Hour + time.Second:        "1h0m1s",
+		1500 * time.Millisecond:        "1.5s",
+		time.Minute + time.Millisecond: "1m0.001s",
+	}
+	for d, expected := range cases {
+		var out string
+		convertDurationToString(&metav1.Duration{Duration: d}, &out)
+		if out != expected {
+			t.Errorf("%d: expected %q, got %q", d, expected, out)
+			continue
+		}
+		if parsed, err := time.ParseDuration(out); out != "" && (err != nil || parsed != d) {
+			t.Errorf("%q: expected to parse to %s, got %s, %v", out, d, parsed, err)
+		}
+	}
+}
+
+func TestQuantilesConversionRoundTrip(t *testing.T) {
+	in := v1alpha1.Quantiles{"p50": "0.0004", "p90": "2500.4"}
+	var converted Quantiles
+	if err := Convert_v1alpha1_Quantiles_To_v1beta1_Quantiles(&in, &converted, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var back v1alpha1.Quantiles
+	if err := Convert_v1beta1_Quantiles_To_v1alpha1_Quantiles(&converted, &back, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(in, back) {
+		t.Errorf("the quantiles do not round trip, expected %v, got %v", in, back)
+	}
+}
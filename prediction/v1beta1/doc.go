@@ -0,0 +1,5 @@
+// Package v1beta1 is the v1beta1 version of the crane API.
+// +k8s:deepcopy-gen=package,register
+// +k8s:conversion-gen=github.com/gocrane-io/api/prediction/v1alpha1
+// +groupName=prediction.crane.io
+package v1beta1
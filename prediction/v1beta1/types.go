@@ -0,0 +1,559 @@
+package v1beta1
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2beta2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ResourceName represents the name of the resource.
+type ResourceName string
+
+const (
+	// ResourceCPU represents CPU in cores, the values are quantities such as 500m.
+	ResourceCPU ResourceName = "cpu"
+	// ResourceMemory represents memory in bytes, the values are quantities such as 500Gi.
+	ResourceMemory ResourceName = "memory"
+)
+
+// PredictionMode represents the prediction time series mode.
+// +kubebuilder:validation:Enum=instant;range
+type PredictionMode string
+
+const (
+	// PredictionModeInstant means predicting a single point in the future, for example the maximum value for the next hour
+	PredictionModeInstant PredictionMode = "instant"
+	// PredictionModeRange means predicting a time series during a range of time in the future.
+	PredictionModeRange PredictionMode = "range"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=np,categories=crane
+// +kubebuilder:unservedversion
+// +kubebuilder:printcolumn:name="Node",type=string,JSONPath=".spec.nodeName",description="The node the prediction is associated with"
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".spec.mode",description="The prediction time series mode"
+// +kubebuilder:printcolumn:name="Period",type=string,JSONPath=".spec.period",description="The prediction time series interval"
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=".status.status",description="The status of the prediction routine"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Last Update",type=date,JSONPath=".status.lastUpdateTime",description="The last time the prediction data was updated"
+
+// NodePrediction is the node prediction resource. it is associated with a node and is cluster scoped like the node.
+type NodePrediction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NodePredictionResourceSpec `json:"spec"`
+
+	// +optional
+	Status NodePredictionResourceStatus `json:"status,omitempty"`
+}
+
+// NodePredictionResourceSpec is a description of a NodePrediction.
+type NodePredictionResourceSpec struct {
+	// NodeName is the name of the node the prediction is associated with.
+	// +kubebuilder:validation:MinLength=1
+	NodeName string `json:"nodeName"`
+	// Period is the prediction time series interval or step.
+	Period metav1.Duration `json:"period"`
+	// Mode is the prediction time series mode
+	Mode PredictionMode `json:"mode"`
+	// MetricPredictionConfigs is the prediction configs of metric. each metric has its config for different prediction behaviors
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=metricName
+	MetricPredictionConfigs []AlgorithmProviderConfig `json:"metricPredictionConfigs"`
+}
+
+// NodePredictionResourceStatus is the status of a NodePrediction.
+type NodePredictionResourceStatus struct {
+	// ObservedGeneration is the generation of the spec the status was computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions is the condition of NodePrediction
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []PredictionCondition `json:"conditions,omitempty"`
+	// Status is the phase of the prediction routine, it summarizes the conditions.
+	// +optional
+	Status PredictionStatus `json:"status,omitempty"`
+	// LastUpdateTime is the last time the prediction data was updated.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+	// Estimators are the estimators that produced the predicted series of the metrics.
+	// +optional
+	// +listType=map
+	// +listMapKey=metricName
+	Estimators []MetricEstimator `json:"estimators,omitempty"`
+	// Accuracy is the accuracy of the past predictions of the metrics, evaluated against their observed values.
+	// +optional
+	// +listType=map
+	// +listMapKey=metricName
+	Accuracy []MetricAccuracy `json:"accuracy,omitempty"`
+	// Consumed is the predicted resource usage in next resolution point based on past time series.
+	// +optional
+	Consumed Prediction `json:"consumed,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=pgp,categories=crane
+// +kubebuilder:unservedversion
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=".spec.mode",description="The prediction time series mode"
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=".status.status",description="The status of the prediction routine"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Last Update",type=date,JSONPath=".status.lastUpdateTime",description="The last time the prediction data was updated"
+
+// PodGroupPrediction is a prediction on the resource consumed by a pod group.
+// In kubernetes context, a pod group often refers to a batch of pods that satisfy a label selector.
+type PodGroupPrediction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PodGroupPredictionSpec `json:"spec"`
+
+	// +optional
+	Status PodGroupPredictionStatus `json:"status,omitempty"`
+}
+
+type PredictionStatus string
+
+const (
+	// PredictionStatusCharging means no valid prediction series is available, just wait to predicting.
+	PredictionStatusCharging PredictionStatus = "Charging"
+	// PredictionStatusPredicting means the prediction routine is ongoing and the prediction data is valid.
+	PredictionStatusPredicting PredictionStatus = "Predicting"
+	// PredictionStatusNotStarted means the prediction routine has not started yet.
+	PredictionStatusNotStarted PredictionStatus = "NotStarted"
+	// PredictionStatusFinished means the prediction has finished.
+	PredictionStatusFinished PredictionStatus = "Finished"
+	// PredictionStatusStale means the prediction data has not been updated in time and may be outdated.
+	PredictionStatusStale PredictionStatus = "Stale"
+	// PredictionStatusFailed means the prediction routine failed, the prediction data is not valid.
+	PredictionStatusFailed PredictionStatus = "Failed"
+)
+
+// PodGroupPredictionSpec is a description of a PodGroupPrediction.
+type PodGroupPredictionSpec struct {
+	// Prediction start time. If not specified, the prediction routine will start as soon as the CR is created.
+	// +optional
+	Start *metav1.Time `json:"start,omitempty"`
+	// Prediction end time, after which the prediction routine will stop, and the prediction data will not get updated any more.
+	// If not specified, the prediction process will keep running forever.
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
+	// PredictionLength, for example, 24-hours means predicting time series in next 24 hours. This should be used only for PredictionModeRange.
+	// +optional
+	PredictionLength metav1.Duration `json:"predictionLength,omitempty"`
+	// Prediction mode
+	Mode PredictionMode `json:"mode"`
+	// Pods is a list of pod names that belong to this pod group. If not specified then WorkloadRef is invalid. the aggregator aggregate priority is  Pods > WorkloadRef > LabelSelector
+	// +optional
+	// +listType=set
+	Pods []string `json:"pods,omitempty"`
+	// WorkloadRef is a ref of workload(deployment/statefulsets).
+	// +optional
+	WorkloadRef *autoscalingv2.CrossVersionObjectReference `json:"workloadRef,omitempty"`
+	// LabelSelector is the aggregator label selector. aggregator group all data by same key . for example, [online: label=v1] denotes all pods with label label=v1 will aggregate by sum all the resources.
+	// +optional
+	LabelSelector metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// MetricPredictionConfigs is the prediction configs of metric. each metric has its config for different prediction behaviors
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=metricName
+	MetricPredictionConfigs []AlgorithmProviderConfig `json:"metricPredictionConfigs"`
+}
+
+// PodGroupPredictionStatus is the status of a PodGroupPrediction.
+type PodGroupPredictionStatus struct {
+	// Conditions is the condition of PodGroupPrediction
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []PredictionCondition `json:"conditions,omitempty"`
+	// Status
+	// +optional
+	Status PredictionStatus `json:"status,omitempty"`
+	// LastUpdateTime is the last time the prediction data was updated.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+	// Estimators are the estimators that produced the predicted series of the metrics.
+	// +optional
+	// +listType=map
+	// +listMapKey=metricName
+	Estimators []MetricEstimator `json:"estimators,omitempty"`
+	// Accuracy is the accuracy of the past aggregated predictions of the metrics, evaluated against their
+	// observed values.
+	// +optional
+	// +listType=map
+	// +listMapKey=metricName
+	Accuracy []MetricAccuracy `json:"accuracy,omitempty"`
+	// Aggregation is the aggregated prediction value of all pods.
+	// +optional
+	Aggregation Prediction `json:"aggregation,omitempty"`
+	// Containers is all the containers in pod group. excludes pause container.
+	// +optional
+	// +listType=map
+	// +listMapKey=namespace
+	// +listMapKey=podName
+	// +listMapKey=containerName
+	Containers []ContainerPrediction `json:"containers,omitempty"`
+}
+
+// ContainerPrediction is the prediction of a container of the pod group.
+type ContainerPrediction struct {
+	// Namespace is the namespace of the pod.
+	Namespace string `json:"namespace"`
+	// PodName is the name of the pod.
+	PodName string `json:"podName"`
+	// ContainerName is the name of the container.
+	ContainerName string `json:"containerName"`
+	// Prediction is the prediction value of the container.
+	// +optional
+	Prediction Prediction `json:"prediction,omitempty"`
+}
+
+// MetricEstimator records the estimator that produced the predicted series of a metric.
+type MetricEstimator struct {
+	// MetricName is the name of the metric.
+	MetricName string `json:"metricName"`
+	// Estimator is the algorithm followed by the estimator it ran in parentheses, for example dsp(fft), or the
+	// weighted members of an ensemble, for example ensemble(dsp(fft):2,percentile:1). The different estimators
+	// of the containers of a pod group are sorted and separated by semicolons.
+	Estimator string `json:"estimator"`
+}
+
+// MetricAccuracy is the accuracy of the predictions of a metric accumulated over a rolling window, every
+// predicted value is compared once with its observed value.
+type MetricAccuracy struct {
+	// MetricName is the name of the metric.
+	MetricName string `json:"metricName"`
+	// MAPE is the mean absolute percentage error of the predicted values as a fraction, 0.1 is 10%. The observed
+	// values of zero are left out, it is unset when all are zero.
+	// +optional
+	MAPE *resource.Quantity `json:"mape,omitempty"`
+	// RMSE is the root mean square error of the predicted values, in the unit of the metric.
+	RMSE resource.Quantity `json:"rmse"`
+	// Coverage is the fraction of the observed values within the bounds of their predicted vector, it is unset
+	// when the predicted vectors have no bounds.
+	// +optional
+	Coverage *resource.Quantity `json:"coverage,omitempty"`
+	// Samples is the number of predicted values that were compared.
+	Samples int32 `json:"samples"`
+	// Window is the time range of the compared values.
+	Window EvaluationWindow `json:"window"`
+}
+
+// EvaluationWindow is the time range of an evaluation, both ends included.
+type EvaluationWindow struct {
+	// Start is the time of the first compared value.
+	Start metav1.Time `json:"start"`
+	// End is the time of the last compared value.
+	End metav1.Time `json:"end"`
+}
+
+// PredictionConditionType is a valid value for PredictionCondition.Type
+type PredictionConditionType string
+
+// These are valid conditions of NodePrediction and PodGroupPrediction.
+const (
+	// PredictionConditionCharging means no valid prediction series is available, just wait to predict.
+	PredictionConditionCharging PredictionConditionType = "Charging"
+	// PredictionConditionPredicting means the prediction routine is ongoing and the prediction data is valid.
+	PredictionConditionPredicting PredictionConditionType = "Predicting"
+	// PredictionConditionNotStarted means the prediction routine has not started yet.
+	PredictionConditionNotStarted PredictionConditionType = "NotStarted"
+	// PredictionConditionFinished means the prediction has finished, the prediction data will not be updated anymore.
+	PredictionConditionFinished PredictionConditionType = "Finished"
+	// PredictionConditionStale means the prediction data has not been updated in time and may be outdated.
+	PredictionConditionStale PredictionConditionType = "Stale"
+	// PredictionConditionFailed means the prediction routine failed, the reason and message tell why.
+	PredictionConditionFailed PredictionConditionType = "Failed"
+)
+
+// PredictionCondition contains details for the current condition of a prediction.
+type PredictionCondition struct {
+	// Type is the type of the condition.
+	Type PredictionConditionType `json:"type,omitempty"`
+	// Status is the status of the condition.
+	// Can be True, False, Unknown.
+	Status v1.ConditionStatus `json:"status,omitempty"`
+	// Last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// Last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// Prediction define metrics prediction, keyed by the metric name.
+type Prediction map[string]TimeSeries
+
+// TimeSeries is a list of vectors sorted by timestamp.
+type TimeSeries []Vector
+
+// Vector is a point of a time series.
+type Vector struct {
+	// Value is the value of the metric, in the unit of the resource. For example 500m for cpu or 1Gi for memory.
+	Value resource.Quantity `json:"value"`
+	// Timestamp is the unix timestamp of the point, in seconds.
+	Timestamp int64 `json:"timestamp"`
+	// LowerBound is the lower bound of the confidence interval of the value, when the estimator provides one.
+	// +optional
+	LowerBound *resource.Quantity `json:"lowerBound,omitempty"`
+	// UpperBound is the upper bound of the confidence interval of the value, when the estimator provides one.
+	// +optional
+	UpperBound *resource.Quantity `json:"upperBound,omitempty"`
+	// Quantiles are the estimated quantiles of the value keyed by name, for example p50, p90 and p99, when the
+	// estimator provides them.
+	// +optional
+	Quantiles Quantiles `json:"quantiles,omitempty"`
+}
+
+// Quantiles are estimated quantiles keyed by name, p followed by the percentile.
+type Quantiles map[string]resource.Quantity
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodePredictionList is a list of NodePrediction resources
+type NodePredictionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []NodePrediction `json:"items"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PodGroupPredictionList is a list of PodGroupPrediction
+type PodGroupPredictionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []PodGroupPrediction `json:"items"`
+}
+
+// AlgorithmProviderConfig is the prediction config of a metric, exactly one algorithm must be specified.
+type AlgorithmProviderConfig struct {
+	// +kubebuilder:validation:MinLength=1
+	MetricName string `json:"metricName"`
+	// +optional
+	DSP *DspConfig `json:"dsp,omitempty"`
+	// +optional
+	Percentile *PercentileConfig `json:"percentile,omitempty"`
+	// Custom selects an algorithm registered by name in the prediction controller.
+	// +optional
+	Custom *CustomAlgorithmConfig `json:"custom,omitempty"`
+	// Ensemble predicts the metric with the weighted mean of several algorithms, instead of dsp, percentile
+	// or custom.
+	// +optional
+	Ensemble *EnsembleConfig `json:"ensemble,omitempty"`
+	// Fallbacks are the algorithms tried in order when the algorithm of the metric, or every member of its
+	// ensemble, cannot predict the history because it is too short or not periodic.
+	// +optional
+	Fallbacks []AlgorithmConfig `json:"fallbacks,omitempty"`
+	// Source is where the history of the metric is queried from. When unset, cpu and memory are the resource
+	// metrics of the target and any other metric name is an external metric without selector.
+	// +optional
+	Source *MetricSourceSpec `json:"source,omitempty"`
+}
+
+// MetricSourceType is the type of the source of a metric.
+// +kubebuilder:validation:Enum=Resource;Prometheus;External
+type MetricSourceType string
+
+const (
+	// ResourceMetricSourceType is a resource metric of the target, such as its cpu usage.
+	ResourceMetricSourceType MetricSourceType = "Resource"
+	// PrometheusMetricSourceType is a Prometheus query expression evaluated for the target.
+	PrometheusMetricSourceType MetricSourceType = "Prometheus"
+	// ExternalMetricSourceType is a metric not associated with the target, selected by name and labels.
+	ExternalMetricSourceType MetricSourceType = "External"
+)
+
+// MetricSourceSpec is the source of the history of a metric, the field matching its type must be specified
+// and the others must not.
+type MetricSourceSpec struct {
+	// Type is the type of the source.
+	Type MetricSourceType `json:"type"`
+	// Resource is the resource metric of the target, for the Resource type.
+	// +optional
+	Resource *ResourceMetricSource `json:"resource,omitempty"`
+	// Prometheus is the query of the metric, for the Prometheus type.
+	// +optional
+	Prometheus *PrometheusMetricSource `json:"prometheus,omitempty"`
+	// External is the external metric, for the External type.
+	// +optional
+	External *ExternalMetricSource `json:"external,omitempty"`
+}
+
+// ResourceMetricSource is a resource metric of the target of the prediction, a container of a pod group or a node.
+type ResourceMetricSource struct {
+	// Name is the name of the resource, cpu or memory.
+	Name ResourceName `json:"name"`
+}
+
+// PrometheusMetricSource is a Prometheus query expression. The ${namespace}, ${pod}, ${container} and ${node}
+// variables are replaced with the target of the prediction before the query is evaluated.
+type PrometheusMetricSource struct {
+	// Query is the query expression, for example
+	// sum(rate(container_cpu_usage_seconds_total{namespace="${namespace}",pod="${pod}"}[5m])).
+	// +kubebuilder:validation:MinLength=1
+	Query string `json:"query"`
+}
+
+// ExternalMetricSource is a metric that is not associated with the target of the prediction, for example the
+// length of a queue. The series of the metric matching the selector are summed.
+type ExternalMetricSource struct {
+	// MetricName is the name of the external metric.
+	// +kubebuilder:validation:MinLength=1
+	MetricName string `json:"metricName"`
+	// Selector selects the series of the metric by their labels, all of them when unset.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// AlgorithmConfig selects a single algorithm, exactly one of its fields must be specified.
+type AlgorithmConfig struct {
+	// +optional
+	DSP *DspConfig `json:"dsp,omitempty"`
+	// +optional
+	Percentile *PercentileConfig `json:"percentile,omitempty"`
+	// +optional
+	Custom *CustomAlgorithmConfig `json:"custom,omitempty"`
+}
+
+// EnsembleConfig is the config of a weighted ensemble of algorithms.
+type EnsembleConfig struct {
+	// Members are the algorithms of the ensemble. The members that cannot predict the history are left out
+	// and the weights of the others are normalized.
+	// +kubebuilder:validation:MinItems=1
+	Members []EnsembleMember `json:"members"`
+}
+
+// EnsembleMember is an algorithm of an ensemble and its weight.
+type EnsembleMember struct {
+	AlgorithmConfig `json:",inline"`
+	// Weight is the weight of the member relative to the other members, 1 by default.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Weight int32 `json:"weight,omitempty"`
+}
+
+// CustomAlgorithmConfig is the config of an algorithm registered by name in the prediction controller.
+type CustomAlgorithmConfig struct {
+	// Name is the name the algorithm is registered with.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// SampleInterval is the sampling interval of metrics.
+	// +optional
+	SampleInterval metav1.Duration `json:"sampleInterval,omitempty"`
+	// HistoryLength describes how long back should be queried against provider to get historical metrics for prediction.
+	// +optional
+	HistoryLength metav1.Duration `json:"historyLength,omitempty"`
+	// Parameters are the parameters of the algorithm, their schema is defined by the algorithm.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Parameters *runtime.RawExtension `json:"parameters,omitempty"`
+}
+
+// DspConfig is the config of the DSP algorithm.
+type DspConfig struct {
+	// SampleInterval is the sampling interval of metrics.
+	SampleInterval metav1.Duration `json:"sampleInterval"`
+	// HistoryLength describes how long back should be queried against provider to get historical metrics for prediction.
+	HistoryLength metav1.Duration `json:"historyLength"`
+	// Estimators
+	Estimators *EstimatorConfigs `json:"estimators"`
+}
+
+type EstimatorConfigs struct {
+	// +optional
+	MaxValue *MaxValueEstimatorConfig `json:"maxValue,omitempty"`
+	// +optional
+	FFT *FFTEstimatorConfig `json:"fft,omitempty"`
+	// +optional
+	HoltWinters *HoltWintersEstimatorConfig `json:"holtWinters,omitempty"`
+	// +optional
+	LinearRegression *LinearRegressionEstimatorConfig `json:"linearRegression,omitempty"`
+}
+
+type MaxValueEstimatorConfig struct{}
+
+// FFTEstimatorConfig is the config of the FFT estimator, which extends the dominant frequencies of the history.
+type FFTEstimatorConfig struct {
+	// MarginFraction is the fraction added on top of the estimation, which is multiplied by 1+MarginFraction.
+	MarginFraction resource.Quantity `json:"marginFraction"`
+	// LowAmplitudeThreshold is the amplitude, in the unit of the metric, under which the spectrum items beyond the
+	// MinNumOfSpectrumItems largest ones are dropped. The history is not periodic if no item reaches it.
+	LowAmplitudeThreshold resource.Quantity `json:"lowAmplitudeThreshold"`
+	// HighFrequencyThreshold is the frequency, in Hz, above which the spectrum items are dropped.
+	HighFrequencyThreshold resource.Quantity `json:"highFrequencyThreshold"`
+	// MinNumOfSpectrumItems is the number of spectrum items of largest amplitude that are kept whatever their amplitude.
+	// +kubebuilder:validation:Minimum=1
+	MinNumOfSpectrumItems int32 `json:"minNumOfSpectrumItems"`
+	// MaxNumOfSpectrumItems is the maximum number of spectrum items kept.
+	// +kubebuilder:validation:Minimum=1
+	MaxNumOfSpectrumItems int32 `json:"maxNumOfSpectrumItems"`
+}
+
+// HoltWintersEstimatorConfig is the config of the Holt-Winters estimator, which smooths the level, the trend and
+// the additive seasonality of the history and extends them.
+type HoltWintersEstimatorConfig struct {
+	// Alpha is the smoothing factor of the level, in [0, 1].
+	Alpha resource.Quantity `json:"alpha"`
+	// Beta is the smoothing factor of the trend, in [0, 1].
+	Beta resource.Quantity `json:"beta"`
+	// Gamma is the smoothing factor of the seasonality, in [0, 1].
+	Gamma resource.Quantity `json:"gamma"`
+	// SeasonLength is the length of a season, a multiple of the sample interval. The history must cover at least
+	// two seasons.
+	SeasonLength metav1.Duration `json:"seasonLength"`
+}
+
+// LinearRegressionEstimatorConfig is the config of the linear regression estimator, which extends the least
+// squares line of the history.
+type LinearRegressionEstimatorConfig struct {
+	// Window is the length of the most recent history the line is fitted to, the whole history when unset.
+	// +optional
+	Window metav1.Duration `json:"window,omitempty"`
+}
+
+// PercentileConfig is the config of the percentile algorithm.
+type PercentileConfig struct {
+	SampleInterval metav1.Duration `json:"sampleInterval"`
+	Histogram      HistogramConfig `json:"histogram"`
+	// MinSampleWeight is the weight a sample of a smaller weight is raised to.
+	MinSampleWeight resource.Quantity `json:"minSampleWeight"`
+}
+
+// HistogramConfig is the config of a decaying histogram.
+type HistogramConfig struct {
+	// MaxValue is the largest value the histogram distinguishes, larger values fall into the last bucket.
+	MaxValue resource.Quantity `json:"maxValue"`
+	// Epsilon is the weight under which a bucket is considered empty. It is kept as a decimal string since it is
+	// usually smaller than the nano precision of a quantity.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`
+	Epsilon string `json:"epsilon"`
+	// HalfLife is the time after which the weight of a sample is halved.
+	HalfLife metav1.Duration `json:"halfLife"`
+	// BucketSize is the size of the buckets when BucketSizeGrowthRatio is zero.
+	BucketSize resource.Quantity `json:"bucketSize"`
+	// FirstBucketSize is the size of the first bucket when BucketSizeGrowthRatio is positive.
+	FirstBucketSize resource.Quantity `json:"firstBucketSize"`
+	// BucketSizeGrowthRatio is the ratio by which the size of a bucket grows over the previous one, zero for
+	// buckets of a fixed size.
+	BucketSizeGrowthRatio resource.Quantity `json:"bucketSizeGrowthRatio"`
+}
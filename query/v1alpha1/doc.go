@@ -0,0 +1,5 @@
+// Package v1alpha1 is the v1alpha1 version of the query API of crane, served by the prediction API server
+// rather than by CRDs: the objects are not stored, they are answered on demand.
+// +k8s:deepcopy-gen=package,register
+// +groupName=query.prediction.crane.io
+package v1alpha1
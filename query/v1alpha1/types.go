@@ -0,0 +1,68 @@
+package v1alpha1
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	predictionv1beta1 "github.com/gocrane-io/api/prediction/v1beta1"
+)
+
+// MaxForecastPoints is the largest number of points a query may forecast.
+const MaxForecastPoints = 11000
+
+// +genclient
+// +genclient:onlyVerbs=create
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PredictionQuery asks for the forecast of a metric of a prediction over a time window. It is not stored: the
+// server forecasts the metric with the configs of the prediction when the query is created, and returns the
+// query with the forecast in its status.
+type PredictionQuery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PredictionQuerySpec `json:"spec"`
+
+	// +optional
+	Status PredictionQueryStatus `json:"status,omitempty"`
+}
+
+// PredictionQuerySpec selects the prediction, the metric and the window of a forecast. Exactly one of
+// PodGroupPrediction, WorkloadRef and NodePrediction must be specified.
+type PredictionQuerySpec struct {
+	// PodGroupPrediction is the name of the PodGroupPrediction, in the namespace of the query, whose configs
+	// forecast the metric.
+	// +optional
+	PodGroupPrediction string `json:"podGroupPrediction,omitempty"`
+	// WorkloadRef selects the PodGroupPrediction of a workload, in the namespace of the query, by its WorkloadRef.
+	// +optional
+	WorkloadRef *autoscalingv2.CrossVersionObjectReference `json:"workloadRef,omitempty"`
+	// NodePrediction is the name of the NodePrediction whose configs forecast the metric.
+	// +optional
+	NodePrediction string `json:"nodePrediction,omitempty"`
+	// MetricName is the name of the metric, it must be configured by the prediction.
+	MetricName string `json:"metricName"`
+	// Start is the start of the window, the time of the query when unset.
+	// +optional
+	Start *metav1.Time `json:"start,omitempty"`
+	// End is the end of the window. When unset, the window spans the PredictionLength of the PodGroupPrediction,
+	// or 24 hours for a NodePrediction.
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
+	// Step is the interval between the forecast points, the sample interval of the metric when unset. The window
+	// must not hold more than MaxForecastPoints multiples of the step.
+	// +optional
+	Step metav1.Duration `json:"step,omitempty"`
+}
+
+// PredictionQueryStatus is the forecast answering a PredictionQuery.
+type PredictionQueryStatus struct {
+	// Estimators are the estimators that produced the forecast, sorted. A pod group may have several, one per
+	// estimator of its containers.
+	// +optional
+	Estimators []string `json:"estimators,omitempty"`
+	// TimeSeries is the forecast of the metric at the multiples of the step within the window, the sum of the
+	// containers for a pod group. The values are quantities in the unit of the metric as in the v1beta1 API.
+	// +optional
+	TimeSeries predictionv1beta1.TimeSeries `json:"timeSeries,omitempty"`
+}
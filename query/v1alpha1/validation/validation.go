@@ -0,0 +1,70 @@
+// Package validation validates prediction queries.
+package validation
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/gocrane-io/api/query/v1alpha1"
+)
+
+// ValidatePredictionQuery validates a PredictionQuery. Its metadata is not validated since queries are never
+// stored, a query may even have no name.
+func ValidatePredictionQuery(query *v1alpha1.PredictionQuery) field.ErrorList {
+	return ValidatePredictionQuerySpec(&query.Spec, field.NewPath("spec"))
+}
+
+// ValidatePredictionQuerySpec validates the spec of a PredictionQuery: exactly one prediction must be
+// selected, the window must not end before it starts, and a window whose start, end and step are set must not hold
+// more than MaxForecastPoints points.
+func ValidatePredictionQuerySpec(spec *v1alpha1.PredictionQuerySpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	targets := 0
+	if spec.PodGroupPrediction != "" {
+		targets++
+	}
+	if spec.WorkloadRef != nil {
+		targets++
+		refPath := fldPath.Child("workloadRef")
+		if spec.WorkloadRef.Kind == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("kind"), ""))
+		}
+		if spec.WorkloadRef.Name == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), ""))
+		}
+	}
+	if spec.NodePrediction != "" {
+		targets++
+	}
+	switch {
+	case targets == 0:
+		allErrs = append(allErrs, field.Required(fldPath, "one of podGroupPrediction, workloadRef and nodePrediction must be specified"))
+	case targets > 1:
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only one of podGroupPrediction, workloadRef and nodePrediction may be specified"))
+	}
+
+	if spec.MetricName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("metricName"), ""))
+	}
+	if spec.Start != nil && spec.End != nil && spec.End.Before(spec.Start) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("end"), spec.End.String(), "must not be before start"))
+	}
+	if step := spec.Step.Duration; step != 0 && step < time.Second {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("step"), spec.Step.String(), "must be at least 1s"))
+	} else if step != 0 && spec.Start != nil && spec.End != nil && !spec.End.Before(spec.Start) {
+		// the points are the multiples of the step within the window, as the forecaster computes them.
+		seconds := int64(step / time.Second)
+		first, last := spec.Start.Unix(), spec.End.Unix()
+		if r := first % seconds; r != 0 {
+			first += seconds - r
+		}
+		if last >= first && (last-first)/seconds+1 > v1alpha1.MaxForecastPoints {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("step"), spec.Step.String(),
+				fmt.Sprintf("must not split the window into more than %d points", v1alpha1.MaxForecastPoints)))
+		}
+	}
+	return allErrs
+}
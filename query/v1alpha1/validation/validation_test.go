@@ -0,0 +1,161 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/gocrane-io/api/query/v1alpha1"
+)
+
+var start = metav1.NewTime(time.Date(2021, 11, 24, 8, 0, 0, 0, time.UTC))
+
+func newPredictionQuery() *v1alpha1.PredictionQuery {
+	return &v1alpha1.PredictionQuery{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+		Spec:       v1alpha1.PredictionQuerySpec{PodGroupPrediction: "web", MetricName: "cpu"},
+	}
+}
+
+// window sets the window of the query to span the duration from start, every step.
+func window(query *v1alpha1.PredictionQuery, duration, step time.Duration) {
+	end := metav1.NewTime(start.Add(duration))
+	query.Spec.Start, query.Spec.End, query.Spec.Step = &start, &end, metav1.Duration{Duration: step}
+}
+
+// expectErrors checks that the errors are exactly the expected ones, each given as its type and field.
+func expectErrors(t *testing.T, name string, errs field.ErrorList, expected ...field.Error) {
+	t.Helper()
+	if len(errs) != len(expected) {
+		t.Errorf("%s: expected %d errors, got %v", name, len(expected), errs)
+		return
+	}
+	for i := range expected {
+		if errs[i].Type != expected[i].Type || errs[i].Field != expected[i].Field {
+			t.Errorf("%s: expected the error %s on %s, got %v", name, expected[i].Type, expected[i].Field, errs[i])
+		}
+	}
+}
+
+func TestValidatePredictionQuery(t *testing.T) {
+	cases := []struct {
+		name     string
+		update   func(query *v1alpha1.PredictionQuery)
+		expected []field.Error
+	}{
+		{
+			name:   "valid",
+			update: func(query *v1alpha1.PredictionQuery) {},
+		},
+		{
+			name: "workloadRef",
+			update: func(query *v1alpha1.PredictionQuery) {
+				query.Spec.PodGroupPrediction = ""
+				query.Spec.WorkloadRef = &autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web"}
+			},
+		},
+		{
+			name: "workloadRef without kind and name",
+			update: func(query *v1alpha1.PredictionQuery) {
+				query.Spec.PodGroupPrediction = ""
+				query.Spec.WorkloadRef = &autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1"}
+			},
+			expected: []field.Error{
+				{Type: field.ErrorTypeRequired, Field: "spec.workloadRef.kind"},
+				{Type: field.ErrorTypeRequired, Field: "spec.workloadRef.name"},
+			},
+		},
+		{
+			name: "nodePrediction",
+			update: func(query *v1alpha1.PredictionQuery) {
+				query.Namespace, query.Spec.PodGroupPrediction, query.Spec.NodePrediction = "", "", "node-1"
+			},
+		},
+		{
+			name: "no prediction",
+			update: func(query *v1alpha1.PredictionQuery) {
+				query.Spec.PodGroupPrediction = ""
+			},
+			expected: []field.Error{{Type: field.ErrorTypeRequired, Field: "spec"}},
+		},
+		{
+			name: "two predictions",
+			update: func(query *v1alpha1.PredictionQuery) {
+				query.Spec.NodePrediction = "node-1"
+			},
+			expected: []field.Error{{Type: field.ErrorTypeForbidden, Field: "spec"}},
+		},
+		{
+			name: "no metric name",
+			update: func(query *v1alpha1.PredictionQuery) {
+				query.Spec.MetricName = ""
+			},
+			expected: []field.Error{{Type: field.ErrorTypeRequired, Field: "spec.metricName"}},
+		},
+		{
+			name: "window",
+			update: func(query *v1alpha1.PredictionQuery) {
+				window(query, time.Hour, time.Minute)
+			},
+		},
+		{
+			name: "end equal to start",
+			update: func(query *v1alpha1.PredictionQuery) {
+				window(query, 0, time.Minute)
+			},
+		},
+		{
+			name: "end before start",
+			update: func(query *v1alpha1.PredictionQuery) {
+				window(query, -time.Hour, time.Minute)
+			},
+			expected: []field.Error{{Type: field.ErrorTypeInvalid, Field: "spec.end"}},
+		},
+		{
+			name: "only a start",
+			update: func(query *v1alpha1.PredictionQuery) {
+				query.Spec.Start = &start
+			},
+		},
+		{
+			name: "step of a second",
+			update: func(query *v1alpha1.PredictionQuery) {
+				window(query, time.Hour, time.Second)
+			},
+		},
+		{
+			name: "step under a second",
+			update: func(query *v1alpha1.PredictionQuery) {
+				window(query, time.Hour, 500*time.Millisecond)
+			},
+			expected: []field.Error{{Type: field.ErrorTypeInvalid, Field: "spec.step"}},
+		},
+		{
+			name: "maximum number of points",
+			update: func(query *v1alpha1.PredictionQuery) {
+				window(query, (v1alpha1.MaxForecastPoints-1)*time.Minute, time.Minute)
+			},
+		},
+		{
+			name: "too many points",
+			update: func(query *v1alpha1.PredictionQuery) {
+				window(query, v1alpha1.MaxForecastPoints*time.Minute, time.Minute)
+			},
+			expected: []field.Error{{Type: field.ErrorTypeInvalid, Field: "spec.step"}},
+		},
+		{
+			name: "default step, checked by the forecaster",
+			update: func(query *v1alpha1.PredictionQuery) {
+				window(query, v1alpha1.MaxForecastPoints*time.Minute, 0)
+			},
+		},
+	}
+	for _, c := range cases {
+		query := newPredictionQuery()
+		c.update(query)
+		expectErrors(t, c.name, ValidatePredictionQuery(query), c.expected...)
+	}
+}